@@ -4,11 +4,17 @@ import (
 	"bufio"
 	"fmt"
 	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/report"
 	"github.com/derekimcheng/mj/rules"
-	"github.com/derekimcheng/mj/rules/zj"
 	"github.com/derekimcheng/mj/shorthand"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"io"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 var outSourceTypeOptionsStr = "d, sd, sdr, ak, ih"
@@ -22,26 +28,95 @@ var outSourceTypeMap = map[string]rules.OutTileSourceType{
 
 // PlayerStateAnalyzer analyzes the given player state and scores it.
 type PlayerStateAnalyzer struct {
+	ruleSet         rules.RuleSet
 	scanner         *bufio.Scanner
 	shortHandParser *shorthand.Parser
 }
 
-// NewPlayerStateAnalyzer returns a new PlayerStateAnalyzer.
-// TODO: add rules to input?
-func NewPlayerStateAnalyzer(reader io.Reader) *PlayerStateAnalyzer {
+// NewPlayerStateAnalyzer returns a new PlayerStateAnalyzer that analyzes states using the given
+// RuleSet.
+func NewPlayerStateAnalyzer(ruleSet rules.RuleSet, reader io.Reader) *PlayerStateAnalyzer {
 	return &PlayerStateAnalyzer{
+		ruleSet:         ruleSet,
 		scanner:         bufio.NewScanner(reader),
 		shortHandParser: shorthand.NewParser(),
 	}
 }
 
-// Start ...
+// Start analyzes the states in the file given by -mj.stateFile, or the state given by -mj.state or
+// the individual state flags. If none of them is given, the state is prompted for instead.
 func (p *PlayerStateAnalyzer) Start() {
-	if err := p.doStart(); err != nil {
-		fmt.Printf("Encountered error: %s\n", err)
+	var err error
+	if *flags.StateFileFlag != "" {
+		err = p.analyzeStateFile(*flags.StateFileFlag)
+	} else if notation := getStateNotationFromFlags(); notation != "" {
+		err = p.analyzeStateNotation(notation, 0)
+	} else {
+		err = p.doStart()
+	}
+	if err != nil {
+		// Keep the standard output parseable if the results are printed as JSON.
+		out := os.Stdout
+		if isJSONOutput() {
+			out = os.Stderr
+		}
+		fmt.Fprintf(out, "Encountered error: %s\n", err)
 	}
 }
 
+// analyzeStateNotation analyzes the state given in the single-line state notation, which is on the
+// given line of the state file, or 0 if there is no state file.
+func (p *PlayerStateAnalyzer) analyzeStateNotation(notation string, lineNumber int) error {
+	result := &report.StateResult{Line: lineNumber, State: notation}
+	input, err := parseStateNotation(p.ruleSet, p.shortHandParser, notation)
+	if err != nil {
+		return p.reportError(result, err)
+	}
+	return p.analyze(input, result)
+}
+
+// analyzeStateFile analyzes each state in the given file, which has one state in the single-line
+// state notation per line. Empty lines and lines starting with '#' are skipped. An error is
+// returned if any of the states cannot be analyzed, after analyzing all of them.
+func (p *PlayerStateAnalyzer) analyzeStateFile(fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open state file")
+	}
+	defer file.Close()
+
+	numStates := 0
+	numErrors := 0
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		numStates++
+		if !isJSONOutput() {
+			fmt.Printf("Line %d: %s\n", lineNumber, line)
+		}
+		if err := p.analyzeStateNotation(line, lineNumber); err != nil {
+			// Errors are included in the results if they are printed as JSON.
+			if !isJSONOutput() {
+				fmt.Printf("Encountered error on line %d: %s\n", lineNumber, err)
+			}
+			numErrors++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "unable to read state file")
+	}
+	if !isJSONOutput() {
+		fmt.Printf("Analyzed %d states\n", numStates)
+	}
+	if numErrors > 0 {
+		return fmt.Errorf("Failed to analyze %d of %d states", numErrors, numStates)
+	}
+	return nil
+}
+
 func (p *PlayerStateAnalyzer) doStart() error {
 	// Input hand
 	hand, err := p.inputHand()
@@ -55,8 +130,20 @@ func (p *PlayerStateAnalyzer) doStart() error {
 		return err
 	}
 
+	// Input bonus area
+	bonusTiles, err := p.inputBonusTiles()
+	if err != nil {
+		return err
+	}
+
 	// Input player wind seat
-	windOrdinal, err := p.inputWind("player")
+	windOrdinal, err := p.inputWind("player wind seat")
+	if err != nil {
+		return err
+	}
+
+	// Input prevailing wind
+	prevailingWindOrdinal, err := p.inputWind("prevailing wind")
 	if err != nil {
 		return err
 	}
@@ -67,36 +154,109 @@ func (p *PlayerStateAnalyzer) doStart() error {
 		return err
 	}
 
-	// Validate (melds are valid, number of tiles in hand+meld is valid, max 4 tiles each)
-	// TODO: implement a validator.
 	// Add self-drawn tile to hand so it is picked up by the calculator.
 	if rules.IsSelfDrawnType(outTileSource.SourceType) {
 		hand.AddTile(outTileSource.Tile)
 	}
+	playerGameState := rules.NewExistingPlayerGameState(hand, windOrdinal, bonusTiles, nil,
+		meldGroups)
+	return p.analyze(&stateInput{
+		player:                playerGameState,
+		outTileSource:         outTileSource,
+		isLastTile:            isLastTile,
+		prevailingWindOrdinal: prevailingWindOrdinal,
+	}, &report.StateResult{})
+}
 
-	// Score and list out plans
-	playerGameState := rules.NewExistingPlayerGameState(hand, windOrdinal, nil, meldGroups)
-	calc := rules.NewOutPlanCalculator(rules.GetSuitsForGame(), playerGameState, outTileSource)
-	plans := calc.Calculate()
-
-	fmt.Printf("Found %d out plans\n", len(plans))
-	if len(plans) > 0 {
-		// Hack to simulate last-tile.
-		numRemainingTiles := 42
-		if isLastTile {
-			numRemainingTiles = 0
-		}
+// analyze validates and scores the given state, and prints the result in the format given by
+// -mj.output, which is annotated with the given result. In text, plans that do not qualify as an
+// Out are noted, and the discard advice is printed instead if there are no Out plans.
+func (p *PlayerStateAnalyzer) analyze(input *stateInput, result *report.StateResult) error {
+	playerGameState := input.player
+	outTileSource := input.outTileSource
+	scoredPlans, context, err := p.score(input)
+	if err != nil {
+		return p.reportError(result, err)
+	}
+
+	if isJSONOutput() {
+		result.RuleName = p.ruleSet.GetName()
+		result.ScoringResult = report.NewScoringResult(scoredPlans, context)
+		return report.WriteJSON(os.Stdout, result)
+	}
 
-		scorer := zj.NewOutPlansScorer()
-		context := rules.NewOutPlanScoringContext(outTileSource, playerGameState, numRemainingTiles)
-		scoredPlans := scorer.ScoreOutPlans(plans, context)
+	// List out plans
+	fmt.Printf("Analyzing %s (seat wind %s, prevailing wind %s, %s)\n",
+		shorthand.NewFormatter().FormatPlayerGameState(playerGameState),
+		rules.GetWindName(playerGameState.GetWindOrdinal()),
+		rules.GetWindName(input.prevailingWindOrdinal), outTileSource)
+	fmt.Printf("Found %d out plans\n", len(scoredPlans))
+	if len(scoredPlans) > 0 {
 		fmt.Printf("Detailed scoring:\n")
 		fmt.Printf("%s\n", scoredPlans)
+		if len(rules.GetQualifyingOutPlans(p.ruleSet, scoredPlans)) == 0 {
+			fmt.Printf("No plan qualifies as an Out under %s rules\n", p.ruleSet.GetName())
+		}
+	} else if len(playerGameState.GetHand().GetTiles())%3 == 2 {
+		PrintDiscardAdvice(p.ruleSet, playerGameState, input.getNumRemainingTiles(),
+			input.prevailingWindOrdinal)
 	}
 
 	return nil
 }
 
+// score validates the given state, and returns its scored Out plans and the context they are
+// scored with.
+func (p *PlayerStateAnalyzer) score(input *stateInput) (rules.ScoredOutPlans,
+	*rules.OutPlanScoringContext, error) {
+	playerGameState := input.player
+	outTileSource := input.outTileSource
+
+	// Validate (melds are valid, number of tiles in hand+meld is valid, max 4 tiles each)
+	if err := rules.ValidatePlayerGameState(p.ruleSet, playerGameState, outTileSource); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid player state")
+	}
+
+	context := rules.NewOutPlanScoringContext(outTileSource, playerGameState,
+		input.getNumRemainingTiles(), input.prevailingWindOrdinal)
+
+	// Score out plans
+	plans := rules.NewOutPlanCalculatorForRuleSet(p.ruleSet, playerGameState, outTileSource).
+		Calculate()
+	if len(plans) == 0 {
+		return nil, context, nil
+	}
+	return p.ruleSet.GetOutPlansScorer().ScoreOutPlans(plans, context), context, nil
+}
+
+// reportError prints the given error in the given result if the results are printed as JSON, and
+// returns the error.
+func (p *PlayerStateAnalyzer) reportError(result *report.StateResult, err error) error {
+	if isJSONOutput() {
+		result.RuleName = p.ruleSet.GetName()
+		result.Error = err.Error()
+		if writeErr := report.WriteJSON(os.Stdout, result); writeErr != nil {
+			glog.Errorf("Unable to write result: %s\n", writeErr)
+		}
+	}
+	return err
+}
+
+// isJSONOutput returns whether the results are printed as JSON, as given by -mj.output.
+func isJSONOutput() bool {
+	return *flags.OutputFlag == flags.OutputFormatJSON
+}
+
+// PrintDiscardAdvice prints the advice for each possible discard of the given player, whose hand
+// must contain the extra tile, with the given number of tiles remaining in the deck in a round of
+// the given prevailing wind. Only the player's own tiles are considered visible.
+func PrintDiscardAdvice(ruleSet rules.RuleSet, player *rules.PlayerGameState,
+	numRemainingTiles int, prevailingWindOrdinal int) {
+	advisor := rules.NewDiscardAdvisor(ruleSet, ruleSet.GetOutPlansScorer(), player, nil,
+		numRemainingTiles, prevailingWindOrdinal)
+	fmt.Printf("Discard advice:\n%s\n", advisor.Advise())
+}
+
 func (p *PlayerStateAnalyzer) inputHand() (*domain.Hand, error) {
 	for {
 		str, err := p.promptForInput("Enter hand tiles, not including the out tile", "")
@@ -108,6 +268,10 @@ func (p *PlayerStateAnalyzer) inputHand() (*domain.Hand, error) {
 			fmt.Printf("Error parsing hand tiles: %s\n", err)
 			continue
 		}
+		if ineligibleTile := findTile(tiles, isBonusTile); ineligibleTile != nil {
+			fmt.Printf("Bonus tile %s must be entered in the bonus area\n", ineligibleTile)
+			continue
+		}
 		numTiles := len(tiles)
 		// This value is typically 1, but could also be 2 if the player wins with initial hand.
 		if numTiles%3 == 0 {
@@ -122,7 +286,8 @@ func (p *PlayerStateAnalyzer) inputHand() (*domain.Hand, error) {
 
 func (p *PlayerStateAnalyzer) inputMeldGroups() (rules.TileGroups, error) {
 	for {
-		str, err := p.promptForInput("Enter meld groups", "")
+		str, err := p.promptForInput(
+			"Enter meld groups, e.g. [111m][234b][5555d][!7777m] (! = concealed kong)", "")
 		if err != nil {
 			return nil, err
 		}
@@ -139,22 +304,36 @@ func (p *PlayerStateAnalyzer) inputMeldGroups() (rules.TileGroups, error) {
 	}
 }
 
-func (p *PlayerStateAnalyzer) inputWind(who string) (int, error) {
+func (p *PlayerStateAnalyzer) inputBonusTiles() (domain.Tiles, error) {
 	for {
-		str, err := p.promptForInput(
-			fmt.Sprintf("Input %s wind seat (1=E, 2=S, 3=W, 4=N)", who), "1")
+		str, err := p.promptForInput("Enter bonus tiles (flowers f, seasons s)", "")
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		windSeat, err := strconv.Atoi(str)
+		tiles, err := p.shortHandParser.ParseTiles(str)
 		if err != nil {
-			fmt.Printf("Invalid wind seat %s\n", str)
+			fmt.Printf("Error parsing bonus tiles: %s\n", err)
 			continue
 		}
+		isNotBonusTile := func(tile *domain.Tile) bool { return !isBonusTile(tile) }
+		if nonBonusTile := findTile(tiles, isNotBonusTile); nonBonusTile != nil {
+			fmt.Printf("Not a bonus tile: %s\n", nonBonusTile)
+			continue
+		}
+		sort.Sort(tiles)
+		return tiles, nil
+	}
+}
 
-		windOrdinal := windSeat - 1
-		if windOrdinal < 0 || windOrdinal >= rules.Winds.GetSize() {
-			fmt.Printf("Wind seat out of range: %d\n", windSeat)
+func (p *PlayerStateAnalyzer) inputWind(what string) (int, error) {
+	for {
+		str, err := p.promptForInput(fmt.Sprintf("Input %s (1=E, 2=S, 3=W, 4=N)", what), "1")
+		if err != nil {
+			return 0, err
+		}
+		windOrdinal, err := parseWindSeat(str)
+		if err != nil {
+			fmt.Printf("%s\n", err)
 			continue
 		}
 		return windOrdinal, nil
@@ -190,29 +369,23 @@ func (p *PlayerStateAnalyzer) inputOutTileSource() (*rules.OutTileSource, bool,
 		}
 
 		// Parse discard player
-		var discardInfo *rules.DiscardInfo
-		if rules.IsExternalOutSourceType(outSourceType) {
-			discardPlayerWindOrdinal, err := p.inputWind("discarder")
+		discardPlayerWindOrdinal := 0
+		isFirstDiscard := false
+		if outSourceType == rules.OutTileSourceTypeDiscard {
+			discardPlayerWindOrdinal, err = p.inputWind("discarder wind seat")
 			if err != nil {
 				fmt.Printf("Error parsing discarder wind: %s\n", err)
 				return nil, false, err
 			}
-			isFirstDiscard, err := p.inputBool("Is first discard", false)
+			isFirstDiscard, err = p.inputBool("Is first discard", false)
 			if err != nil {
 				fmt.Printf("Error parsing isFirstDiscard bool: %s\n", err)
 				return nil, false, err
 			}
-			var discardedTiles domain.Tiles
-			// Hack to emulate that this is not a first discard.
-			if !isFirstDiscard {
-				discardedTiles = append(discardedTiles, nil)
-			}
-			discardPlayer := rules.NewExistingPlayerGameState(
-				domain.NewHand(), discardPlayerWindOrdinal, discardedTiles, nil)
-			discardInfo = rules.NewDiscardInfo(discardPlayer)
 		}
 
-		return rules.NewOutTileSource(outSourceType, outTile, discardInfo), isLastTile, nil
+		return newOutTileSource(outSourceType, outTile, discardPlayerWindOrdinal, isFirstDiscard),
+			isLastTile, nil
 	}
 }
 
@@ -222,17 +395,12 @@ func (p *PlayerStateAnalyzer) inputOutTile() (*domain.Tile, error) {
 		if err != nil {
 			return nil, err
 		}
-		tiles, err := p.shortHandParser.ParseTiles(str)
+		tile, err := parseOutTile(p.shortHandParser, str)
 		if err != nil {
 			fmt.Printf("Error parsing out tile: %s\n", err)
 			continue
 		}
-		numTiles := len(tiles)
-		if numTiles != 1 {
-			fmt.Printf("Invalid number of tiles in hand, expected 1: %d\n", numTiles)
-			continue
-		}
-		return tiles[0], nil
+		return tile, nil
 	}
 }
 
@@ -270,3 +438,17 @@ func (p *PlayerStateAnalyzer) promptForInput(prompt string, defaultValue string)
 		return text, nil
 	}
 }
+
+func isBonusTile(tile *domain.Tile) bool {
+	return !rules.IsEligibleForHand(tile.GetSuit())
+}
+
+// findTile returns the first tile that satisfies the given predicate, or nil if there is none.
+func findTile(tiles domain.Tiles, predicate func(*domain.Tile) bool) *domain.Tile {
+	for _, tile := range tiles {
+		if predicate(tile) {
+			return tile
+		}
+	}
+	return nil
+}
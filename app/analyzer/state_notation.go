@@ -0,0 +1,227 @@
+package analyzer
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// Keys of the options in the state notation.
+const (
+	stateKeyBonus      = "bonus"
+	stateKeySeat       = "seat"
+	stateKeyPrevailing = "prevailing"
+	stateKeySource     = "source"
+	stateKeyTile       = "tile"
+	stateKeyLast       = "last"
+	stateKeyFirst      = "first"
+	stateKeyDiscarder  = "discarder"
+	stateKeyRiichi     = "riichi"
+)
+
+// defaultNumRemainingTiles is the number of tiles remaining in the deck of a state that is not
+// the last tile. A state does not record how far the game has progressed, so it is analyzed as if
+// in the middle of a game: any count above zero scores the same, as only the last tile is scored
+// differently, and the discard advice then assumes enough draws remain to complete the hand.
+const defaultNumRemainingTiles = 42
+
+// stateInput is a player state to analyze, together with the circumstances of the Out.
+type stateInput struct {
+	player        *rules.PlayerGameState
+	outTileSource *rules.OutTileSource
+	isLastTile    bool
+	// prevailingWindOrdinal is the wind of the round.
+	prevailingWindOrdinal int
+}
+
+// getNumRemainingTiles returns the number of tiles remaining in the deck of the state.
+func (i *stateInput) getNumRemainingTiles() int {
+	if i.isLastTile {
+		return 0
+	}
+	return defaultNumRemainingTiles
+}
+
+// parseStateNotation parses the given single-line state notation, e.g.
+// "123b55m [111m] seat=2 source=d tile=5m", and returns the corresponding stateInput under the
+// given RuleSet. The
+// notation consists of the hand and meld groups in shorthand form, not including the out tile,
+// followed by key=value options:
+//   - bonus: the bonus tiles, e.g. 1f2s.
+//   - seat: the wind seat of the player (1=E, 2=S, 3=W, 4=N). Defaults to 1.
+//   - prevailing: the prevailing wind (1=E, 2=S, 3=W, 4=N). Defaults to 1.
+//   - source: the out source (d, sd, sdr, ak, ih). Required.
+//   - tile: the out tile. Required unless the source is ih.
+//   - last: whether the out tile is the last tile. Defaults to false.
+//   - first: whether the out tile is the first discard of the discarder. Defaults to false.
+//   - discarder: the wind seat of the discarder, if the source is d. Defaults to the seat before
+//     the player.
+//   - riichi: whether the player has declared riichi, which requires a concealed hand that is
+//     ready without the out tile. Defaults to false.
+func parseStateNotation(ruleSet rules.RuleSet, parser *shorthand.Parser,
+	notation string) (*stateInput, error) {
+	var handStrs []string
+	// The options in the order they are given, as key and value pairs.
+	var options [][2]string
+	keys := make(map[string]bool)
+	for _, field := range strings.Fields(notation) {
+		index := strings.IndexRune(field, '=')
+		if index < 0 {
+			handStrs = append(handStrs, field)
+			continue
+		}
+		key, value := field[:index], field[index+1:]
+		if keys[key] {
+			return nil, fmt.Errorf("Duplicate option %s", key)
+		}
+		keys[key] = true
+		options = append(options, [2]string{key, value})
+	}
+
+	tiles, meldGroups, err := parser.ParseHandAndMeldGroups(strings.Join(handStrs, " "))
+	if err != nil {
+		return nil, err
+	}
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+
+	input := &stateInput{}
+	var bonusTiles domain.Tiles
+	windOrdinal := 0
+	discarderWindOrdinal := -1
+	var outSourceType rules.OutTileSourceType
+	var outTile *domain.Tile
+	isFirstDiscard := false
+	isRiichiDeclared := false
+	for _, option := range options {
+		key, value := option[0], option[1]
+		switch key {
+		case stateKeyBonus:
+			bonusTiles, err = parser.ParseTiles(value)
+		case stateKeySeat:
+			windOrdinal, err = parseWindSeat(value)
+		case stateKeyPrevailing:
+			input.prevailingWindOrdinal, err = parseWindSeat(value)
+		case stateKeySource:
+			var found bool
+			if outSourceType, found = outSourceTypeMap[value]; !found {
+				err = fmt.Errorf("Unknown out source type %s, expected one of %s", value,
+					outSourceTypeOptionsStr)
+			}
+		case stateKeyTile:
+			outTile, err = parseOutTile(parser, value)
+		case stateKeyLast:
+			input.isLastTile, err = strconv.ParseBool(value)
+		case stateKeyFirst:
+			isFirstDiscard, err = strconv.ParseBool(value)
+		case stateKeyDiscarder:
+			discarderWindOrdinal, err = parseWindSeat(value)
+		case stateKeyRiichi:
+			isRiichiDeclared, err = strconv.ParseBool(value)
+		default:
+			err = fmt.Errorf("Unknown option")
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing option %s=%s", key, value)
+		}
+	}
+
+	if !keys[stateKeySource] {
+		return nil, fmt.Errorf("Missing option %s", stateKeySource)
+	}
+	if (outSourceType == rules.OutTileSourceTypeInitialHand) != (outTile == nil) {
+		return nil, fmt.Errorf("Option %s must be given unless the out source is ih",
+			stateKeyTile)
+	}
+	if discarderWindOrdinal < 0 {
+		discarderWindOrdinal = (windOrdinal + rules.Winds.GetSize() - 1) % rules.Winds.GetSize()
+	}
+	input.outTileSource = newOutTileSource(outSourceType, outTile, discarderWindOrdinal,
+		isFirstDiscard)
+
+	input.player = rules.NewExistingPlayerGameState(hand, windOrdinal, bonusTiles, nil, meldGroups)
+	if isRiichiDeclared && !input.player.DeclareRiichi(ruleSet) {
+		return nil, fmt.Errorf("Option %s requires a concealed hand that is ready", stateKeyRiichi)
+	}
+	// Add self-drawn tile to hand so it is picked up by the calculator.
+	if rules.IsSelfDrawnType(outSourceType) {
+		hand.AddTile(outTile)
+	}
+	return input, nil
+}
+
+// getStateNotationFromFlags returns the state notation given by -mj.state, or the one composed of
+// the individual state flags if -mj.hand is set, or "" if neither is set.
+func getStateNotationFromFlags() string {
+	if *flags.StateFlag != "" || *flags.HandFlag == "" {
+		return *flags.StateFlag
+	}
+	options := []string{
+		*flags.HandFlag,
+		fmt.Sprintf("%s=%d", stateKeySeat, *flags.SeatWindFlag),
+		fmt.Sprintf("%s=%d", stateKeyPrevailing, *flags.PrevailingWindFlag),
+		fmt.Sprintf("%s=%s", stateKeySource, *flags.OutSourceFlag),
+		fmt.Sprintf("%s=%t", stateKeyLast, *flags.LastTileFlag),
+		fmt.Sprintf("%s=%t", stateKeyFirst, *flags.FirstDiscardFlag),
+		fmt.Sprintf("%s=%t", stateKeyRiichi, *flags.RiichiFlag),
+	}
+	if *flags.BonusTilesFlag != "" {
+		options = append(options, fmt.Sprintf("%s=%s", stateKeyBonus, *flags.BonusTilesFlag))
+	}
+	if *flags.OutTileFlag != "" {
+		options = append(options, fmt.Sprintf("%s=%s", stateKeyTile, *flags.OutTileFlag))
+	}
+	return strings.Join(options, " ")
+}
+
+// parseWindSeat parses the given wind seat (1=E, 2=S, 3=W, 4=N) and returns the wind ordinal.
+func parseWindSeat(str string) (int, error) {
+	windSeat, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid wind seat %s", str)
+	}
+	windOrdinal := windSeat - 1
+	if windOrdinal < 0 || windOrdinal >= rules.Winds.GetSize() {
+		return 0, fmt.Errorf("Wind seat out of range: %d", windSeat)
+	}
+	return windOrdinal, nil
+}
+
+// parseOutTile parses the given out tile in shorthand form.
+func parseOutTile(parser *shorthand.Parser, str string) (*domain.Tile, error) {
+	tiles, err := parser.ParseTiles(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(tiles) != 1 {
+		return nil, fmt.Errorf("Invalid number of out tiles, expected 1: %d", len(tiles))
+	}
+	if isBonusTile(tiles[0]) {
+		return nil, fmt.Errorf("Bonus tile %s cannot be the out tile", tiles[0])
+	}
+	return tiles[0], nil
+}
+
+// newOutTileSource creates an OutTileSource with the given type and tile. If the tile is a
+// discard, the discarder is a player with the given wind, who has not discarded any other tile if
+// isFirstDiscard is true.
+func newOutTileSource(sourceType rules.OutTileSourceType, tile *domain.Tile,
+	discarderWindOrdinal int, isFirstDiscard bool) *rules.OutTileSource {
+	var discardInfo *rules.DiscardInfo
+	if sourceType == rules.OutTileSourceTypeDiscard {
+		var discardedTiles domain.Tiles
+		// Hack to emulate that this is not a first discard.
+		if !isFirstDiscard {
+			discardedTiles = append(discardedTiles, nil)
+		}
+		discardPlayer := rules.NewExistingPlayerGameState(
+			domain.NewHand(), discarderWindOrdinal, nil, discardedTiles, nil)
+		discardInfo = rules.NewDiscardInfo(discardPlayer)
+	}
+	return rules.NewOutTileSource(sourceType, tile, discardInfo)
+}
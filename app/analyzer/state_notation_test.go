@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/rules/riichi"
+	"github.com/derekimcheng/mj/rules/zj"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ParseStateNotation_Discard(t *testing.T) {
+	input, err := parseStateNotation(zj.NewRuleSet(), shorthand.NewParser(),
+		"123456789b1m [111d] seat=2 prevailing=3 source=d tile=1m first=true bonus=1f2s")
+	require.NoError(t, err)
+
+	formatter := shorthand.NewFormatter()
+	player := input.player
+	assert.Equal(t, "123456789b1m [111d]", formatter.FormatPlayerGameState(player))
+	assert.Equal(t, "1f2s", formatter.FormatTiles(player.GetBonusTiles()))
+	assert.Equal(t, 1, player.GetWindOrdinal())
+	assert.Equal(t, 2, input.prevailingWindOrdinal)
+	assert.False(t, input.isLastTile)
+	assert.Equal(t, defaultNumRemainingTiles, input.getNumRemainingTiles())
+
+	outTileSource := input.outTileSource
+	assert.Equal(t, rules.OutTileSourceTypeDiscard, outTileSource.SourceType)
+	assert.Equal(t, "1m", formatter.FormatTiles(domain.Tiles{outTileSource.Tile}))
+	// The discarder defaults to the seat before the player.
+	discardPlayer := outTileSource.DiscardInfo.DiscardPlayer
+	assert.Equal(t, 0, discardPlayer.GetWindOrdinal())
+	assert.Empty(t, discardPlayer.GetDiscardedTiles())
+}
+
+func Test_ParseStateNotation_SelfDrawn(t *testing.T) {
+	input, err := parseStateNotation(zj.NewRuleSet(), shorthand.NewParser(),
+		"123456789b1m [111d] source=sd tile=1m last=true")
+	require.NoError(t, err)
+
+	// The self-drawn tile is added to the hand.
+	assert.Equal(t, "123456789b11m [111d]",
+		shorthand.NewFormatter().FormatPlayerGameState(input.player))
+	assert.Equal(t, 0, input.player.GetWindOrdinal())
+	assert.Equal(t, 0, input.prevailingWindOrdinal)
+	assert.True(t, input.isLastTile)
+	assert.Equal(t, 0, input.getNumRemainingTiles())
+	assert.Equal(t, rules.OutTileSourceTypeSelfDrawn, input.outTileSource.SourceType)
+	assert.Nil(t, input.outTileSource.DiscardInfo)
+}
+
+func Test_ParseStateNotation_Riichi(t *testing.T) {
+	ruleSet := riichi.NewRuleSet()
+	input, err := parseStateNotation(ruleSet, shorthand.NewParser(),
+		"123456789b23d99d source=sd tile=1d riichi=true")
+	require.NoError(t, err)
+	assert.True(t, input.player.IsRiichiDeclared())
+	assert.Equal(t, 14, input.player.GetHand().NumTiles())
+
+	// Riichi requires a concealed hand that is ready without the out tile.
+	testCases := []string{
+		"123456789b28d59d source=sd tile=1d riichi=true",
+		"123456b23d99d [789b] source=d tile=1d riichi=true",
+		"123456789b23d99d source=sd tile=1d riichi=maybe",
+	}
+	for _, testCase := range testCases {
+		_, err := parseStateNotation(ruleSet, shorthand.NewParser(), testCase)
+		assert.Error(t, err, "Expected error for %s", testCase)
+	}
+}
+
+func Test_ParseStateNotation_Invalid(t *testing.T) {
+	testCases := []string{
+		"123456789b11m99d",
+		"123456789b11m99d source=x",
+		"123456789b11m99d source=ih tile=9d",
+		"123456789b11m99d source=sd",
+		"123456789b11m99d source=sd tile=1f",
+		"123456789b11m99d source=sd tile=9d seat=5",
+		"123456789b11m99d source=sd tile=9d last=maybe",
+		"123456789b11m99d source=sd tile=9d source=d",
+		"123456789b11m99d source=sd tile=9d unknown=1",
+		"123456789b11m99x source=sd tile=9d",
+	}
+	for _, testCase := range testCases {
+		_, err := parseStateNotation(zj.NewRuleSet(), shorthand.NewParser(), testCase)
+		assert.Error(t, err, "Expected error for %s", testCase)
+	}
+}
@@ -4,28 +4,48 @@ import (
 	"flag"
 	"fmt"
 	"github.com/derekimcheng/mj/app/analyzer"
+	"github.com/derekimcheng/mj/app/replayer"
+	"github.com/derekimcheng/mj/bot"
 	"github.com/derekimcheng/mj/domain"
 	"github.com/derekimcheng/mj/engine"
 	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/record"
 	"github.com/derekimcheng/mj/rules"
+	// Registers all rule sets.
+	_ "github.com/derekimcheng/mj/rules/variants"
 	"github.com/derekimcheng/mj/ui"
 	"github.com/pkg/errors"
+	"io"
 	"math/rand"
 	"os"
 	"time"
 )
 
 func main() {
-	fmt.Println("mj Hello world")
-	initialize()
+	seed := initialize()
+	if *flags.OutputFlag != flags.OutputFormatText && *flags.OutputFlag != flags.OutputFormatJSON {
+		fmt.Printf("Invalid output format: %s\n", *flags.OutputFlag)
+		os.Exit(1)
+	}
+	fmt.Fprintln(messageWriter(), "mj Hello world")
+
+	ruleSet, err := rules.GetRuleSet(*flags.RuleNameFlag)
+	if err != nil {
+		fmt.Fprintf(messageWriter(), "%s, available rules: %s\n", err, rules.GetRuleSetNames())
+		os.Exit(1)
+	}
 
 	switch *flags.ModeFlag {
 	case flags.AppModeDeck:
-		createAndDumpDeck()
+		createAndDumpDeck(ruleSet, seed)
 	case flags.AppModeSingle:
-		simulateSingleHand()
+		simulateSingleHand(ruleSet, seed)
+	case flags.AppModeMulti:
+		simulateMultiPlayerGame(ruleSet, seed)
 	case flags.AppModeAnalyzeState:
-		analyzer.NewPlayerStateAnalyzer(os.Stdin).Start()
+		analyzer.NewPlayerStateAnalyzer(ruleSet, os.Stdin).Start()
+	case flags.AppModeReplay:
+		replayGame()
 	default:
 		printUsage()
 		os.Exit(1)
@@ -33,9 +53,24 @@ func main() {
 	os.Exit(0)
 }
 
-func initialize() {
+// initialize parses the flags and returns the seed of the game, which shuffles the deck and seeds
+// the bots.
+func initialize() int64 {
 	flag.Parse()
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := *flags.SeedFlag
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+	return seed
+}
+
+// messageWriter returns the writer of the messages of the app, which is stderr if the results are
+// printed as JSON, to keep the standard output parseable.
+func messageWriter() io.Writer {
+	if *flags.OutputFlag == flags.OutputFormatJSON {
+		return os.Stderr
+	}
+	return os.Stdout
 }
 
 func printUsage() {
@@ -44,8 +79,8 @@ func printUsage() {
 
 // createAndDumpDeck creates a game deck and empties it, logging each tile in the order they are
 // drawn.
-func createAndDumpDeck() {
-	deck := createDeck()
+func createAndDumpDeck(ruleSet rules.RuleSet, seed int64) {
+	deck := createDeck(ruleSet, seed)
 	for !deck.IsEmpty() {
 		tile, err := deck.PopFront()
 		if err != nil {
@@ -53,24 +88,133 @@ func createAndDumpDeck() {
 		}
 		fmt.Printf("Got tile [%s]\n", tile)
 	}
+	if wall, ok := deck.(*domain.Wall); ok && wall.GetDeadWallSize() > 0 {
+		fmt.Printf("Dead wall: %s\n", wall.GetDeadWallTiles())
+	}
+}
+
+func simulateSingleHand(ruleSet rules.RuleSet, seed int64) {
+	receiver := ui.NewConsoleCommandReceiver(os.Stdin, messageWriter())
+	runner := engine.NewSinglePlayerRunner(ruleSet, receiver)
+	// The tiles burned by the pseudo opponent are shown when they are discarded.
+	runner.AddObserver(engine.NewConsoleObserver(os.Stdout, []int{0}))
+	deck := createDeck(ruleSet, seed)
+	// Only the player is dealt a hand in single player mode.
+	writer, closeRecord := createRecordWriter(ruleSet, seed, 1, deck)
+	if writer != nil {
+		runner.AddObserver(writer)
+	}
+	result, err := runner.Start(deck)
+	if err != nil {
+		fmt.Fprintf(messageWriter(), "Encountered error while running single player game: %s\n",
+			err)
+	} else {
+		fmt.Fprintf(messageWriter(), "Game over: %s\n", result)
+	}
+	closeRecord()
+}
+
+func simulateMultiPlayerGame(ruleSet rules.RuleSet, seed int64) {
+	if *flags.NumHumanPlayersFlag < 0 || *flags.NumHumanPlayersFlag > engine.NumPlayers {
+		fmt.Fprintf(messageWriter(), "Invalid number of human players: %d\n",
+			*flags.NumHumanPlayersFlag)
+		os.Exit(1)
+	}
+	// Human players share the console.
+	receiver := ui.NewConsoleCommandReceiver(os.Stdin, messageWriter())
+	var receivers []ui.CommandReceiver
+	for seat := 0; seat < engine.NumPlayers; seat++ {
+		if seat < *flags.NumHumanPlayersFlag {
+			receivers = append(receivers, receiver)
+			continue
+		}
+		// Each bot has its own source derived from the seed, so that the same seed replays the
+		// same game between bots.
+		rng := rand.New(rand.NewSource(seed + int64(seat)))
+		strategy, err := bot.NewStrategy(*flags.BotLevelFlag, rng)
+		if err != nil {
+			fmt.Fprintf(messageWriter(), "%s, available levels: %s\n", err, bot.GetLevels())
+			os.Exit(1)
+		}
+		receivers = append(receivers, bot.NewBot(rules.GetWindName(seat), strategy))
+	}
+	runner := engine.NewMultiPlayerRunner(ruleSet, receivers)
+	runner.AddObserver(engine.NewConsoleObserver(os.Stdout, getVisibleSeats()))
+	deck := createDeck(ruleSet, seed)
+	writer, closeRecord := createRecordWriter(ruleSet, seed, engine.NumPlayers, deck)
+	if writer != nil {
+		runner.AddObserver(writer)
+	}
+	result, err := runner.Start(deck)
+	if err != nil {
+		fmt.Fprintf(messageWriter(), "Encountered error while running multi player game: %s\n",
+			err)
+	} else {
+		fmt.Fprintf(messageWriter(), "Game over: %s\n", result)
+	}
+	closeRecord()
 }
 
-func simulateSingleHand() {
-	runner := engine.NewSinglePlayerRunner(ui.NewConsoleCommandReceiver(os.Stdin))
-	err := runner.Start(createDeck())
+// getVisibleSeats returns the seats whose hands are shown on the console in a multi player game,
+// which are the seats of the human players, or every seat if the game is played by bots only.
+func getVisibleSeats() []int {
+	var seats []int
+	for seat := 0; seat < engine.NumPlayers; seat++ {
+		if seat < *flags.NumHumanPlayersFlag || *flags.NumHumanPlayersFlag == 0 {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// createRecordWriter creates the record file specified by -mj.recordFile and returns a writer of
+// the game record, and a function that closes the file after the game. The writer is nil if no
+// record file is specified.
+func createRecordWriter(ruleSet rules.RuleSet, seed int64, numHands int,
+	deck domain.Deck) (*record.Writer, func()) {
+	if *flags.RecordFileFlag == "" {
+		return nil, func() {}
+	}
+	file, err := os.Create(*flags.RecordFileFlag)
 	if err != nil {
-		fmt.Printf("Encountered error while running single player game: %s\n", err)
+		fmt.Fprintf(messageWriter(), "Unable to create record file: %s\n", err)
+		os.Exit(1)
+	}
+	writer := record.NewWriter(file, ruleSet, seed, numHands, deck.GetRemainingTiles())
+	return writer, func() {
+		if err := writer.Err(); err != nil {
+			fmt.Fprintf(messageWriter(), "Encountered error while saving game record: %s\n", err)
+		}
+		if err := file.Close(); err != nil {
+			fmt.Fprintf(messageWriter(), "Unable to close record file: %s\n", err)
+			return
+		}
+		fmt.Fprintf(messageWriter(), "Saved game record to %s\n", *flags.RecordFileFlag)
 	}
 }
 
-func createDeck() domain.Deck {
-	deck, err := rules.NewDeckForGame(*flags.RuleNameFlag)
+func replayGame() {
+	file, err := os.Open(*flags.RecordFileFlag)
+	if err != nil {
+		fmt.Printf("Unable to open record file: %s\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	gameRecord, err := record.Read(file)
 	if err != nil {
-		panic(errors.Wrapf(err, "failed to initialize deck"))
+		fmt.Printf("Unable to load game record: %s\n", err)
+		os.Exit(1)
 	}
+	replayer.NewReplayer(gameRecord, os.Stdin).Start()
+}
+
+// createDeck creates a deck shuffled with the given seed, and prints the seed so that the game can
+// be reproduced with -mj.seed.
+func createDeck(ruleSet rules.RuleSet, seed int64) domain.Deck {
+	deck := rules.NewShuffledDeckForRuleSet(ruleSet, seed)
 	if deck.IsEmpty() {
 		panic(errors.New("Deck is empty"))
 	}
-	deck.Shuffle()
+	fmt.Fprintf(messageWriter(), "Rule: %s, seed: %d\n", ruleSet.GetName(), seed)
 	return deck
 }
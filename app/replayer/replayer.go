@@ -0,0 +1,137 @@
+package replayer
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/record"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Replayer steps through a game record forward and backward, showing the state of every seat.
+type Replayer struct {
+	gameRecord *record.GameRecord
+	scanner    *bufio.Scanner
+	formatter  *shorthand.Formatter
+	// position is the number of entries that have been applied.
+	position int
+}
+
+// NewReplayer returns a new Replayer of the given record, which reads the replay commands from the
+// given reader.
+func NewReplayer(gameRecord *record.GameRecord, reader io.Reader) *Replayer {
+	return &Replayer{
+		gameRecord: gameRecord,
+		scanner:    bufio.NewScanner(reader),
+		formatter:  shorthand.NewFormatter(),
+	}
+}
+
+// Start ...
+func (p *Replayer) Start() {
+	if err := p.doStart(); err != nil && err != io.EOF {
+		fmt.Printf("Encountered error: %s\n", err)
+	}
+}
+
+func (p *Replayer) doStart() error {
+	header := p.gameRecord.Header
+	fmt.Printf("Replaying game with rule %s in the %s round, seed %d, %d entries\n",
+		header.RuleName, rules.GetWindName(header.PrevailingWind), header.Seed,
+		len(p.gameRecord.Entries))
+	for {
+		if err := p.showPosition(); err != nil {
+			return err
+		}
+		quit, err := p.promptForMove()
+		if err != nil || quit {
+			return err
+		}
+	}
+}
+
+// promptForMove prompts until the position is moved or the replay is quit. Returns true if the
+// replay is quit.
+func (p *Replayer) promptForMove() (bool, error) {
+	numEntries := len(p.gameRecord.Entries)
+	for {
+		fmt.Printf("Enter a command [n(ext)|p(rev)|g(oto) <entry>|e(nd)|q(uit)] [default='n']: ")
+		if !p.scanner.Scan() {
+			err := p.scanner.Err()
+			if err == nil {
+				err = io.EOF
+			}
+			return true, err
+		}
+
+		fields := strings.Fields(p.scanner.Text())
+		command := "n"
+		if len(fields) > 0 {
+			command = fields[0]
+		}
+		switch command {
+		case "n", "next":
+			if p.position == numEntries {
+				fmt.Println("Already at the end of the game")
+				continue
+			}
+			p.position++
+		case "p", "prev":
+			if p.position == 0 {
+				fmt.Println("Already at the start of the game")
+				continue
+			}
+			p.position--
+		case "g", "goto":
+			if len(fields) < 2 {
+				fmt.Println("Missing entry number")
+				continue
+			}
+			position, err := strconv.Atoi(fields[1])
+			if err != nil || position < 0 || position > numEntries {
+				fmt.Printf("Invalid entry number, expected [0, %d]: %s\n", numEntries, fields[1])
+				continue
+			}
+			p.position = position
+		case "e", "end":
+			p.position = numEntries
+		case "q", "quit":
+			return true, nil
+		default:
+			fmt.Printf("Unknown command %s\n", command)
+			continue
+		}
+		return false, nil
+	}
+}
+
+func (p *Replayer) showPosition() error {
+	state, err := p.gameRecord.GetStateAt(p.position)
+	if err != nil {
+		return err
+	}
+
+	if p.position == 0 {
+		fmt.Printf("Entry 0/%d: hands dealt\n", len(p.gameRecord.Entries))
+	} else {
+		fmt.Printf("Entry %d/%d: %s\n", p.position, len(p.gameRecord.Entries),
+			p.gameRecord.Entries[p.position-1])
+	}
+	for seat, player := range state.Players {
+		fmt.Printf("  %s: %s, bonus: %s, discarded: %s\n", rules.GetWindName(seat),
+			p.formatter.FormatPlayerGameState(player),
+			p.formatter.FormatTiles(player.GetBonusTiles()),
+			p.formatter.FormatTiles(player.GetDiscardedTiles()))
+	}
+	if state.DiscardedTile != nil {
+		fmt.Printf("  Claimable tile %s discarded by %s\n",
+			p.formatter.FormatTiles(domain.Tiles{state.DiscardedTile}),
+			rules.GetWindName(state.DiscarderSeat))
+	}
+	fmt.Printf("  Remaining tiles: %d\n", state.NumRemainingTiles)
+	return nil
+}
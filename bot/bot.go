@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/golang/glog"
+	"math/rand"
+)
+
+// Level specifies the playing strength of a bot.
+type Level = string
+
+const (
+	// LevelRandom bots make random legal moves.
+	LevelRandom Level = "random"
+	// LevelGreedy bots discard to reduce the shanten number, and claim melds that reduce it.
+	LevelGreedy Level = "greedy"
+	// LevelScoring bots play like LevelGreedy bots, but steer the hand towards high-value patterns
+	// such as one suit and all triplets, and prefer waits with a high expected score.
+	LevelScoring Level = "scoring"
+)
+
+// GetLevels returns all bot levels, from weakest to strongest.
+func GetLevels() []Level {
+	return []Level{LevelRandom, LevelGreedy, LevelScoring}
+}
+
+// Strategy selects a command based on the information visible to a player.
+type Strategy interface {
+	// SelectCommand returns a legal command whose type is one of the given CommandTypes.
+	SelectCommand(view engine.PlayerView, acceptedCommands ui.CommandTypes) *ui.Command
+}
+
+// NewStrategy returns a new Strategy of the given level. The random number generator is used for
+// any random decision.
+func NewStrategy(level Level, rng *rand.Rand) (Strategy, error) {
+	switch level {
+	case LevelRandom:
+		return newRandomStrategy(rng), nil
+	case LevelGreedy:
+		return newMeldingStrategy(&shantenEvaluator{}), nil
+	case LevelScoring:
+		return newMeldingStrategy(&valueEvaluator{}), nil
+	}
+	return nil, fmt.Errorf("Unknown bot level %s", level)
+}
+
+// Bot is a computer player backed by a Strategy. It only sees the information provided by its
+// engine.PlayerView.
+type Bot struct {
+	name     string
+	strategy Strategy
+	view     engine.PlayerView
+}
+
+// NewBot creates a new Bot with the given name and Strategy.
+func NewBot(name string, strategy Strategy) *Bot {
+	return &Bot{name: name, strategy: strategy}
+}
+
+// SetPlayerView ... (engine.PlayerViewReceiver implementation)
+func (b *Bot) SetPlayerView(view engine.PlayerView) {
+	b.view = view
+}
+
+// PromptForCommand ... (ui.CommandReceiver implementation)
+func (b *Bot) PromptForCommand(acceptedCommands ui.CommandTypes) (*ui.Command, error) {
+	if b.view == nil {
+		return nil, errors.New("Bot has no player view")
+	}
+	cmd := b.strategy.SelectCommand(b.view, acceptedCommands)
+	if !acceptedCommands.ContainsCommand(cmd.GetCommandType()) {
+		return nil, fmt.Errorf("Bot %s selected unacceptable command %s", b.name,
+			cmd.GetCommandType())
+	}
+	glog.V(2).Infof("Bot %s selected command %s\n", b.name, cmd.GetCommandType())
+	return cmd, nil
+}
+
+// canDeclareOut returns whether the player of the view may declare an Out that qualifies under the
+// rule set, including by robbing a kong.
+func canDeclareOut(view engine.PlayerView, acceptedCommands ui.CommandTypes) bool {
+	outTileSource := view.GetOutTileSource()
+	if outTileSource == nil ||
+		!(acceptedCommands.ContainsCommand(ui.Out) || acceptedCommands.ContainsCommand(ui.RobKong)) {
+		return false
+	}
+	ruleSet := view.GetRuleSet()
+	plans := rules.NewOutPlanCalculatorForRuleSet(
+		ruleSet, view.GetPlayer(), outTileSource).Calculate()
+	if len(plans) == 0 {
+		return false
+	}
+	context := rules.NewOutPlanScoringContext(
+		outTileSource, view.GetPlayer(), view.GetNumRemainingTiles(),
+		view.GetPrevailingWindOrdinal())
+	scoredPlans := ruleSet.GetOutPlansScorer().ScoreOutPlans(plans, context)
+	return len(rules.GetQualifyingOutPlans(ruleSet, scoredPlans)) > 0
+}
+
+// newOutCommand returns the command that declares an Out among the given CommandTypes.
+func newOutCommand(acceptedCommands ui.CommandTypes) *ui.Command {
+	if acceptedCommands.ContainsCommand(ui.RobKong) {
+		return ui.NewRobKongCommand()
+	}
+	return ui.NewOutCommand()
+}
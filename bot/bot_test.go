@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	// Registers the rule set used in tests.
+	_ "github.com/derekimcheng/mj/rules/zj"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+var (
+	turnCommands  = ui.CommandTypes{ui.DiscardTile, ui.ConcealedKong, ui.AdditionalKong, ui.Out}
+	claimCommands = ui.CommandTypes{ui.Pong, ui.Kong, ui.Chow, ui.Pass, ui.Out}
+)
+
+// fakeView is a PlayerView with fixed contents.
+type fakeView struct {
+	ruleSet       rules.RuleSet
+	player        *rules.PlayerGameState
+	claimableTile *domain.Tile
+	outTileSource *rules.OutTileSource
+}
+
+func (v *fakeView) GetRuleSet() rules.RuleSet              { return v.ruleSet }
+func (v *fakeView) GetPlayer() *rules.PlayerGameState      { return v.player.Copy() }
+func (v *fakeView) GetOpponents() []*rules.PlayerGameState { return nil }
+func (v *fakeView) GetClaimableTile() *domain.Tile         { return v.claimableTile }
+func (v *fakeView) GetOutTileSource() *rules.OutTileSource { return v.outTileSource }
+func (v *fakeView) GetNumRemainingTiles() int              { return 40 }
+func (v *fakeView) GetPrevailingWindOrdinal() int          { return 0 }
+
+func newFakeView(t *testing.T, handStr string) *fakeView {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameZJ)
+	require.NoError(t, err)
+	tiles, groups, err := shorthand.NewParser().ParseHandAndMeldGroups(handStr)
+	require.NoError(t, err)
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	return &fakeView{
+		ruleSet: ruleSet,
+		player:  rules.NewExistingPlayerGameState(hand, 0, nil, nil, groups),
+	}
+}
+
+func parseTileForTest(t *testing.T, str string) *domain.Tile {
+	tiles, err := shorthand.NewParser().ParseTiles(str)
+	require.NoError(t, err)
+	require.Len(t, tiles, 1)
+	return tiles[0]
+}
+
+func newBotForTest(t *testing.T, level Level, view *fakeView) *Bot {
+	strategy, err := NewStrategy(level, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	bot := NewBot("test", strategy)
+	bot.SetPlayerView(view)
+	return bot
+}
+
+func Test_NewStrategy_UnknownLevel(t *testing.T) {
+	_, err := NewStrategy("unknown", rand.New(rand.NewSource(1)))
+	assert.Error(t, err)
+}
+
+func Test_Bot_NoView(t *testing.T) {
+	strategy, err := NewStrategy(LevelGreedy, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	_, err = NewBot("test", strategy).PromptForCommand(turnCommands)
+	assert.Error(t, err)
+}
+
+func Test_Bot_DiscardsIsolatedTile(t *testing.T) {
+	for _, level := range []Level{LevelGreedy, LevelScoring} {
+		view := newFakeView(t, "123456789d1123b9m")
+		cmd, err := newBotForTest(t, level, view).PromptForCommand(turnCommands)
+		require.NoError(t, err)
+		require.Equal(t, ui.DiscardTile, cmd.GetCommandType(), "level %s", level)
+		assert.Equal(t, 13, cmd.GetTileIndexCommand().GetIndex(), "level %s", level)
+	}
+}
+
+func Test_Bot_DeclaresOut(t *testing.T) {
+	for _, level := range GetLevels() {
+		view := newFakeView(t, "123456789d11123b")
+		view.outTileSource = rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawn,
+			view.player.GetHand().GetTiles()[13], nil)
+		cmd, err := newBotForTest(t, level, view).PromptForCommand(turnCommands)
+		require.NoError(t, err)
+		if level != LevelRandom {
+			assert.Equal(t, ui.Out, cmd.GetCommandType(), "level %s", level)
+		}
+	}
+}
+
+func Test_Bot_RobsKong(t *testing.T) {
+	for _, level := range GetLevels() {
+		view := newFakeView(t, "123456789d1123b")
+		view.claimableTile = parseTileForTest(t, "1b")
+		view.outTileSource = rules.NewOutTileSource(rules.OutTileSourceTypeAdditionalKong,
+			view.claimableTile, nil)
+		cmd, err := newBotForTest(t, level, view).PromptForCommand(
+			ui.CommandTypes{ui.RobKong, ui.Pass})
+		require.NoError(t, err)
+		if level != LevelRandom {
+			assert.Equal(t, ui.RobKong, cmd.GetCommandType(), "level %s", level)
+		}
+	}
+}
+
+func Test_Bot_ClaimsPongThatReducesShanten(t *testing.T) {
+	view := newFakeView(t, "123456d5577b159m")
+	view.claimableTile = parseTileForTest(t, "5b")
+	cmd, err := newBotForTest(t, LevelGreedy, view).PromptForCommand(claimCommands)
+	require.NoError(t, err)
+	assert.Equal(t, ui.Pong, cmd.GetCommandType())
+}
+
+func Test_Bot_PassesOnUselessClaim(t *testing.T) {
+	view := newFakeView(t, "123456789d1123b")
+	view.claimableTile = parseTileForTest(t, "1b")
+	cmd, err := newBotForTest(t, LevelGreedy, view).PromptForCommand(claimCommands)
+	require.NoError(t, err)
+	assert.Equal(t, ui.Pass, cmd.GetCommandType())
+}
+
+func Test_Bot_ScoringRejectsOffSuitChow(t *testing.T) {
+	// Aiming for one suit (Dots), the bot does not chow Bamboo.
+	view := newFakeView(t, "1234569999d13b1y")
+	view.claimableTile = parseTileForTest(t, "2b")
+	cmd, err := newBotForTest(t, LevelScoring, view).PromptForCommand(claimCommands)
+	require.NoError(t, err)
+	assert.Equal(t, ui.Pass, cmd.GetCommandType())
+
+	cmd, err = newBotForTest(t, LevelGreedy, view).PromptForCommand(claimCommands)
+	require.NoError(t, err)
+	assert.Equal(t, ui.Chow, cmd.GetCommandType())
+}
+
+func Test_Bot_RandomSelectsLegalCommands(t *testing.T) {
+	view := newFakeView(t, "123456789d1123b [555m]")
+	view.claimableTile = parseTileForTest(t, "2b")
+	bot := newBotForTest(t, LevelRandom, view)
+	for i := 0; i < 20; i++ {
+		cmd, err := bot.PromptForCommand(claimCommands)
+		require.NoError(t, err)
+		switch cmd.GetCommandType() {
+		case ui.Pass:
+		case ui.Chow:
+			indices := cmd.GetTileIndexCommand2()
+			assert.True(t, view.player.CanDeclareChow(view.claimableTile, indices.GetIndex1(),
+				indices.GetIndex2()))
+		default:
+			assert.Fail(t, "Unexpected command", cmd.GetCommandType())
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/ui"
+)
+
+// handEvaluator customizes the decisions of a meldingStrategy.
+type handEvaluator interface {
+	// chooseDiscard returns the advice of the tile to discard. The advices are sorted by shanten
+	// number and number of useful tiles, best first.
+	chooseDiscard(view engine.PlayerView, player *rules.PlayerGameState,
+		advices rules.DiscardAdvices) *rules.DiscardAdvice
+	// acceptMeld returns whether the given meld group should be considered for the player.
+	acceptMeld(player *rules.PlayerGameState, group *rules.TileGroup) bool
+	// getScorer returns the scorer used to estimate the value of discards, or nil if values are not
+	// needed by chooseDiscard.
+	getScorer(view engine.PlayerView) rules.OutPlansScorer
+}
+
+// meldingStrategy always declares an Out when possible. Otherwise, it only makes moves that do
+// not increase the shanten number: kongs are declared if they keep the shanten number, and other
+// melds are declared if they reduce it.
+type meldingStrategy struct {
+	evaluator handEvaluator
+}
+
+func newMeldingStrategy(evaluator handEvaluator) *meldingStrategy {
+	return &meldingStrategy{evaluator: evaluator}
+}
+
+// SelectCommand ... (Strategy implementation)
+func (s *meldingStrategy) SelectCommand(view engine.PlayerView,
+	acceptedCommands ui.CommandTypes) *ui.Command {
+	if canDeclareOut(view, acceptedCommands) {
+		return newOutCommand(acceptedCommands)
+	}
+	if tile := view.GetClaimableTile(); tile != nil {
+		return s.selectClaim(view, acceptedCommands)
+	}
+	return s.selectTurnAction(view, acceptedCommands)
+}
+
+func (s *meldingStrategy) selectTurnAction(view engine.PlayerView,
+	acceptedCommands ui.CommandTypes) *ui.Command {
+	player := view.GetPlayer()
+	advices := newDiscardAdvisor(view, player, s.evaluator.getScorer(view)).Advise()
+	shanten := advices[0].Shanten
+
+	if acceptedCommands.ContainsCommand(ui.ConcealedKong) {
+		for _, index := range player.FindConcealedKongIndices() {
+			stateCopy := player.Copy()
+			if _, declared := stateCopy.DeclareConcealedKong(index); declared &&
+				s.acceptLastMeld(stateCopy) && calculateShanten(view, stateCopy) <= shanten {
+				return ui.NewConcealedKongCommand(index)
+			}
+		}
+	}
+	if acceptedCommands.ContainsCommand(ui.AdditionalKong) {
+		for _, index := range player.FindAdditionalKongIndices() {
+			stateCopy := player.Copy()
+			if _, declared := stateCopy.DeclareAdditionalKong(index); declared &&
+				calculateShanten(view, stateCopy) <= shanten {
+				return ui.NewAdditionalKongCommand(index)
+			}
+		}
+	}
+	return ui.NewDiscardTileCommand(s.evaluator.chooseDiscard(view, player, advices).Index)
+}
+
+func (s *meldingStrategy) selectClaim(view engine.PlayerView,
+	acceptedCommands ui.CommandTypes) *ui.Command {
+	player := view.GetPlayer()
+	tile := view.GetClaimableTile()
+	shanten := calculateShanten(view, player)
+
+	bestCmd := ui.NewPassCommand()
+	bestShanten := shanten
+	consider := func(cmd *ui.Command, stateCopy *rules.PlayerGameState, newShanten int) {
+		if s.acceptLastMeld(stateCopy) && newShanten < bestShanten {
+			bestCmd = cmd
+			bestShanten = newShanten
+		}
+	}
+
+	if acceptedCommands.ContainsCommand(ui.Kong) {
+		stateCopy := player.Copy()
+		if stateCopy.DeclareKong(tile) {
+			// A kong keeping the shanten number is worth the replacement tile.
+			consider(ui.NewKongCommand(), stateCopy, calculateShanten(view, stateCopy)-1)
+		}
+	}
+	if acceptedCommands.ContainsCommand(ui.Pong) {
+		stateCopy := player.Copy()
+		if stateCopy.DeclarePong(tile) {
+			consider(ui.NewPongCommand(), stateCopy,
+				calculateShantenAfterDiscard(view, stateCopy))
+		}
+	}
+	if acceptedCommands.ContainsCommand(ui.Chow) {
+		for _, indices := range player.FindChowIndices(tile) {
+			stateCopy := player.Copy()
+			if _, declared := stateCopy.DeclareChow(tile, indices[0], indices[1]); declared {
+				consider(ui.NewChowCommand(indices[0], indices[1]), stateCopy,
+					calculateShantenAfterDiscard(view, stateCopy))
+			}
+		}
+	}
+	return bestCmd
+}
+
+// acceptLastMeld returns whether the evaluator accepts the last meld group of the given player.
+func (s *meldingStrategy) acceptLastMeld(player *rules.PlayerGameState) bool {
+	meldGroups := player.GetMeldGroups()
+	return s.evaluator.acceptMeld(player, meldGroups[len(meldGroups)-1])
+}
+
+// calculateShantenAfterDiscard returns the shanten number of the given player's hand, which
+// contains the extra tile, after the best discard.
+func calculateShantenAfterDiscard(view engine.PlayerView, player *rules.PlayerGameState) int {
+	return newDiscardAdvisor(view, player, nil).Advise()[0].Shanten
+}
+
+func newDiscardAdvisor(view engine.PlayerView, player *rules.PlayerGameState,
+	scorer rules.OutPlansScorer) *rules.DiscardAdvisor {
+	return rules.NewDiscardAdvisor(view.GetRuleSet(), scorer, player, view.GetOpponents(),
+		view.GetNumRemainingTiles(), view.GetPrevailingWindOrdinal())
+}
+
+// calculateShanten returns the shanten number of the given player's hand, which does not contain
+// the extra tile.
+func calculateShanten(view engine.PlayerView, player *rules.PlayerGameState) int {
+	return rules.NewShantenCalculatorForRuleSet(view.GetRuleSet(), player).Calculate()
+}
+
+// shantenEvaluator only considers the shanten number and the number of useful tiles.
+type shantenEvaluator struct{}
+
+func (e *shantenEvaluator) chooseDiscard(view engine.PlayerView, player *rules.PlayerGameState,
+	advices rules.DiscardAdvices) *rules.DiscardAdvice {
+	return advices[0]
+}
+
+func (e *shantenEvaluator) acceptMeld(player *rules.PlayerGameState,
+	group *rules.TileGroup) bool {
+	return true
+}
+
+func (e *shantenEvaluator) getScorer(view engine.PlayerView) rules.OutPlansScorer {
+	return nil
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/ui"
+	"math/rand"
+	"sort"
+)
+
+// randomStrategy selects a random command type among the legal ones, then a random command of
+// that type.
+type randomStrategy struct {
+	rng *rand.Rand
+}
+
+func newRandomStrategy(rng *rand.Rand) *randomStrategy {
+	return &randomStrategy{rng: rng}
+}
+
+// SelectCommand ... (Strategy implementation)
+func (s *randomStrategy) SelectCommand(view engine.PlayerView,
+	acceptedCommands ui.CommandTypes) *ui.Command {
+	candidates := make(map[ui.CommandType][]*ui.Command)
+	addCandidate := func(cmd *ui.Command) {
+		if acceptedCommands.ContainsCommand(cmd.GetCommandType()) {
+			candidates[cmd.GetCommandType()] = append(candidates[cmd.GetCommandType()], cmd)
+		}
+	}
+
+	player := view.GetPlayer()
+	if canDeclareOut(view, acceptedCommands) {
+		addCandidate(newOutCommand(acceptedCommands))
+	}
+	if tile := view.GetClaimableTile(); tile != nil {
+		addCandidate(ui.NewPassCommand())
+		if player.CanDeclarePong(tile) {
+			addCandidate(ui.NewPongCommand())
+		}
+		if player.CanDeclareKong(tile) {
+			addCandidate(ui.NewKongCommand())
+		}
+		for _, indices := range player.FindChowIndices(tile) {
+			addCandidate(ui.NewChowCommand(indices[0], indices[1]))
+		}
+	} else {
+		for index := 0; index < player.GetHand().NumTiles(); index++ {
+			addCandidate(ui.NewDiscardTileCommand(index))
+		}
+		for _, index := range player.FindConcealedKongIndices() {
+			addCandidate(ui.NewConcealedKongCommand(index))
+		}
+		for _, index := range player.FindAdditionalKongIndices() {
+			addCandidate(ui.NewAdditionalKongCommand(index))
+		}
+	}
+
+	// Sort the command types so that the selection only depends on the random number generator.
+	var cmdTypes []ui.CommandType
+	for cmdType := range candidates {
+		cmdTypes = append(cmdTypes, cmdType)
+	}
+	sort.Strings(cmdTypes)
+	cmds := candidates[cmdTypes[s.rng.Intn(len(cmdTypes))]]
+	return cmds[s.rng.Intn(len(cmds))]
+}
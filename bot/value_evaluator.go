@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/rules"
+)
+
+const (
+	// oneSuitThreshold is the minimum fraction of tiles of a single simple suit (and honors) for
+	// the hand to aim for a one suit pattern, e.g. 混一色 / 清一色 in zj.
+	oneSuitThreshold = 0.7
+	// minPairsForAllTriplets is the minimum number of pairs (or better) in a hand without melded
+	// chows for the hand to aim for the all triplets pattern, e.g. 對對和 in zj.
+	minPairsForAllTriplets = 4
+)
+
+// valueEvaluator aims for high-value patterns. Among the discards with the best shanten number,
+// ready hands are ranked by the expected score of the waits, and other hands keep the tiles that
+// contribute to a one suit or all triplets pattern. Melds that break these patterns are rejected.
+type valueEvaluator struct{}
+
+func (e *valueEvaluator) chooseDiscard(view engine.PlayerView, player *rules.PlayerGameState,
+	advices rules.DiscardAdvices) *rules.DiscardAdvice {
+	best := advices[0]
+	if best.Shanten == rules.ShantenReady {
+		for _, advice := range advices[1:] {
+			if advice.Shanten == best.Shanten && expectedValue(advice) > expectedValue(best) {
+				best = advice
+			}
+		}
+		return best
+	}
+
+	target := newPatternTarget(player)
+	for _, advice := range advices {
+		if advice.Shanten != best.Shanten {
+			break
+		}
+		if target.isUnwanted(advice.Tile) ||
+			(target.allTriplets && target.handCounts[advice.Tile] == 1) {
+			return advice
+		}
+	}
+	return best
+}
+
+func (e *valueEvaluator) acceptMeld(player *rules.PlayerGameState, group *rules.TileGroup) bool {
+	target := newPatternTarget(player)
+	if target.allTriplets && group.GetGroupType() == rules.TileGroupTypeChow {
+		return false
+	}
+	return !target.isUnwanted(group.GetTiles()[0].TileBase)
+}
+
+func (e *valueEvaluator) getScorer(view engine.PlayerView) rules.OutPlansScorer {
+	return view.GetRuleSet().GetOutPlansScorer()
+}
+
+// expectedValue returns the expected score of a ready hand, weighted by the number of winning
+// tiles.
+func expectedValue(advice *rules.DiscardAdvice) float64 {
+	return advice.EstimatedValue * float64(advice.NumUsefulTiles)
+}
+
+// patternTarget is the high-value pattern that a hand is aiming for.
+type patternTarget struct {
+	// oneSuit is the simple suit of the one suit pattern, or nil if not aiming for one suit.
+	oneSuit *domain.Suit
+	// allTriplets is whether the hand is aiming for all triplets.
+	allTriplets bool
+	// handCounts is the number of tiles of each kind in the hand.
+	handCounts map[domain.TileBase]int
+}
+
+func newPatternTarget(player *rules.PlayerGameState) *patternTarget {
+	var tiles domain.Tiles
+	tiles = append(tiles, player.GetHand().GetTiles()...)
+	hasMeldedChow := false
+	for _, group := range player.GetMeldGroups() {
+		tiles = append(tiles, group.GetTiles()...)
+		if group.GetGroupType() == rules.TileGroupTypeChow {
+			hasMeldedChow = true
+		}
+	}
+
+	target := &patternTarget{handCounts: make(map[domain.TileBase]int)}
+	for _, tile := range player.GetHand().GetTiles() {
+		target.handCounts[tile.TileBase]++
+	}
+	countsBySuit := make(map[*domain.Suit]int)
+	numHonorTiles := 0
+	for _, tile := range tiles {
+		if rules.CanChow(tile.GetSuit()) {
+			countsBySuit[tile.GetSuit()]++
+		} else {
+			numHonorTiles++
+		}
+	}
+	for suit, count := range countsBySuit {
+		if float64(count+numHonorTiles) >= oneSuitThreshold*float64(len(tiles)) {
+			target.oneSuit = suit
+		}
+	}
+
+	if !hasMeldedChow {
+		numPairs := len(player.GetMeldGroups())
+		for _, count := range target.handCounts {
+			if count >= 2 {
+				numPairs++
+			}
+		}
+		target.allTriplets = numPairs >= minPairsForAllTriplets
+	}
+	return target
+}
+
+// isUnwanted returns whether the given tile does not contribute to the target pattern.
+func (t *patternTarget) isUnwanted(tile domain.TileBase) bool {
+	return t.oneSuit != nil && rules.CanChow(tile.GetSuit()) && tile.GetSuit() != t.oneSuit
+}
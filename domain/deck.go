@@ -11,9 +11,11 @@ type Deck interface {
 	NumRemainingTiles() int
 	// IsEmpty returns true if the Deck is empty.
 	IsEmpty() bool
-	// Shuttle randomly shuffles the tiles in the deck. It is assumed that the random source has
-	// been properly seeded.
-	Shuffle()
+	// Shuffle randomly shuffles the tiles in the deck using the given random source. Shuffling
+	// identical decks with identically seeded sources results in the same tile order.
+	Shuffle(rng *rand.Rand)
+	// GetRemainingTiles returns a copy of the remaining tiles in the deck, from front to back.
+	GetRemainingTiles() Tiles
 	// PopFront removes a tile from the front of the deck and returns it. If the deck is empty,
 	// an error will be returned.
 	PopFront() (*Tile, error)
@@ -38,12 +40,17 @@ func (d *SliceDeck) IsEmpty() bool {
 }
 
 // Shuffle ... (Deck implementation)
-func (d *SliceDeck) Shuffle() {
-	rand.Shuffle(len(d.tiles), func(i, j int) {
+func (d *SliceDeck) Shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(d.tiles), func(i, j int) {
 		d.tiles[i], d.tiles[j] = d.tiles[j], d.tiles[i]
 	})
 }
 
+// GetRemainingTiles ... (Deck implementation)
+func (d *SliceDeck) GetRemainingTiles() Tiles {
+	return append(Tiles{}, d.tiles...)
+}
+
 // PopFront ... (Deck implementation)
 func (d *SliceDeck) PopFront() (*Tile, error) {
 	if d.IsEmpty() {
@@ -31,8 +31,6 @@ func Test_SliceDeckPopFrontBack(t *testing.T) {
 }
 
 func Test_SliceDeckShuffle(t *testing.T) {
-	rand.Seed(0)
-
 	suit := NewSuit("Dots", SuitTypeSimple, 9, nil)
 	var tiles []*Tile
 	for i := 0; i < suit.GetSize(); i++ {
@@ -43,7 +41,7 @@ func Test_SliceDeckShuffle(t *testing.T) {
 	deck := NewDeck(tiles)
 	assert.False(t, deck.IsEmpty())
 
-	deck.Shuffle()
+	deck.Shuffle(rand.New(rand.NewSource(0)))
 	seen := make(map[*Tile]bool)
 	for !deck.IsEmpty() {
 		tile, err := deck.PopFront()
@@ -56,3 +54,28 @@ func Test_SliceDeckShuffle(t *testing.T) {
 	}
 	assert.Len(t, seen, len(tiles), "Some tiles were lost during shuffle")
 }
+
+func Test_SliceDeckShuffle_SameSeed(t *testing.T) {
+	suit := NewSuit("Dots", SuitTypeSimple, 9, nil)
+	newTiles := func() []*Tile {
+		var tiles []*Tile
+		for i := 0; i < suit.GetSize(); i++ {
+			tile, _ := NewTile(suit, i, 0)
+			require.NotNil(t, tile)
+			tiles = append(tiles, tile)
+		}
+		return tiles
+	}
+	deck1 := NewDeck(newTiles())
+	deck2 := NewDeck(newTiles())
+	deck1.Shuffle(rand.New(rand.NewSource(42)))
+	deck2.Shuffle(rand.New(rand.NewSource(42)))
+	for !deck1.IsEmpty() {
+		tile1, err := deck1.PopFront()
+		require.Nil(t, err)
+		tile2, err := deck2.PopFront()
+		require.Nil(t, err)
+		assert.Equal(t, 0, CompareTiles(tile1, tile2))
+	}
+	assert.True(t, deck2.IsEmpty())
+}
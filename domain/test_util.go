@@ -12,3 +12,22 @@ func CreateTileForTest(t *testing.T, s *Suit, ordinal int) *Tile {
 	require.NotNil(t, tile, "Failed to create file for suit=%s ord=%d", s.GetName(), ordinal)
 	return tile
 }
+
+// CreateTilesForTest creates one Tile of the given suit for each of the given ordinals, in order,
+// and asserts that the Tiles can be created.
+func CreateTilesForTest(t *testing.T, s *Suit, ordinals ...int) Tiles {
+	var tiles Tiles
+	for _, ordinal := range ordinals {
+		tiles = append(tiles, CreateTileForTest(t, s, ordinal))
+	}
+	return tiles
+}
+
+// ConcatTilesForTest returns the given lists of Tiles concatenated in order.
+func ConcatTilesForTest(tilesList ...Tiles) Tiles {
+	var allTiles Tiles
+	for _, tiles := range tilesList {
+		allTiles = append(allTiles, tiles...)
+	}
+	return allTiles
+}
@@ -11,8 +11,21 @@ type Tile struct {
 	// id distinguishes tiles with the same tuit and ordinal, e.g., there may be 4 "Bamboo 1"
 	// tiles, but they will have IDs [0, 1, 2, 3]. This field is typically not shown to the player.
 	id int
+	// variant distinguishes tiles that play the same as the other tiles of the same suit and
+	// ordinal, but may be scored differently, e.g. a red five.
+	variant TileVariant
 }
 
+// TileVariant is an attribute of a tile that does not affect how the tile is played.
+type TileVariant int
+
+const (
+	// TileVariantNone is the variant of a regular tile.
+	TileVariantNone TileVariant = iota
+	// TileVariantRed is the variant of a red tile, e.g. the red five in Riichi MJ.
+	TileVariantRed
+)
+
 // TileBase contains information about the suit and the value of the tile.
 type TileBase struct {
 	suit    *Suit
@@ -34,6 +47,14 @@ func (tb TileBase) GetOrdinal() int {
 	return tb.ordinal
 }
 
+// String ...
+func (tb TileBase) String() string {
+	if tb.suit.friendlyNameFunc != nil {
+		return fmt.Sprintf("[%s]", tb.suit.friendlyNameFunc(&Tile{TileBase: tb}))
+	}
+	return fmt.Sprintf("[suit:%s,ord:%d]", tb.suit.GetName(), tb.ordinal)
+}
+
 // NewTile returns a new Tile with the input parameters, or nil if the input is invalid.
 func NewTile(suit *Suit, ordinal int, id int) (*Tile, error) {
 	if suit == nil {
@@ -42,7 +63,18 @@ func NewTile(suit *Suit, ordinal int, id int) (*Tile, error) {
 	if ordinal < 0 || ordinal >= suit.GetSize() {
 		return nil, fmt.Errorf("Ordinal out of range [%d, %d): %d", 0, suit.GetSize(), ordinal)
 	}
-	tile := &Tile{NewTileBase(suit, ordinal), id}
+	tile := &Tile{NewTileBase(suit, ordinal), id, TileVariantNone}
+	return tile, nil
+}
+
+// NewTileWithVariant returns a new Tile with the input parameters and the given variant, or nil if
+// the input is invalid.
+func NewTileWithVariant(suit *Suit, ordinal int, id int, variant TileVariant) (*Tile, error) {
+	tile, err := NewTile(suit, ordinal, id)
+	if err != nil {
+		return nil, err
+	}
+	tile.variant = variant
 	return tile, nil
 }
 
@@ -56,6 +88,21 @@ func (t *Tile) GetOrdinal() int {
 	return t.ordinal
 }
 
+// GetID ...
+func (t *Tile) GetID() int {
+	return t.id
+}
+
+// GetVariant ...
+func (t *Tile) GetVariant() TileVariant {
+	return t.variant
+}
+
+// IsRed returns whether the tile is a red tile, e.g. a red five.
+func (t *Tile) IsRed() bool {
+	return t.variant == TileVariantRed
+}
+
 // IsTerminal returns whether the tile is considered a terminal tile.
 func (t *Tile) IsTerminal() bool {
 	if t.GetSuit().GetSuitType() != SuitTypeSimple {
@@ -69,11 +116,16 @@ func (t *Tile) String() string {
 	if t.GetSuit().friendlyNameFunc != nil {
 		return fmt.Sprintf("[%s]", t.GetSuit().friendlyNameFunc(t))
 	}
+	if t.variant != TileVariantNone {
+		return fmt.Sprintf("[suit:%s,ord:%d,id:%d,variant:%d]", t.GetSuit().GetName(),
+			t.GetOrdinal(), t.id, t.variant)
+	}
 	return fmt.Sprintf("[suit:%s,ord:%d,id:%d]", t.GetSuit().GetName(), t.GetOrdinal(), t.id)
 }
 
 // CompareTiles is a comparison for tiles. Returns a positive value if tile1 should come
-// before tile2, a negative value if tile2 should come before tile1, or 0 otherwise.
+// before tile2, a negative value if tile2 should come before tile1, or 0 otherwise. The variant is
+// ignored, as tiles of different variants play the same.
 func CompareTiles(tile1, tile2 *Tile) int {
 	if suitTypeDiff := tile1.GetSuit().GetSuitType() - tile2.GetSuit().GetSuitType(); suitTypeDiff != 0 {
 		return int(suitTypeDiff)
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
 )
@@ -39,19 +41,19 @@ func Test_NewTile(t *testing.T) {
 		{
 			"happy path 1",
 			args{suit, 0, 0},
-			&Tile{TileBase{suit, 0}, 0},
+			&Tile{TileBase{suit, 0}, 0, TileVariantNone},
 			false,
 		},
 		{
 			"happy path 2",
 			args{suit, 5, 5},
-			&Tile{TileBase{suit, 5}, 5},
+			&Tile{TileBase{suit, 5}, 5, TileVariantNone},
 			false,
 		},
 		{
 			"happy path 3",
 			args{suit, 9, 9},
-			&Tile{TileBase{suit, 9}, 9},
+			&Tile{TileBase{suit, 9}, 9, TileVariantNone},
 			false,
 		},
 	}
@@ -69,6 +71,23 @@ func Test_NewTile(t *testing.T) {
 	}
 }
 
+func Test_NewTileWithVariant(t *testing.T) {
+	suit := NewSuit("Bamboo", SuitTypeSimple, 9, nil)
+	tile, err := NewTileWithVariant(suit, 4, 0, TileVariantRed)
+	assert.NoError(t, err)
+	assert.True(t, tile.IsRed())
+	assert.Equal(t, TileVariantRed, tile.GetVariant())
+	assert.Equal(t, "[suit:Bamboo,ord:4,id:0,variant:1]", tile.String())
+
+	// The variant does not affect the order of tiles.
+	other, _ := NewTile(suit, 4, 1)
+	assert.False(t, other.IsRed())
+	assert.Equal(t, 0, CompareTiles(tile, other))
+
+	_, err = NewTileWithVariant(suit, 9, 0, TileVariantRed)
+	assert.Error(t, err)
+}
+
 func Test_StringNilFriendlyNameFunc(t *testing.T) {
 	suit := NewSuit("Bamboo", SuitTypeSimple, 10, nil)
 	tile, _ := NewTile(suit, 5, 0)
@@ -99,3 +118,14 @@ func Test_StringNonNilFriendlyNameFunc(t *testing.T) {
 		t.Errorf("Wrong name: expected: %s, actual: %s", expected, actual)
 	}
 }
+
+func Test_TileBaseString(t *testing.T) {
+	friendlyNameFunc := func(t *Tile) string {
+		return fmt.Sprintf("Foo %d", t.GetOrdinal())
+	}
+	suit := NewSuit("Bamboo", SuitTypeSimple, 10, friendlyNameFunc)
+	assert.Equal(t, "[Foo 5]", NewTileBase(suit, 5).String())
+
+	suit = NewSuit("Bamboo", SuitTypeSimple, 10, nil)
+	assert.Equal(t, "[suit:Bamboo,ord:5]", NewTileBase(suit, 5).String())
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	// NumWallSides is the number of sides of a wall, one in front of each seat.
+	NumWallSides = 4
+	// numTilesPerStack is the number of tiles stacked on top of each other in a wall.
+	numTilesPerStack = 2
+	// numDieFaces is the number of faces of a die.
+	numDieFaces = 6
+)
+
+// RollDice returns the total of rolling the given number of dice with the given random source.
+func RollDice(rng *rand.Rand, numDice int) int {
+	total := 0
+	for i := 0; i < numDice; i++ {
+		total += rng.Intn(numDieFaces) + 1
+	}
+	return total
+}
+
+// Wall is an implementation of Deck that models the wall of a game. The tiles are laid out in
+// NumWallSides sides, starting from the side in front of the dealer, in the order they are drawn
+// before the wall is broken. Tiles are drawn from the front of the live wall, while replacement
+// tiles are drawn from the back, which is the dead wall. The dead wall is a number of tiles at the
+// back that are never drawn from the front. The live wall ends where the dead wall begins, so the
+// game is over when the live wall is empty. Drawing a replacement tile moves the last tile of the
+// live wall to the dead wall, so that the dead wall keeps its size.
+type Wall struct {
+	tiles []*Tile
+	// front is the index of the next tile drawn from the front.
+	front int
+	// back is the index after the next tile drawn from the back.
+	back         int
+	deadWallSize int
+	// broken is set once the wall is broken, or a tile is drawn.
+	broken                   bool
+	numReplacementTilesDrawn int
+}
+
+// NewWall creates a new Wall with the given tiles, in order, and the given size of the dead wall.
+func NewWall(tiles []*Tile, deadWallSize int) *Wall {
+	if deadWallSize < 0 {
+		panic(fmt.Errorf("Invalid dead wall size: %d", deadWallSize))
+	}
+	return &Wall{tiles: tiles, back: len(tiles), deadWallSize: deadWallSize}
+}
+
+// GetSideLength returns the number of tiles in each side of the wall.
+func (w *Wall) GetSideLength() int {
+	return len(w.tiles) / NumWallSides
+}
+
+// Break breaks the wall at the point determined by the given total of the dice. The side to break
+// is counted counter-clockwise from the dealer, starting with the dealer, and the break point is
+// the given number of stacks into that side. Tiles are then drawn from the break point onwards,
+// and replacement tiles from the tiles before it. Returns an error if the wall is already broken.
+func (w *Wall) Break(diceTotal int) error {
+	if w.broken {
+		return fmt.Errorf("Wall is already broken")
+	}
+	if diceTotal <= 0 {
+		return fmt.Errorf("Invalid dice total: %d", diceTotal)
+	}
+	w.broken = true
+	if len(w.tiles) == 0 {
+		return nil
+	}
+	side := (diceTotal - 1) % NumWallSides
+	breakIndex := (side*w.GetSideLength() + diceTotal*numTilesPerStack) % len(w.tiles)
+	var tiles []*Tile
+	tiles = append(tiles, w.tiles[breakIndex:]...)
+	tiles = append(tiles, w.tiles[:breakIndex]...)
+	w.tiles = tiles
+	return nil
+}
+
+// GetDeadWallSize returns the number of tiles reserved for the dead wall.
+func (w *Wall) GetDeadWallSize() int {
+	return w.deadWallSize
+}
+
+// GetDeadWallTiles returns a copy of the tiles in the dead wall, from front to back.
+func (w *Wall) GetDeadWallTiles() Tiles {
+	return append(Tiles{}, w.tiles[w.getLiveWallEnd():w.back]...)
+}
+
+// GetNumReplacementTilesDrawn returns the number of tiles that have been drawn from the back.
+func (w *Wall) GetNumReplacementTilesDrawn() int {
+	return w.numReplacementTilesDrawn
+}
+
+// getLiveWallEnd returns the index after the last tile of the live wall.
+func (w *Wall) getLiveWallEnd() int {
+	end := w.back - w.deadWallSize
+	if end < w.front {
+		return w.front
+	}
+	return end
+}
+
+// NumRemainingTiles ... (Deck implementation)
+// Only the tiles in the live wall are counted.
+func (w *Wall) NumRemainingTiles() int {
+	return w.getLiveWallEnd() - w.front
+}
+
+// IsEmpty ... (Deck implementation)
+// The wall is empty when the live wall is empty, even if tiles remain in the dead wall.
+func (w *Wall) IsEmpty() bool {
+	return w.NumRemainingTiles() == 0
+}
+
+// Shuffle ... (Deck implementation)
+func (w *Wall) Shuffle(rng *rand.Rand) {
+	remaining := w.tiles[w.front:w.back]
+	rng.Shuffle(len(remaining), func(i, j int) {
+		remaining[i], remaining[j] = remaining[j], remaining[i]
+	})
+}
+
+// GetRemainingTiles ... (Deck implementation)
+// The tiles of the dead wall are included.
+func (w *Wall) GetRemainingTiles() Tiles {
+	return append(Tiles{}, w.tiles[w.front:w.back]...)
+}
+
+// PopFront ... (Deck implementation)
+func (w *Wall) PopFront() (*Tile, error) {
+	if w.IsEmpty() {
+		return nil, fmt.Errorf("Wall is empty")
+	}
+	w.broken = true
+	tile := w.tiles[w.front]
+	w.front++
+	return tile, nil
+}
+
+// PopBack ... (Deck implementation)
+// The tile is drawn from the dead wall, which takes the last tile of the live wall in exchange.
+func (w *Wall) PopBack() (*Tile, error) {
+	if w.IsEmpty() {
+		return nil, fmt.Errorf("Wall is empty")
+	}
+	w.broken = true
+	w.back--
+	w.numReplacementTilesDrawn++
+	return w.tiles[w.back], nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+// newTilesForWallTest returns 16 distinct tiles: 1-8 Dots and 1-8 Bamboo.
+func newTilesForWallTest(t *testing.T) []*Tile {
+	var tiles []*Tile
+	for _, name := range []string{"Dots", "Bamboo"} {
+		suit := NewSuit(name, SuitTypeSimple, 9, nil)
+		for i := 0; i < 8; i++ {
+			tile, err := NewTile(suit, i, 0)
+			require.NoError(t, err)
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles
+}
+
+func Test_WallWithoutDeadWall(t *testing.T) {
+	tiles := newTilesForWallTest(t)
+	wall := NewWall(tiles, 0)
+	assert.Equal(t, 16, wall.NumRemainingTiles())
+	assert.Equal(t, 4, wall.GetSideLength())
+	assert.Empty(t, wall.GetDeadWallTiles())
+
+	tile, err := wall.PopFront()
+	require.NoError(t, err)
+	assert.Equal(t, tiles[0], tile)
+	tile, err = wall.PopBack()
+	require.NoError(t, err)
+	assert.Equal(t, tiles[15], tile)
+	assert.Equal(t, 14, wall.NumRemainingTiles())
+	assert.Equal(t, 1, wall.GetNumReplacementTilesDrawn())
+
+	for !wall.IsEmpty() {
+		_, err := wall.PopFront()
+		require.NoError(t, err)
+	}
+	_, err = wall.PopFront()
+	assert.Error(t, err)
+	_, err = wall.PopBack()
+	assert.Error(t, err)
+}
+
+func Test_WallDeadWall(t *testing.T) {
+	tiles := newTilesForWallTest(t)
+	wall := NewWall(tiles, 4)
+	// The dead wall is not part of the live wall.
+	assert.Equal(t, 12, wall.NumRemainingTiles())
+	assert.Equal(t, Tiles(tiles[12:]), wall.GetDeadWallTiles())
+	assert.Len(t, wall.GetRemainingTiles(), 16)
+
+	// A replacement tile is drawn from the dead wall, which takes the last tile of the live wall.
+	tile, err := wall.PopBack()
+	require.NoError(t, err)
+	assert.Equal(t, tiles[15], tile)
+	assert.Equal(t, 11, wall.NumRemainingTiles())
+	assert.Equal(t, Tiles(tiles[11:15]), wall.GetDeadWallTiles())
+
+	for i := 0; i < 11; i++ {
+		tile, err := wall.PopFront()
+		require.NoError(t, err)
+		assert.Equal(t, tiles[i], tile)
+	}
+	// The game is over with the dead wall remaining.
+	assert.True(t, wall.IsEmpty())
+	_, err = wall.PopFront()
+	assert.Error(t, err)
+	_, err = wall.PopBack()
+	assert.Error(t, err)
+	assert.Len(t, wall.GetDeadWallTiles(), 4)
+}
+
+func Test_WallBreak(t *testing.T) {
+	tiles := newTilesForWallTest(t)
+	wall := NewWall(append([]*Tile{}, tiles...), 2)
+	// A total of 7 breaks the third side, 7 stacks (14 tiles) in: (8 + 14) % 16 = 6.
+	require.NoError(t, wall.Break(7))
+	assert.Error(t, wall.Break(7))
+
+	tile, err := wall.PopFront()
+	require.NoError(t, err)
+	assert.Equal(t, tiles[6], tile)
+	// Replacement tiles are the ones before the break point.
+	tile, err = wall.PopBack()
+	require.NoError(t, err)
+	assert.Equal(t, tiles[5], tile)
+	assert.Equal(t, Tiles{tiles[3], tiles[4]}, wall.GetDeadWallTiles())
+}
+
+func Test_WallBreakAfterDraw(t *testing.T) {
+	wall := NewWall(newTilesForWallTest(t), 0)
+	_, err := wall.PopFront()
+	require.NoError(t, err)
+	assert.Error(t, wall.Break(7))
+}
+
+func Test_RollDice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		total := RollDice(rng, 3)
+		assert.True(t, total >= 3 && total <= 18, "Invalid total %d", total)
+	}
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/report"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/golang/glog"
+	"io"
+)
+
+// meldNames are the names of the meld types printed by ConsoleObserver.
+var meldNames = map[ui.CommandType]string{
+	ui.Pong:           "pong",
+	ui.Kong:           "kong",
+	ui.Chow:           "chow",
+	ui.ConcealedKong:  "concealed kong",
+	ui.AdditionalKong: "additional kong",
+}
+
+// ConsoleObserver is a GameObserver that prints the events of a game to a writer, e.g. stdout. The
+// hands and drawn tiles are only printed for the visible seats, e.g. the seats played on the
+// console. If -mj.output is json, only the scoring results are written, one JSON object per line,
+// so that the output can be parsed by other tools.
+type ConsoleObserver struct {
+	out          io.Writer
+	visibleSeats map[int]bool
+	jsonOutput   bool
+}
+
+// NewConsoleObserver returns a new ConsoleObserver that writes to the given writer and shows the
+// hands and drawn tiles of the given seats. The output format is given by -mj.output.
+func NewConsoleObserver(out io.Writer, visibleSeats []int) *ConsoleObserver {
+	o := &ConsoleObserver{
+		out:          out,
+		visibleSeats: make(map[int]bool),
+		jsonOutput:   *flags.OutputFlag == flags.OutputFormatJSON,
+	}
+	for _, seat := range visibleSeats {
+		o.visibleSeats[seat] = true
+	}
+	return o
+}
+
+// OnGameEvent ... (GameObserver implementation)
+func (o *ConsoleObserver) OnGameEvent(event GameEvent) {
+	if o.jsonOutput {
+		o.writeScoringResult(event)
+		return
+	}
+	switch e := event.(type) {
+	case *TurnStartedEvent:
+		o.println(separator)
+		o.println(e)
+	case *HandUpdatedEvent:
+		if !o.visibleSeats[e.Seat] {
+			break
+		}
+		o.println(e)
+		o.printf("Shorthand: %s\n", shorthand.NewFormatter().FormatTiles(e.Tiles))
+		if len(e.MeldGroups) > 0 {
+			o.printf("%s melded groups: %s\n", rules.GetWindName(e.Seat), e.MeldGroups)
+		}
+	case *TileDrawnEvent:
+		if o.visibleSeats[e.Seat] {
+			o.println(e)
+		} else if e.FromBack {
+			o.printf("%s drew a replacement tile\n", rules.GetWindName(e.Seat))
+		} else {
+			o.printf("%s drew a tile\n", rules.GetWindName(e.Seat))
+		}
+	case *KongReplacementDrawnEvent:
+		if o.visibleSeats[e.Seat] {
+			o.println(e)
+		} else {
+			o.printf("%s drew a kong replacement tile\n", rules.GetWindName(e.Seat))
+		}
+	case *ClaimOfferedEvent, *CommandRejectedEvent, *InfoShownEvent:
+		if o.visibleSeats[e.GetSeat()] {
+			o.println(e)
+		}
+	case *MeldDeclaredEvent:
+		o.printf("%s declared %s %s\n", rules.GetWindName(e.Seat), meldNames[e.MeldType], e.Tiles)
+	case *OutDeclaredEvent:
+		o.println(e)
+		if e.ScoredPlans != nil {
+			o.printf("Detailed scoring:\n")
+			o.println(e.ScoredPlans)
+		}
+	default:
+		o.println(e)
+	}
+}
+
+// writeScoringResult writes the scoring result of the given event as JSON, if it is a scored Out.
+func (o *ConsoleObserver) writeScoringResult(event GameEvent) {
+	e, ok := event.(*OutDeclaredEvent)
+	if !ok || e.ScoredPlans == nil {
+		return
+	}
+	result := report.NewScoringResult(e.ScoredPlans, e.ScoringContext)
+	if err := report.WriteJSON(o.out, result); err != nil {
+		glog.Errorf("Unable to write scoring result: %s\n", err)
+	}
+}
+
+func (o *ConsoleObserver) printf(format string, args ...interface{}) {
+	fmt.Fprintf(o.out, format, args...)
+}
+
+func (o *ConsoleObserver) println(value interface{}) {
+	fmt.Fprintf(o.out, "%s\n", value)
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func Test_ConsoleObserver_HidesOtherSeats(t *testing.T) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	deck := newMultiPlayerDeckForTest(t, robbingKongHands, robbingKongFront, "")
+	runScriptedMultiPlayerGame(t, ruleSet, deck, []string{
+		"discard 4\ndiscard 13\n",
+		"pong\ndiscard 9\nakong 10\n",
+		"discard 12\nrob\n",
+		"discard 13\n",
+	}, NewConsoleObserver(&out, []int{2}))
+
+	output := out.String()
+	assert.Contains(t, output, "West hand:")
+	assert.Contains(t, output, "West drew tile")
+	assert.Contains(t, output, "West may rob the kong of South")
+	assert.NotContains(t, output, "South hand:")
+	assert.NotContains(t, output, "South drew tile")
+	assert.Contains(t, output, "South drew a tile")
+	assert.NotContains(t, output, "may claim")
+	assert.Contains(t, output, "South: akong 10")
+}
+
+func Test_ConsoleObserver_JSONOutput(t *testing.T) {
+	defer func(original string) { *flags.OutputFlag = original }(*flags.OutputFlag)
+	*flags.OutputFlag = flags.OutputFormatJSON
+	defer func(original bool) { *flags.ReportScoringFlag = original }(*flags.ReportScoringFlag)
+	*flags.ReportScoringFlag = true
+
+	var out bytes.Buffer
+	deck := newDeckForTest(t, "1112b1f123456789m2b", "5d")
+	result, _ := runScriptedGame(t, 0, deck, "discard 13\nout\n",
+		NewConsoleObserver(&out, []int{0}))
+	require.True(t, result.IsOut())
+
+	// The output only contains the scoring result of the Out, one JSON object per line.
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 1)
+	for _, line := range lines {
+		var value map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &value), "Invalid JSON line: %s", line)
+		assert.Contains(t, value, "plans")
+		assert.Contains(t, value, "context")
+	}
+}
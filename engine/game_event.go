@@ -0,0 +1,343 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/ui"
+)
+
+// NoSeat is the seat of an event that applies to the whole game rather than a single seat.
+const NoSeat = -1
+
+// GameEvent is an event that occurred during a game. Observers use a type switch on the event
+// types below to access the details of an event.
+type GameEvent interface {
+	// GetSeat returns the wind ordinal of the seat the event applies to, or NoSeat.
+	GetSeat() int
+	String() string
+}
+
+// GameObserver is notified of every event of a game, in the order they occur. Observers must not
+// modify the tiles or states referenced by an event.
+type GameObserver interface {
+	OnGameEvent(event GameEvent)
+}
+
+// gameObservers is a list of GameObserver that are notified in the order they were added.
+type gameObservers []GameObserver
+
+func (o gameObservers) notify(event GameEvent) {
+	for _, observer := range o {
+		observer.OnGameEvent(event)
+	}
+}
+
+// GameStartedEvent is emitted after the hands are dealt, before any bonus tile is replaced.
+type GameStartedEvent struct {
+	NumPlayers        int
+	NumRemainingTiles int
+	// PrevailingWindOrdinal is the wind of the round.
+	PrevailingWindOrdinal int
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *GameStartedEvent) GetSeat() int {
+	return NoSeat
+}
+
+// String ...
+func (e *GameStartedEvent) String() string {
+	return fmt.Sprintf("Game started with %d players in the %s round, %d tiles remaining in deck",
+		e.NumPlayers, rules.GetWindName(e.PrevailingWindOrdinal), e.NumRemainingTiles)
+}
+
+// HandUpdatedEvent is emitted with the full hand of a seat after the initial bonus tiles are
+// replaced, whenever the hand is sorted, and before the seat is prompted to act on its turn or on
+// a claim. Only observers that show the seat should print it.
+type HandUpdatedEvent struct {
+	Seat       int
+	Tiles      domain.Tiles
+	MeldGroups rules.TileGroups
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *HandUpdatedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *HandUpdatedEvent) String() string {
+	return fmt.Sprintf("%s hand: %s", rules.GetWindName(e.Seat), e.Tiles)
+}
+
+// TurnStartedEvent is emitted at the start of the turn of a seat, including a turn that starts
+// with a meld claimed from a discard. Turns are numbered from 1.
+type TurnStartedEvent struct {
+	Seat int
+	Turn int
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *TurnStartedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *TurnStartedEvent) String() string {
+	return fmt.Sprintf("Start of turn %d of %s", e.Turn, rules.GetWindName(e.Seat))
+}
+
+// TileDrawnEvent is emitted when a seat draws a tile, other than a replacement for a kong.
+type TileDrawnEvent struct {
+	Seat int
+	Tile *domain.Tile
+	// FromBack is true if the tile is drawn from the back of the deck to replace a bonus tile.
+	FromBack bool
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *TileDrawnEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *TileDrawnEvent) String() string {
+	if e.FromBack {
+		return fmt.Sprintf("%s drew replacement tile %s", rules.GetWindName(e.Seat), e.Tile)
+	}
+	return fmt.Sprintf("%s drew tile %s", rules.GetWindName(e.Seat), e.Tile)
+}
+
+// KongReplacementDrawnEvent is emitted when a seat draws a tile from the back of the deck after
+// declaring a kong.
+type KongReplacementDrawnEvent struct {
+	Seat int
+	Tile *domain.Tile
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *KongReplacementDrawnEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *KongReplacementDrawnEvent) String() string {
+	return fmt.Sprintf("%s drew kong replacement tile %s", rules.GetWindName(e.Seat), e.Tile)
+}
+
+// BonusTileMovedEvent is emitted when a bonus tile is moved to the bonus area of a seat.
+type BonusTileMovedEvent struct {
+	Seat int
+	Tile *domain.Tile
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *BonusTileMovedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *BonusTileMovedEvent) String() string {
+	return fmt.Sprintf("%s added tile to bonus area: %s", rules.GetWindName(e.Seat), e.Tile)
+}
+
+// TileDiscardedEvent is emitted when a seat discards a tile, before other seats may claim it.
+type TileDiscardedEvent struct {
+	Seat int
+	Tile *domain.Tile
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *TileDiscardedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *TileDiscardedEvent) String() string {
+	return fmt.Sprintf("%s discarded tile %s", rules.GetWindName(e.Seat), e.Tile)
+}
+
+// MeldDeclaredEvent is emitted when a seat declares a meld group.
+type MeldDeclaredEvent struct {
+	Seat int
+	// MeldType is one of ui.Pong, ui.Kong, ui.Chow, ui.ConcealedKong and ui.AdditionalKong.
+	MeldType ui.CommandType
+	// Tile is the claimed tile, or the kong tile for a concealed or additional kong.
+	Tile *domain.Tile
+	// Tiles are the tiles of the resulting meld group.
+	Tiles domain.Tiles
+	// DiscarderSeat is the seat that discarded the claimed tile, or NoSeat for a concealed or
+	// additional kong.
+	DiscarderSeat int
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *MeldDeclaredEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *MeldDeclaredEvent) String() string {
+	return fmt.Sprintf("%s declared %s %s", rules.GetWindName(e.Seat), e.MeldType, e.Tiles)
+}
+
+// RiichiDeclaredEvent is emitted when a seat declares riichi, right before the accompanying
+// TileDiscardedEvent.
+type RiichiDeclaredEvent struct {
+	Seat int
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *RiichiDeclaredEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *RiichiDeclaredEvent) String() string {
+	return fmt.Sprintf("%s declared riichi", rules.GetWindName(e.Seat))
+}
+
+// OutDeclaredEvent is emitted when a seat declares a valid Out. The game is over.
+type OutDeclaredEvent struct {
+	Seat          int
+	OutTileSource *rules.OutTileSource
+	// ScoredPlans are the scored Out plans, or nil if scoring is not reported.
+	ScoredPlans rules.ScoredOutPlans
+	// ScoringContext is the context the plans are scored with, or nil if scoring is not reported.
+	ScoringContext *rules.OutPlanScoringContext
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *OutDeclaredEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *OutDeclaredEvent) String() string {
+	return fmt.Sprintf("%s declared Out: %s.", rules.GetWindName(e.Seat), e.OutTileSource)
+}
+
+// GameDrawnEvent is emitted when a tile must be drawn from an empty deck. The game is over.
+type GameDrawnEvent struct{}
+
+// GetSeat ... (GameEvent implementation)
+func (e *GameDrawnEvent) GetSeat() int {
+	return NoSeat
+}
+
+// String ...
+func (e *GameDrawnEvent) String() string {
+	return "Game drawn: the deck is empty"
+}
+
+// CommandReceivedEvent is emitted when the runner receives a command from the receiver of a seat,
+// before the command is executed. The command may be rejected by the runner.
+type CommandReceivedEvent struct {
+	Seat    int
+	Command *ui.Command
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *CommandReceivedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *CommandReceivedEvent) String() string {
+	return fmt.Sprintf("%s: %s", rules.GetWindName(e.Seat), e.Command)
+}
+
+// ClaimOfferedEvent is emitted before a seat is asked whether to claim a tile discarded by another
+// seat, or to rob the kong that another seat declared with the tile.
+type ClaimOfferedEvent struct {
+	Seat int
+	Tile *domain.Tile
+	// DiscarderSeat is the seat that discarded the tile, or that added it to a kong.
+	DiscarderSeat int
+	// RobbingKong is true if the tile was added to an additional kong.
+	RobbingKong bool
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *ClaimOfferedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *ClaimOfferedEvent) String() string {
+	if e.RobbingKong {
+		return fmt.Sprintf("%s may rob the kong of %s with %s", rules.GetWindName(e.Seat),
+			rules.GetWindName(e.DiscarderSeat), e.Tile)
+	}
+	return fmt.Sprintf("%s may claim %s discarded by %s", rules.GetWindName(e.Seat), e.Tile,
+		rules.GetWindName(e.DiscarderSeat))
+}
+
+// CommandRejectedEvent is emitted when the runner rejects the last command received from a seat,
+// e.g. an Out with a hand that is not an Out. The seat is prompted again.
+type CommandRejectedEvent struct {
+	Seat int
+	// Reason describes why the command is rejected.
+	Reason string
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *CommandRejectedEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *CommandRejectedEvent) String() string {
+	return fmt.Sprintf("%s: %s", rules.GetWindName(e.Seat), e.Reason)
+}
+
+// InfoShownEvent is emitted with information for a seat, either requested by a command, e.g. the
+// discard advice of Hint, or following an action, e.g. the waits of a hand that became ready. Only
+// observers that show the seat should print it.
+type InfoShownEvent struct {
+	Seat int
+	Info string
+}
+
+// GetSeat ... (GameEvent implementation)
+func (e *InfoShownEvent) GetSeat() int {
+	return e.Seat
+}
+
+// String ...
+func (e *InfoShownEvent) String() string {
+	return e.Info
+}
+
+// newHandUpdatedEvent returns the event of the given seat with the current hand and meld groups of
+// the given player.
+func newHandUpdatedEvent(seat int, player *rules.PlayerGameState) *HandUpdatedEvent {
+	return &HandUpdatedEvent{
+		Seat:       seat,
+		Tiles:      append(domain.Tiles{}, player.GetHand().GetTiles()...),
+		MeldGroups: append(rules.TileGroups{}, player.GetMeldGroups()...),
+	}
+}
+
+// newMeldDeclaredEvent returns the event of the given seat declaring the meld group containing
+// the given tile, or an error if the player has no such meld group.
+func newMeldDeclaredEvent(seat int, player *rules.PlayerGameState, meldType ui.CommandType,
+	tile *domain.Tile, discarderSeat int) (*MeldDeclaredEvent, error) {
+	event := &MeldDeclaredEvent{
+		Seat:          seat,
+		MeldType:      meldType,
+		Tile:          tile,
+		DiscarderSeat: discarderSeat,
+	}
+	for _, group := range player.GetMeldGroups() {
+		for _, groupTile := range group.GetTiles() {
+			if groupTile == tile {
+				// Copy the tiles as an additional kong modifies the group.
+				event.Tiles = append(domain.Tiles{}, group.GetTiles()...)
+				return event, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Failed to find meld group of tile %s", tile)
+}
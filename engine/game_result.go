@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// GameEndReason is the reason a game ended.
+type GameEndReason int
+
+const (
+	// GameEndReasonOut means that a player declared an Out.
+	GameEndReasonOut GameEndReason = iota
+	// GameEndReasonWallExhausted means that a tile was required to be drawn from an empty deck.
+	GameEndReasonWallExhausted
+	// GameEndReasonInputAborted means that a CommandReceiver failed to return a command.
+	GameEndReasonInputAborted
+)
+
+// String ...
+func (r GameEndReason) String() string {
+	switch r {
+	case GameEndReasonOut:
+		return "Out"
+	case GameEndReasonWallExhausted:
+		return "Wall exhausted"
+	case GameEndReasonInputAborted:
+		return "Input aborted"
+	}
+	return fmt.Sprintf("GameEndReason(%d)", int(r))
+}
+
+// GameResult is the outcome of a game that ran to its end.
+type GameResult struct {
+	Reason GameEndReason
+	// Seat is the seat that declared Out, or whose input was aborted. It is NoSeat if the wall is
+	// exhausted.
+	Seat int
+	// OutTileSource is the source of the Out tile, or nil if no player declared an Out.
+	OutTileSource *rules.OutTileSource
+	// WinningPlan is the highest scoring Out plan of the winner, or nil if no player declared an
+	// Out.
+	WinningPlan *rules.ScoredOutPlan
+	// InputErr is the error returned by the CommandReceiver if the input was aborted.
+	InputErr error
+}
+
+// IsOut returns whether the game ended with an Out.
+func (r *GameResult) IsOut() bool {
+	return r.Reason == GameEndReasonOut
+}
+
+// String ...
+func (r *GameResult) String() string {
+	switch r.Reason {
+	case GameEndReasonOut:
+		str := fmt.Sprintf("%s declared Out (%s)", rules.GetWindName(r.Seat),
+			r.OutTileSource.SourceType)
+		if r.WinningPlan != nil {
+			str += fmt.Sprintf(" with a score of %d", r.WinningPlan.TotalScore)
+		}
+		return str
+	case GameEndReasonInputAborted:
+		return fmt.Sprintf("Input of %s aborted: %s", rules.GetWindName(r.Seat), r.InputErr)
+	}
+	return r.Reason.String()
+}
+
+func newOutGameResult(seat int, outTileSource *rules.OutTileSource,
+	scoredPlans rules.ScoredOutPlans) *GameResult {
+	result := &GameResult{Reason: GameEndReasonOut, Seat: seat, OutTileSource: outTileSource}
+	if len(scoredPlans) > 0 {
+		// Scored plans are sorted from the highest score.
+		result.WinningPlan = scoredPlans[0]
+	}
+	return result
+}
+
+func newWallExhaustedGameResult() *GameResult {
+	return &GameResult{Reason: GameEndReasonWallExhausted, Seat: NoSeat}
+}
+
+func newInputAbortedGameResult(seat int, err error) *GameResult {
+	return &GameResult{Reason: GameEndReasonInputAborted, Seat: seat, InputErr: err}
+}
+
+// gameOverError is returned up the call chain of a runner to end the game sequence with the given
+// result. Runners convert it to the result returned by Start, so it never leaves the engine.
+type gameOverError struct {
+	result *GameResult
+}
+
+func newGameOverError(result *GameResult) *gameOverError {
+	return &gameOverError{result: result}
+}
+
+// Error ... (error implementation)
+func (e *gameOverError) Error() string {
+	return fmt.Sprintf("Game over: %s", e.result)
+}
+
+// getGameResult returns the result of a game sequence that ended with the given error. Errors
+// other than gameOverError are returned as is.
+func getGameResult(err error) (*GameResult, error) {
+	if err == nil {
+		return nil, fmt.Errorf("Game sequence ended without a result")
+	}
+	if gameOverErr, ok := err.(*gameOverError); ok {
+		return gameOverErr.result, nil
+	}
+	return nil, err
+}
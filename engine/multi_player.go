@@ -0,0 +1,706 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// NumPlayers is the number of players in a multi player game.
+const NumPlayers = 4
+
+// getPrevailingWindOrdinal returns the wind ordinal of the prevailing wind given by
+// -mj.prevailingWind.
+func getPrevailingWindOrdinal() int {
+	windSeat := *flags.PrevailingWindFlag
+	if windSeat < 1 || windSeat > rules.Winds.GetSize() {
+		panic(fmt.Errorf("Invalid value for prevailingWindFlag: %d", windSeat))
+	}
+	return windSeat - 1
+}
+
+// claimPriority returns the priority of a claim on a discarded tile. Higher values take
+// precedence. Pass has the lowest priority.
+func claimPriority(cmdType ui.CommandType) int {
+	switch cmdType {
+	case ui.Out:
+		return 3
+	case ui.Pong, ui.Kong:
+		return 2
+	case ui.Chow:
+		return 1
+	}
+	return 0
+}
+
+// claim is a player's response to a discarded tile.
+type claim struct {
+	seat int
+	cmd  *ui.Command
+	// outTileSource is the source of the discarded tile, should the claim be an Out.
+	outTileSource *rules.OutTileSource
+}
+
+// MultiPlayerRunner is the runner for the four player game.
+// The game proceeds as follows:
+// (1) Each player is dealt tiles from the front of a shuffled deck, with East (seat 0) receiving
+//     the extra tile.
+// (2) In seat order, each player replaces the bonus tiles that were dealt, until there are no more
+//     bonus tiles in any hand.
+// (3) The following consists of a single turn of the current player: Drawing -> Player action ->
+//     Discard -> Claim. East does not draw in the first turn.
+// Drawing:
+// (D1) The player draws a tile from the front of the deck. Bonus tiles are moved to the bonus area
+//      and replaced with tiles from the back of the deck.
+// Player action:
+// (P1) The player may declare a concealed or additional kong, after which a replacement tile is
+//      drawn and (P1) is repeated, or the player may declare Out, in which case the game is over.
+// (P2) If the rule set allows it, before the replacement tile of an additional kong is drawn,
+//      every other player that can form an Out with the added tile may rob the kong, in seat order
+//      from the player. Robbing the kong is an Out, in which case the game is over.
+// Discard:
+// (R1) The player discards a tile from their hand.
+// Claim:
+// (C1) Every other player may claim the discarded tile. Out takes precedence over Pong / Kong,
+//      which takes precedence over Chow. Only the next player may claim a chow. Ties are broken by
+//      seat order from the discarder.
+// (C2) If the tile is claimed for an Out, the game is over. Otherwise, the claiming player melds
+//      the tile and becomes the current player. After a kong, a replacement tile is drawn and
+//      (P1) applies. The claiming player then discards, continuing at (C1).
+// (C3) If the tile is not claimed, it is moved to the discarder's discard area, and the next player
+//      becomes the current player.
+// The game ends if any of the conditions are met:
+// - A player declares an Out.
+// - The deck becomes empty AND a tile is required to be drawn, in which case the game is drawn.
+type MultiPlayerRunner struct {
+	ruleSet rules.RuleSet
+	// receivers contains the CommandReceiver of each seat, indexed by wind ordinal.
+	receivers []ui.CommandReceiver
+	observers gameObservers
+	// prevailingWindOrdinal is the wind of the round, which is used for scoring.
+	prevailingWindOrdinal int
+
+	started bool
+	deck    domain.Deck
+	// players contains the state of each seat, indexed by wind ordinal.
+	players []*rules.PlayerGameState
+
+	// claimableTile is the discarded tile, or the tile added to a kong, that the prompted seat may
+	// claim, if any.
+	claimableTile *domain.Tile
+	// currentOutTileSource is the source of the Out tile if the prompted seat were to declare an
+	// Out, or nil if it may not.
+	currentOutTileSource *rules.OutTileSource
+}
+
+// NewMultiPlayerRunner returns a new instance of MultiPlayerRunner with the given input parameters.
+// There must be exactly NumPlayers receivers, the first of which is East. The same receiver may be
+// used for multiple seats. The prevailing wind is given by -mj.prevailingWind.
+func NewMultiPlayerRunner(ruleSet rules.RuleSet,
+	receivers []ui.CommandReceiver) *MultiPlayerRunner {
+	if len(receivers) != NumPlayers {
+		panic(fmt.Errorf("Invalid number of receivers: %d", len(receivers)))
+	}
+	return &MultiPlayerRunner{
+		ruleSet:               ruleSet,
+		receivers:             receivers,
+		prevailingWindOrdinal: getPrevailingWindOrdinal(),
+	}
+}
+
+// AddObserver adds an observer that is notified of every event of the game. Observers must be
+// added before the game is started.
+func (r *MultiPlayerRunner) AddObserver(observer GameObserver) {
+	r.observers = append(r.observers, observer)
+}
+
+// Start starts the game sequence. This function returns the result of the game when the game
+// ends. Returns an error if the game is already started (or ended), if the game is unable to
+// start, e.g. the deck deals a hand that is invalid under the rule set (see
+// rules.ValidatePlayerGameState), or if the game is unable to proceed.
+func (r *MultiPlayerRunner) Start(deck domain.Deck) (*GameResult, error) {
+	if r.started {
+		return nil, errors.New("Already started")
+	}
+	if deck.IsEmpty() {
+		return nil, errors.New("Deck is empty")
+	}
+
+	glog.V(2).Infof("Starting multi player game")
+	r.started = true
+	for seat, receiver := range r.receivers {
+		if viewReceiver, ok := receiver.(PlayerViewReceiver); ok {
+			viewReceiver.SetPlayerView(&seatView{runner: r, seat: seat})
+		}
+	}
+
+	r.deck = deck
+	err := r.initializePlayers()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to start game")
+	}
+
+	// From here on, the game sequence returns a gameOverError to signal that the game is over.
+	result, err := getGameResult(r.startGameSequence())
+	if err != nil {
+		return nil, err
+	}
+	glog.V(2).Infof("Game over: %s\n", result)
+	return result, nil
+}
+
+func (r *MultiPlayerRunner) initializePlayers() error {
+	glog.V(2).Infof("Initializing hands\n")
+	var hands []*domain.Hand
+	for seat := 0; seat < NumPlayers; seat++ {
+		hands = append(hands, domain.NewHand())
+	}
+	err := rules.PopulateHands(r.deck, hands, r.ruleSet.GetNumTilesPerHand())
+	if err != nil {
+		return err
+	}
+
+	for seat, hand := range hands {
+		hand.Sort()
+		r.players = append(r.players, rules.NewPlayerGameState(hand, seat))
+		glog.V(2).Infof("Populated hand of %s: %s\n", rules.GetWindName(seat), hand)
+	}
+	return nil
+}
+
+func (r *MultiPlayerRunner) startGameSequence() error {
+	glog.V(2).Infof("Starting game sequence\n")
+	r.observers.notify(&GameStartedEvent{
+		NumPlayers:            NumPlayers,
+		NumRemainingTiles:     r.deck.NumRemainingTiles(),
+		PrevailingWindOrdinal: r.prevailingWindOrdinal,
+	})
+	if err := r.replaceInitialBonusTiles(); err != nil {
+		return err
+	}
+	for seat, player := range r.players {
+		if err := rules.ValidatePlayerGameState(r.ruleSet, player, nil); err != nil {
+			return errors.Wrapf(err, "invalid initial hand of %s", rules.GetWindName(seat))
+		}
+	}
+	return r.startTurnLoop()
+}
+
+// replaceInitialBonusTiles replaces the bonus tiles in every hand, in seat order, until there are
+// no more bonus tiles in any hand.
+func (r *MultiPlayerRunner) replaceInitialBonusTiles() error {
+	for replacementRound := 1; ; replacementRound++ {
+		replaced := false
+		for seat, player := range r.players {
+			numBonusTiles := len(player.GetBonusTiles())
+			numTilesToReplace := player.BulkMoveBonusTilesFromHand()
+			if numTilesToReplace == 0 {
+				continue
+			}
+			for _, tile := range player.GetBonusTiles()[numBonusTiles:] {
+				r.observers.notify(&BonusTileMovedEvent{Seat: seat, Tile: tile})
+			}
+			replaced = true
+			glog.V(2).Infof("Replacing %d bonus tiles of %s (round %d)\n",
+				numTilesToReplace, rules.GetWindName(seat), replacementRound)
+			for i := 0; i < numTilesToReplace; i++ {
+				tile, err := r.drawFromDeckBack()
+				if err != nil {
+					return err
+				}
+				r.observers.notify(&TileDrawnEvent{Seat: seat, Tile: tile, FromBack: true})
+				player.AddTileToHandNoCheck(tile)
+			}
+		}
+		if !replaced {
+			break
+		}
+	}
+	for seat := range r.players {
+		r.sortHand(seat)
+	}
+	return nil
+}
+
+// startTurnLoop runs turns until the game is over.
+func (r *MultiPlayerRunner) startTurnLoop() error {
+	seat := 0
+	turn := 1
+	r.observers.notify(&TurnStartedEvent{Seat: seat, Turn: turn})
+	// East starts with the extra tile, so there is no draw in the first turn.
+	discardedTile, err := r.promptForTurnAction(seat, commandsAfterDrawingTile,
+		rules.NewOutTileSource(rules.OutTileSourceTypeInitialHand, nil, nil))
+	if err != nil {
+		return err
+	}
+	for {
+		c, err := r.resolveClaims(seat, discardedTile)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			turn++
+			discardedTile, err = r.executeClaim(c, seat, discardedTile, turn)
+			if err != nil {
+				return err
+			}
+			seat = c.seat
+			continue
+		}
+
+		r.players[seat].AddTileToDiscardArea(discardedTile)
+		seat = (seat + 1) % NumPlayers
+		turn++
+		r.observers.notify(&TurnStartedEvent{Seat: seat, Turn: turn})
+		outTileSource, err := r.drawTile(seat)
+		if err != nil {
+			return err
+		}
+		discardedTile, err = r.promptForTurnAction(seat, commandsAfterDrawingTile, outTileSource)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// drawTile draws a tile for the given seat, replacing bonus tiles, and returns the source of the
+// tile that was added to the hand.
+func (r *MultiPlayerRunner) drawTile(seat int) (*rules.OutTileSource, error) {
+	player := r.players[seat]
+	tile, err := r.drawFromDeckFront()
+	if err != nil {
+		return nil, err
+	}
+	r.observers.notify(&TileDrawnEvent{Seat: seat, Tile: tile})
+	if rules.IsEligibleForHand(tile.GetSuit()) {
+		player.AddTileToHand(tile)
+		return rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawn, tile, nil), nil
+	}
+	player.AddTileToBonusArea(tile)
+	r.observers.notify(&BonusTileMovedEvent{Seat: seat, Tile: tile})
+	return r.drawReplacementTile(seat, false)
+}
+
+// drawReplacementTile draws tiles from the back of the deck for the given seat until a non-bonus
+// tile is obtained, and returns its source. If afterKong is true, the first tile is a kong
+// replacement.
+func (r *MultiPlayerRunner) drawReplacementTile(seat int,
+	afterKong bool) (*rules.OutTileSource, error) {
+	player := r.players[seat]
+	for round := 1; ; round++ {
+		tile, err := r.drawFromDeckBack()
+		if err != nil {
+			return nil, err
+		}
+		if afterKong && round == 1 {
+			r.observers.notify(&KongReplacementDrawnEvent{Seat: seat, Tile: tile})
+		} else {
+			r.observers.notify(&TileDrawnEvent{Seat: seat, Tile: tile, FromBack: true})
+		}
+		if rules.IsEligibleForHand(tile.GetSuit()) {
+			player.AddTileToHand(tile)
+			return rules.NewOutTileSource(
+				rules.OutTileSourceTypeSelfDrawnReplacement, tile, nil), nil
+		}
+		player.AddTileToBonusArea(tile)
+		r.observers.notify(&BonusTileMovedEvent{Seat: seat, Tile: tile})
+	}
+}
+
+// promptForTurnAction prompts the player of the given seat, whose hand contains the extra tile,
+// until they discard a tile, which is returned without being moved to the discard area. If
+// outTileSource is nil, the player may not declare an Out.
+func (r *MultiPlayerRunner) promptForTurnAction(seat int, acceptedCommands ui.CommandTypes,
+	outTileSource *rules.OutTileSource) (*domain.Tile, error) {
+	player := r.players[seat]
+	r.notifyHandUpdated(seat)
+	for {
+		r.currentOutTileSource = outTileSource
+		cmd, err := r.promptForCommand(seat,
+			withRiichiCommands(acceptedCommands, r.ruleSet, player))
+		if err != nil {
+			return nil, err
+		}
+		if reason := getRiichiRejectionReason(cmd, r.ruleSet, player, outTileSource); reason != "" {
+			r.rejectCommand(seat, reason)
+			continue
+		}
+		switch cmd.GetCommandType() {
+		case ui.Riichi:
+			index := cmd.GetTileIndexCommand().GetIndex()
+			if player.CanDeclareRiichi(r.ruleSet, index) {
+				tile, _ := player.RemoveTileFromHandAt(index)
+				player.DeclareRiichi(r.ruleSet)
+				r.observers.notify(&RiichiDeclaredEvent{Seat: seat})
+				r.observers.notify(&TileDiscardedEvent{Seat: seat, Tile: tile})
+				return tile, nil
+			}
+			r.rejectCommand(seat,
+				fmt.Sprintf("Cannot declare riichi by discarding tile at %d", index))
+		case ui.DiscardTile:
+			index := cmd.GetTileIndexCommand().GetIndex()
+			tile, removed := player.RemoveTileFromHandAt(index)
+			if removed {
+				r.observers.notify(&TileDiscardedEvent{Seat: seat, Tile: tile})
+				return tile, nil
+			}
+			r.rejectCommand(seat, fmt.Sprintf("Failed to discard tile at %d", index))
+		case ui.ConcealedKong:
+			index := cmd.GetTileIndexCommand().GetIndex()
+			if tile, declared := player.DeclareConcealedKong(index); declared {
+				if err := r.notifyMeldDeclared(seat, ui.ConcealedKong, tile, NoSeat); err != nil {
+					return nil, err
+				}
+				if outTileSource, err = r.drawReplacementTile(seat, true); err != nil {
+					return nil, err
+				}
+				acceptedCommands = commandsAfterDrawingTile
+				r.notifyHandUpdated(seat)
+				continue
+			}
+			r.rejectCommand(seat,
+				fmt.Sprintf("Failed to declare concealed kong with tile at %d", index))
+		case ui.AdditionalKong:
+			index := cmd.GetTileIndexCommand().GetIndex()
+			if tile, declared := player.DeclareAdditionalKong(index); declared {
+				if err := r.notifyMeldDeclared(seat, ui.AdditionalKong, tile, NoSeat); err != nil {
+					return nil, err
+				}
+				if err := r.offerRobbingKong(seat, tile); err != nil {
+					return nil, err
+				}
+				if outTileSource, err = r.drawReplacementTile(seat, true); err != nil {
+					return nil, err
+				}
+				acceptedCommands = commandsAfterDrawingTile
+				r.notifyHandUpdated(seat)
+				continue
+			}
+			r.rejectCommand(seat,
+				fmt.Sprintf("Failed to declare additional kong with tile at %d", index))
+		case ui.Out:
+			if outTileSource != nil && r.isOut(player, outTileSource) {
+				return nil, r.declareOut(seat, outTileSource)
+			}
+			r.rejectCommand(seat, "Not an Out hand!")
+		default:
+			r.executeCommonCommand(seat, cmd)
+		}
+	}
+}
+
+// resolveClaims prompts every other player for a claim on the tile discarded by the given seat, and
+// returns the claim with the highest priority, or nil if no player claims the tile.
+func (r *MultiPlayerRunner) resolveClaims(discarderSeat int, tile *domain.Tile) (*claim, error) {
+	var bestClaim *claim
+	for offset := 1; offset < NumPlayers; offset++ {
+		seat := (discarderSeat + offset) % NumPlayers
+		acceptedCommands := r.getClaimCommands(seat, discarderSeat, tile)
+		if len(acceptedCommands) == 0 {
+			continue
+		}
+
+		r.observers.notify(&ClaimOfferedEvent{Seat: seat, Tile: tile, DiscarderSeat: discarderSeat})
+		r.notifyHandUpdated(seat)
+		r.claimableTile = tile
+		r.currentOutTileSource = nil
+		if acceptedCommands.ContainsCommand(ui.Out) {
+			r.currentOutTileSource = r.newDiscardOutTileSource(discarderSeat, tile)
+		}
+		cmd, err := r.promptForClaim(seat, tile,
+			withCommands(append(acceptedCommands, ui.Pass)...))
+		r.claimableTile = nil
+		if err != nil {
+			return nil, err
+		}
+		// Seats are visited in order from the discarder, so earlier claims win ties.
+		priority := claimPriority(cmd.GetCommandType())
+		if priority > 0 &&
+			(bestClaim == nil || priority > claimPriority(bestClaim.cmd.GetCommandType())) {
+			bestClaim = &claim{
+				seat:          seat,
+				cmd:           cmd,
+				outTileSource: r.newDiscardOutTileSource(discarderSeat, tile),
+			}
+		}
+	}
+	return bestClaim, nil
+}
+
+// offerRobbingKong prompts every other player that can form an Out with the tile added to a kong by
+// the given seat, in seat order from that seat. The first player to rob the kong ends the game.
+func (r *MultiPlayerRunner) offerRobbingKong(kongSeat int, tile *domain.Tile) error {
+	if !r.ruleSet.IsRobbingKongAllowed() {
+		return nil
+	}
+	outTileSource := rules.NewOutTileSource(rules.OutTileSourceTypeAdditionalKong, tile, nil)
+	for offset := 1; offset < NumPlayers; offset++ {
+		seat := (kongSeat + offset) % NumPlayers
+		if !r.isOut(r.players[seat], outTileSource) {
+			continue
+		}
+
+		r.observers.notify(&ClaimOfferedEvent{
+			Seat:          seat,
+			Tile:          tile,
+			DiscarderSeat: kongSeat,
+			RobbingKong:   true,
+		})
+		r.notifyHandUpdated(seat)
+		r.claimableTile = tile
+		r.currentOutTileSource = outTileSource
+		cmd, err := r.promptForClaim(seat, tile, withCommands(ui.RobKong, ui.Pass))
+		r.claimableTile = nil
+		r.currentOutTileSource = nil
+		if err != nil {
+			return err
+		}
+		if cmd.GetCommandType() == ui.RobKong {
+			return r.declareOut(seat, outTileSource)
+		}
+	}
+	return nil
+}
+
+// getClaimCommands returns the claims the player of the given seat may make on the discarded tile,
+// not including Pass. Only an Out may be claimed after riichi.
+func (r *MultiPlayerRunner) getClaimCommands(seat, discarderSeat int,
+	tile *domain.Tile) ui.CommandTypes {
+	player := r.players[seat]
+	var commands ui.CommandTypes
+	if r.isOut(player, r.newDiscardOutTileSource(discarderSeat, tile)) {
+		commands = append(commands, ui.Out)
+	}
+	if player.IsRiichiDeclared() {
+		// A player who declared riichi may only claim the tile for an Out.
+		return commands
+	}
+	if player.CanDeclarePong(tile) {
+		commands = append(commands, ui.Pong)
+	}
+	if player.CanDeclareKong(tile) {
+		commands = append(commands, ui.Kong)
+	}
+	if seat == (discarderSeat+1)%NumPlayers && len(player.FindChowIndices(tile)) > 0 {
+		commands = append(commands, ui.Chow)
+	}
+	return commands
+}
+
+// promptForClaim prompts the player of the given seat until a valid claim or Pass is received.
+func (r *MultiPlayerRunner) promptForClaim(seat int, tile *domain.Tile,
+	acceptedCommands ui.CommandTypes) (*ui.Command, error) {
+	for {
+		cmd, err := r.promptForCommand(seat, acceptedCommands)
+		if err != nil {
+			return nil, err
+		}
+		switch cmd.GetCommandType() {
+		case ui.Out, ui.RobKong, ui.Pong, ui.Kong, ui.Pass:
+			return cmd, nil
+		case ui.Chow:
+			indices := cmd.GetTileIndexCommand2()
+			if r.players[seat].CanDeclareChow(tile, indices.GetIndex1(), indices.GetIndex2()) {
+				return cmd, nil
+			}
+			r.rejectCommand(seat, "Invalid chow")
+		default:
+			r.executeCommonCommand(seat, cmd)
+		}
+	}
+}
+
+// executeClaim executes the given claim on the discarded tile, and returns the tile subsequently
+// discarded by the claiming player. A meld starts the given turn of the claiming player.
+func (r *MultiPlayerRunner) executeClaim(c *claim, discarderSeat int, tile *domain.Tile,
+	turn int) (*domain.Tile, error) {
+	player := r.players[c.seat]
+	switch c.cmd.GetCommandType() {
+	case ui.Out:
+		return nil, r.declareOut(c.seat, c.outTileSource)
+	case ui.Pong:
+		if !player.DeclarePong(tile) {
+			return nil, fmt.Errorf("Failed to declare validated pong")
+		}
+		if err := r.notifyMeldDeclared(c.seat, ui.Pong, tile, discarderSeat); err != nil {
+			return nil, err
+		}
+		r.observers.notify(&TurnStartedEvent{Seat: c.seat, Turn: turn})
+		return r.promptForTurnAction(c.seat, commandsAfterMelding, nil)
+	case ui.Kong:
+		if !player.DeclareKong(tile) {
+			return nil, fmt.Errorf("Failed to declare validated kong")
+		}
+		if err := r.notifyMeldDeclared(c.seat, ui.Kong, tile, discarderSeat); err != nil {
+			return nil, err
+		}
+		r.observers.notify(&TurnStartedEvent{Seat: c.seat, Turn: turn})
+		outTileSource, err := r.drawReplacementTile(c.seat, true)
+		if err != nil {
+			return nil, err
+		}
+		return r.promptForTurnAction(c.seat, commandsAfterDrawingTile, outTileSource)
+	case ui.Chow:
+		indices := c.cmd.GetTileIndexCommand2()
+		_, declared := player.DeclareChow(tile, indices.GetIndex1(), indices.GetIndex2())
+		if !declared {
+			return nil, fmt.Errorf("Failed to declare validated chow")
+		}
+		if err := r.notifyMeldDeclared(c.seat, ui.Chow, tile, discarderSeat); err != nil {
+			return nil, err
+		}
+		r.observers.notify(&TurnStartedEvent{Seat: c.seat, Turn: turn})
+		return r.promptForTurnAction(c.seat, commandsAfterMelding, nil)
+	}
+	return nil, fmt.Errorf("Unhandled claim %s", c.cmd.GetCommandType())
+}
+
+// newDiscardOutTileSource returns the source of the given tile discarded by the given seat. The tile
+// must not have been moved to the discard area yet.
+func (r *MultiPlayerRunner) newDiscardOutTileSource(discarderSeat int,
+	tile *domain.Tile) *rules.OutTileSource {
+	return rules.NewOutTileSource(rules.OutTileSourceTypeDiscard, tile,
+		rules.NewDiscardInfo(r.players[discarderSeat]))
+}
+
+func (r *MultiPlayerRunner) isOut(player *rules.PlayerGameState,
+	outTileSource *rules.OutTileSource) bool {
+	scoredPlans, _ := r.scoreQualifyingOutPlans(player, outTileSource)
+	return len(scoredPlans) > 0
+}
+
+// scoreQualifyingOutPlans returns the scored Out plans of the given player that qualify as an Out
+// under the rule set, and the context they are scored with.
+func (r *MultiPlayerRunner) scoreQualifyingOutPlans(player *rules.PlayerGameState,
+	outTileSource *rules.OutTileSource) (rules.ScoredOutPlans, *rules.OutPlanScoringContext) {
+	plans := rules.NewOutPlanCalculatorForRuleSet(r.ruleSet, player, outTileSource).Calculate()
+	context := rules.NewOutPlanScoringContext(outTileSource, player, r.deck.NumRemainingTiles(),
+		r.prevailingWindOrdinal)
+	scoredPlans := r.ruleSet.GetOutPlansScorer().ScoreOutPlans(plans, context)
+	return rules.GetQualifyingOutPlans(r.ruleSet, scoredPlans), context
+}
+
+// declareOut ends the game with an Out declared by the given seat, and returns the resulting
+// gameOverError. The Out must have been validated.
+func (r *MultiPlayerRunner) declareOut(seat int, outTileSource *rules.OutTileSource) error {
+	scoredPlans, context := r.scoreQualifyingOutPlans(r.players[seat], outTileSource)
+	event := &OutDeclaredEvent{Seat: seat, OutTileSource: outTileSource}
+	if *flags.ReportScoringFlag {
+		event.ScoredPlans = scoredPlans
+		event.ScoringContext = context
+	}
+	r.observers.notify(event)
+	return newGameOverError(newOutGameResult(seat, outTileSource, scoredPlans))
+}
+
+// promptForCommand prompts the player of the given seat for a command. The input being aborted
+// ends the game.
+func (r *MultiPlayerRunner) promptForCommand(seat int,
+	acceptedCommands ui.CommandTypes) (*ui.Command, error) {
+	cmd, err := r.receivers[seat].PromptForCommand(acceptedCommands)
+	if err != nil {
+		return nil, newGameOverError(newInputAbortedGameResult(seat, err))
+	}
+	r.observers.notify(&CommandReceivedEvent{Seat: seat, Command: cmd})
+	return cmd, nil
+}
+
+// executeCommonCommand executes a command that does not change the state of the game.
+func (r *MultiPlayerRunner) executeCommonCommand(seat int, cmd *ui.Command) {
+	switch cmd.GetCommandType() {
+	case ui.SortHand:
+		r.sortHand(seat)
+	case ui.ShowDiscardedTiles:
+		var lines []string
+		for otherSeat, player := range r.players {
+			lines = append(lines, fmt.Sprintf("%s discarded tiles: %s",
+				rules.GetWindName(otherSeat), player.GetDiscardedTiles()))
+		}
+		r.showInfo(seat, strings.Join(lines, "\n"))
+	case ui.ShowMelded:
+		var lines []string
+		for otherSeat, player := range r.players {
+			lines = append(lines, fmt.Sprintf("%s melded groups: %s",
+				rules.GetWindName(otherSeat), player.GetMeldGroups()))
+		}
+		r.showInfo(seat, strings.Join(lines, "\n"))
+	case ui.Hint:
+		advisor := rules.NewDiscardAdvisor(r.ruleSet, r.ruleSet.GetOutPlansScorer(),
+			r.players[seat], r.getOpponents(seat), r.deck.NumRemainingTiles(),
+			r.prevailingWindOrdinal)
+		r.showInfo(seat, fmt.Sprintf("Discard advice:\n%s", advisor.Advise()))
+	default:
+		r.rejectCommand(seat, fmt.Sprintf("Unhandled command: %s", cmd.GetCommandType()))
+	}
+}
+
+// notifyMeldDeclared notifies the observers that the given seat declared the meld group containing
+// the given tile.
+func (r *MultiPlayerRunner) notifyMeldDeclared(seat int, meldType ui.CommandType,
+	tile *domain.Tile, discarderSeat int) error {
+	event, err := newMeldDeclaredEvent(seat, r.players[seat], meldType, tile, discarderSeat)
+	if err != nil {
+		return err
+	}
+	r.observers.notify(event)
+	return nil
+}
+
+// showInfo notifies the observers of information for the given seat.
+func (r *MultiPlayerRunner) showInfo(seat int, info string) {
+	r.observers.notify(&InfoShownEvent{Seat: seat, Info: info})
+}
+
+// rejectCommand notifies the observers that the last command of the given seat is rejected for
+// the given reason.
+func (r *MultiPlayerRunner) rejectCommand(seat int, reason string) {
+	r.observers.notify(&CommandRejectedEvent{Seat: seat, Reason: reason})
+}
+
+// getOpponents returns the states of the other seats, starting from the next seat.
+func (r *MultiPlayerRunner) getOpponents(seat int) []*rules.PlayerGameState {
+	var opponents []*rules.PlayerGameState
+	for offset := 1; offset < NumPlayers; offset++ {
+		opponents = append(opponents, r.players[(seat+offset)%NumPlayers])
+	}
+	return opponents
+}
+
+func (r *MultiPlayerRunner) sortHand(seat int) {
+	r.players[seat].SortHand()
+	r.notifyHandUpdated(seat)
+}
+
+func (r *MultiPlayerRunner) notifyHandUpdated(seat int) {
+	r.observers.notify(newHandUpdatedEvent(seat, r.players[seat]))
+}
+
+// drawFromDeckFront draws a tile from the front of the deck. If the deck is empty, the game is
+// drawn and a gameOverError is returned.
+func (r *MultiPlayerRunner) drawFromDeckFront() (*domain.Tile, error) {
+	tile, err := r.deck.PopFront()
+	if err != nil {
+		return nil, r.endDrawnGame()
+	}
+	return tile, nil
+}
+
+// drawFromDeckBack draws a tile from the back of the deck. If the deck is empty, the game is
+// drawn and a gameOverError is returned.
+func (r *MultiPlayerRunner) drawFromDeckBack() (*domain.Tile, error) {
+	tile, err := r.deck.PopBack()
+	if err != nil {
+		return nil, r.endDrawnGame()
+	}
+	return tile, nil
+}
+
+func (r *MultiPlayerRunner) endDrawnGame() error {
+	r.observers.notify(&GameDrawnEvent{})
+	return newGameOverError(newWallExhaustedGameResult())
+}
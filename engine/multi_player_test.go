@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// robbingKongHands are the initial hands of a game in which South pongs 5m discarded by East, and
+// later declares an additional kong with a drawn 5m, which West is waiting on.
+var robbingKongHands = []string{
+	"1357b58m1467d23y34w",
+	"55m111222333d12w",
+	"123456789b4m99d1y",
+	"24689b279m258d1y4w",
+}
+
+// robbingKongFront are the tiles drawn by West, North, East and South, in order.
+const robbingKongFront = "6m3w8m5m"
+
+// newMultiPlayerDeckForTest returns a deck that deals the given hands, starting from East, then
+// the given front tiles in order, and the given back tiles when drawing from the back, in order.
+func newMultiPlayerDeckForTest(t *testing.T, hands []string, front, back string) domain.Deck {
+	require.Len(t, hands, NumPlayers)
+	parser := shorthand.NewParser()
+	var handTiles []domain.Tiles
+	for _, hand := range hands {
+		tiles, err := parser.ParseTiles(hand)
+		require.NoError(t, err)
+		handTiles = append(handTiles, tiles)
+	}
+	require.Len(t, handTiles[0], 14)
+
+	// Deal in the same order as rules.PopulateHands: 3 rounds of 4 tiles, 1 tile each, then the
+	// extra tile of East.
+	var tiles domain.Tiles
+	for round := 0; round < 3; round++ {
+		for _, hand := range handTiles {
+			tiles = append(tiles, hand[round*4:round*4+4]...)
+		}
+	}
+	for _, hand := range handTiles {
+		tiles = append(tiles, hand[12])
+	}
+	tiles = append(tiles, handTiles[0][13])
+
+	frontTiles, err := parser.ParseTiles(front)
+	require.NoError(t, err)
+	tiles = append(tiles, frontTiles...)
+	backTiles, err := parser.ParseTiles(back)
+	require.NoError(t, err)
+	for i := len(backTiles) - 1; i >= 0; i-- {
+		tiles = append(tiles, backTiles[i])
+	}
+	return domain.NewDeck(tiles)
+}
+
+// runScriptedMultiPlayerGame runs a multi player game with the given deck and one script per seat,
+// and returns the result, the recorded events and the receivers. The given observers are also
+// notified of the events.
+func runScriptedMultiPlayerGame(t *testing.T, ruleSet rules.RuleSet, deck domain.Deck,
+	scripts []string,
+	observers ...GameObserver) (*GameResult, *eventRecorder, []*ui.ScriptedCommandReceiver) {
+	var scriptedReceivers []*ui.ScriptedCommandReceiver
+	var receivers []ui.CommandReceiver
+	for _, script := range scripts {
+		receiver, err := ui.NewScriptedCommandReceiverFromReader(strings.NewReader(script))
+		require.NoError(t, err)
+		scriptedReceivers = append(scriptedReceivers, receiver)
+		receivers = append(receivers, receiver)
+	}
+	recorder := &eventRecorder{}
+	runner := NewMultiPlayerRunner(ruleSet, receivers)
+	runner.AddObserver(recorder)
+	for _, observer := range observers {
+		runner.AddObserver(observer)
+	}
+
+	result, err := runner.Start(deck)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	return result, recorder, scriptedReceivers
+}
+
+func Test_MultiPlayer_RobbingKong(t *testing.T) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	require.True(t, ruleSet.IsRobbingKongAllowed())
+
+	deck := newMultiPlayerDeckForTest(t, robbingKongHands, robbingKongFront, "")
+	result, recorder, receivers := runScriptedMultiPlayerGame(t, ruleSet, deck, []string{
+		"discard 4\ndiscard 13\n",
+		"pong\ndiscard 9\nakong 10\n",
+		"discard 12\nrob\n",
+		"discard 13\n",
+	})
+	for _, receiver := range receivers {
+		assert.NoError(t, receiver.Verify())
+	}
+
+	require.True(t, result.IsOut(), "Game did not end with an Out: %s", result)
+	assert.Equal(t, 2, result.Seat)
+	assert.Equal(t, rules.OutTileSourceTypeAdditionalKong, result.OutTileSource.SourceType)
+	assert.Equal(t, 0,
+		domain.CompareTiles(parseTileForTest(t, "5m"), result.OutTileSource.Tile))
+	assert.NotNil(t, result.WinningPlan)
+
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		offered, ok := event.(*ClaimOfferedEvent)
+		return ok && offered.RobbingKong && offered.Seat == 2 && offered.DiscarderSeat == 1
+	}))
+	// The kong is robbed before the replacement tile is drawn.
+	assert.Zero(t, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*KongReplacementDrawnEvent)
+		return ok
+	}))
+	_, ok := recorder.getLastEvent().(*OutDeclaredEvent)
+	assert.True(t, ok, "Last event is not an Out: %s", recorder.getLastEvent())
+}
+
+func Test_MultiPlayer_ClaimStartsTurn(t *testing.T) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	deck := newMultiPlayerDeckForTest(t, robbingKongHands, robbingKongFront, "")
+	_, recorder, _ := runScriptedMultiPlayerGame(t, ruleSet, deck, []string{
+		"discard 4\n",
+		"pong\ndiscard 9\n",
+		"",
+		"",
+	})
+
+	// South starts turn 2 by claiming the 5m discarded by East, and West draws in turn 3.
+	var turns []TurnStartedEvent
+	for i, event := range recorder.events {
+		if turnStarted, ok := event.(*TurnStartedEvent); ok {
+			turns = append(turns, *turnStarted)
+		}
+		if meld, ok := event.(*MeldDeclaredEvent); ok {
+			require.Less(t, i+1, len(recorder.events))
+			assert.Equal(t, &TurnStartedEvent{Seat: meld.Seat, Turn: 2}, recorder.events[i+1])
+		}
+	}
+	assert.Equal(t, []TurnStartedEvent{{Seat: 0, Turn: 1}, {Seat: 1, Turn: 2}, {Seat: 2, Turn: 3}},
+		turns)
+}
+
+func Test_MultiPlayer_RobbingKongNotAllowed(t *testing.T) {
+	hkRuleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	ruleSet := *hkRuleSet.(*rules.BaseRuleSet)
+	ruleSet.AllowRobbingKong = false
+
+	deck := newMultiPlayerDeckForTest(t, robbingKongHands, robbingKongFront, "1b")
+	result, recorder, receivers := runScriptedMultiPlayerGame(t, &ruleSet, deck, []string{
+		"discard 4\ndiscard 13\n",
+		"pong\ndiscard 9\nakong 10\n",
+		"discard 12\n",
+		"discard 13\n",
+	})
+	// West is not offered to rob the kong, and the script of South ends after the replacement
+	// tile is drawn.
+	for seat, receiver := range receivers {
+		if seat != 1 {
+			assert.NoError(t, receiver.Verify())
+		}
+	}
+	assert.Zero(t, receivers[1].NumRemainingCommands())
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+	assert.Equal(t, 1, result.Seat)
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		drawn, ok := event.(*KongReplacementDrawnEvent)
+		return ok && drawn.Seat == 1
+	}))
+}
+
+func Test_MultiPlayer_Riichi(t *testing.T) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameRiichi)
+	require.NoError(t, err)
+	deck := newMultiPlayerDeckForTest(t, []string{
+		"123456789b5m23d99d",
+		"147b147m147d1234w",
+		"258b258m258d1234w",
+		"369b369m369d1234w",
+	}, "1y", "")
+	result, recorder, receivers := runScriptedMultiPlayerGame(t, ruleSet, deck, []string{
+		// Discarding 1b does not leave a ready hand.
+		"riichi 0\nriichi 9\n",
+		"",
+		"",
+		"",
+	})
+	assert.NoError(t, receivers[0].Verify())
+	// The script of South ends after drawing a tile.
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+	assert.Equal(t, 1, result.Seat)
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		rejected, ok := event.(*CommandRejectedEvent)
+		return ok && rejected.Seat == 0
+	}))
+
+	for i, event := range recorder.events {
+		if _, ok := event.(*RiichiDeclaredEvent); ok {
+			assert.Equal(t, 0, event.GetSeat())
+			discarded, ok := recorder.events[i+1].(*TileDiscardedEvent)
+			require.True(t, ok, "Riichi is not followed by a discard: %s", recorder.events[i+1])
+			assert.Equal(t, 0, domain.CompareTiles(parseTileForTest(t, "5m"), discarded.Tile))
+			return
+		}
+	}
+	assert.Fail(t, "Riichi was not declared")
+}
+
+func Test_MultiPlayer_RiichiLimits(t *testing.T) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameRiichi)
+	require.NoError(t, err)
+	deck := newMultiPlayerDeckForTest(t, []string{
+		"123456789b5m23d99d",
+		"147b147m147d1234w",
+		"258b258m258d1234w",
+		"369b369m369d1234w",
+	}, "9d2y3y7m1y", "")
+	result, recorder, receivers := runScriptedMultiPlayerGame(t, ruleSet, deck, []string{
+		// Discards 5m, waiting on 1d or 4d. Draws 7m later, then tries to discard 1b first.
+		"riichi 9\ndiscard 0\ndiscard 13\n",
+		"discard 13\n",
+		"discard 13\n",
+		"discard 13\n",
+	})
+	for _, seat := range []int{0, 2, 3} {
+		assert.NoError(t, receivers[seat].Verify())
+	}
+	// The script of South ends after drawing a tile in the next turn.
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+	assert.Equal(t, 1, result.Seat)
+	// East may not pong the 9d discarded by South.
+	assert.Equal(t, 0, countEvents(recorder, func(event GameEvent) bool {
+		offered, ok := event.(*ClaimOfferedEvent)
+		return ok && offered.Seat == 0
+	}))
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		rejected, ok := event.(*CommandRejectedEvent)
+		return ok && rejected.Seat == 0
+	}))
+
+	var lastDiscarded *domain.Tile
+	for _, event := range recorder.events {
+		if discarded, ok := event.(*TileDiscardedEvent); ok && discarded.Seat == 0 {
+			lastDiscarded = discarded.Tile
+		}
+	}
+	require.NotNil(t, lastDiscarded)
+	assert.Equal(t, 0, domain.CompareTiles(parseTileForTest(t, "7m"), lastDiscarded))
+}
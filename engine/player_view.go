@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/ui"
+)
+
+// PlayerView provides the information of a game that is visible to the player of a single seat.
+type PlayerView interface {
+	// GetRuleSet returns the RuleSet of the game.
+	GetRuleSet() rules.RuleSet
+	// GetPlayer returns a copy of the state of the seat.
+	GetPlayer() *rules.PlayerGameState
+	// GetOpponents returns the public states of the other seats, starting from the next seat. The
+	// hands of the opponents are empty.
+	GetOpponents() []*rules.PlayerGameState
+	// GetClaimableTile returns the tile discarded by another seat, or added by another seat to a
+	// kong, that may currently be claimed, or nil if the seat is not being asked for a claim.
+	GetClaimableTile() *domain.Tile
+	// GetOutTileSource returns the source of the Out tile if an Out were declared now, or nil if
+	// an Out may not be declared.
+	GetOutTileSource() *rules.OutTileSource
+	// GetNumRemainingTiles returns the number of tiles remaining in the deck.
+	GetNumRemainingTiles() int
+	// GetPrevailingWindOrdinal returns the wind of the round.
+	GetPrevailingWindOrdinal() int
+}
+
+// PlayerViewReceiver is a CommandReceiver that is given the view of the seat it plays, e.g. a
+// computer player.
+type PlayerViewReceiver interface {
+	ui.CommandReceiver
+	// SetPlayerView is called by the runner before the game starts.
+	SetPlayerView(view PlayerView)
+}
+
+// seatView is the PlayerView of a seat in a MultiPlayerRunner.
+type seatView struct {
+	runner *MultiPlayerRunner
+	seat   int
+}
+
+// GetRuleSet ... (PlayerView implementation)
+func (v *seatView) GetRuleSet() rules.RuleSet {
+	return v.runner.ruleSet
+}
+
+// GetPlayer ... (PlayerView implementation)
+func (v *seatView) GetPlayer() *rules.PlayerGameState {
+	return v.runner.players[v.seat].Copy()
+}
+
+// GetOpponents ... (PlayerView implementation)
+func (v *seatView) GetOpponents() []*rules.PlayerGameState {
+	var opponents []*rules.PlayerGameState
+	for _, opponent := range v.runner.getOpponents(v.seat) {
+		opponents = append(opponents, opponent.PublicCopy())
+	}
+	return opponents
+}
+
+// GetClaimableTile ... (PlayerView implementation)
+func (v *seatView) GetClaimableTile() *domain.Tile {
+	return v.runner.claimableTile
+}
+
+// GetOutTileSource ... (PlayerView implementation)
+func (v *seatView) GetOutTileSource() *rules.OutTileSource {
+	source := v.runner.currentOutTileSource
+	if source == nil || source.DiscardInfo == nil {
+		return source
+	}
+	// Hide the hand of the discarder.
+	return rules.NewOutTileSource(source.SourceType, source.Tile,
+		rules.NewDiscardInfo(source.DiscardInfo.DiscardPlayer.PublicCopy()))
+}
+
+// GetNumRemainingTiles ... (PlayerView implementation)
+func (v *seatView) GetNumRemainingTiles() int {
+	return v.runner.deck.NumRemainingTiles()
+}
+
+// GetPrevailingWindOrdinal ... (PlayerView implementation)
+func (v *seatView) GetPrevailingWindOrdinal() int {
+	return v.runner.prevailingWindOrdinal
+}
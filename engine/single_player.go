@@ -5,39 +5,81 @@ import (
 	"github.com/derekimcheng/mj/domain"
 	"github.com/derekimcheng/mj/flags"
 	"github.com/derekimcheng/mj/rules"
-	"github.com/derekimcheng/mj/rules/zj"
 	"github.com/derekimcheng/mj/ui"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 )
 
-// TODO: put this in rules/?
-type gameOverError struct {
-	outDeclared bool
-}
-
-func newGameOverError(outDeclared bool) *gameOverError {
-	return &gameOverError{outDeclared: outDeclared}
-}
-
-// Error ... (error implementation)
-func (e *gameOverError) Error() string {
-	return fmt.Sprintf("Game over. Declared out? %t", e.outDeclared)
-}
-
 const (
 	separator = "==============================================================="
 )
 
 var (
 	commonCommands           = ui.CommandTypes{ui.SortHand, ui.ShowDiscardedTiles, ui.ShowMelded}
-	commandsAfterDrawingTile = withCommands(ui.DiscardTile, ui.ConcealedKong, ui.AdditionalKong, ui.Out)
+	commandsAfterDrawingTile = withCommands(ui.Hint, ui.DiscardTile, ui.ConcealedKong, ui.AdditionalKong, ui.Out)
+	commandsAfterMelding     = withCommands(ui.Hint, ui.DiscardTile)
 )
 
 func withCommands(types ...ui.CommandType) ui.CommandTypes {
 	return append(commonCommands, types...)
 }
 
+// withRiichiCommands returns the given commands adjusted for riichi under the given RuleSet. Riichi
+// is added if the commands allow a discard and the player may still declare riichi. Once the
+// player has declared riichi, the melds other than a concealed kong are removed.
+func withRiichiCommands(acceptedCommands ui.CommandTypes, ruleSet rules.RuleSet,
+	player *rules.PlayerGameState) ui.CommandTypes {
+	if player.IsRiichiDeclared() {
+		var commands ui.CommandTypes
+		for _, command := range acceptedCommands {
+			switch command {
+			case ui.Pong, ui.Kong, ui.Chow, ui.AdditionalKong:
+				continue
+			}
+			commands = append(commands, command)
+		}
+		return commands
+	}
+	if !acceptedCommands.ContainsCommand(ui.DiscardTile) || !ruleSet.IsRiichiAllowed() ||
+		!player.IsConcealed() {
+		return acceptedCommands
+	}
+	return append(append(ui.CommandTypes{}, acceptedCommands...), ui.Riichi)
+}
+
+// getRiichiRejectionReason returns the reason why the given command of the player is not allowed
+// after riichi, or an empty string if it is allowed. The source is that of the tile obtained in
+// the current turn. After riichi, the player may only discard the drawn tile, or declare a
+// concealed kong that keeps the waits of the hand unchanged.
+func getRiichiRejectionReason(cmd *ui.Command, ruleSet rules.RuleSet,
+	player *rules.PlayerGameState, outTileSource *rules.OutTileSource) string {
+	if !player.IsRiichiDeclared() {
+		return ""
+	}
+	var drawnTile *domain.Tile
+	if outTileSource != nil {
+		drawnTile = outTileSource.Tile
+	}
+	switch cmd.GetCommandType() {
+	case ui.DiscardTile:
+		index := cmd.GetTileIndexCommand().GetIndex()
+		tile, err := player.GetHand().GetTileAt(index)
+		if err == nil && drawnTile != nil && domain.CompareTiles(tile, drawnTile) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("Only the drawn tile can be discarded after riichi, not the tile at %d",
+			index)
+	case ui.ConcealedKong:
+		index := cmd.GetTileIndexCommand().GetIndex()
+		if drawnTile != nil &&
+			player.CanDeclareConcealedKongAfterRiichi(ruleSet, index, drawnTile) {
+			return ""
+		}
+		return fmt.Sprintf("Concealed kong with tile at %d is not allowed after riichi", index)
+	}
+	return ""
+}
+
 // SinglePlayerRunner is the runner for the single player mode game.
 // The single player mode proceeds as follows:
 // (1) The player is dealt tiles from the front of a shuffled deck.
@@ -72,8 +114,12 @@ func withCommands(types ...ui.CommandType) ui.CommandTypes {
 // - The player declares an Out.
 // - The deck becomes empty AND a tile is required to be drawn.
 type SinglePlayerRunner struct {
+	ruleSet          rules.RuleSet
 	receiver         ui.CommandReceiver
 	numBurnsPerRound int
+	observers        gameObservers
+	// prevailingWindOrdinal is the wind of the round, which is used for scoring.
+	prevailingWindOrdinal int
 
 	started bool
 	deck    domain.Deck
@@ -88,22 +134,35 @@ type SinglePlayerRunner struct {
 }
 
 // NewSinglePlayerRunner returns a new instance of NewSinglePlayerRunner with the given input
-// parameters.
-func NewSinglePlayerRunner(receiver ui.CommandReceiver) *SinglePlayerRunner {
+// parameters. The prevailing wind is given by -mj.prevailingWind.
+func NewSinglePlayerRunner(ruleSet rules.RuleSet, receiver ui.CommandReceiver) *SinglePlayerRunner {
 	if *flags.NumBurnsFlag < 0 || *flags.NumBurnsFlag > 3 {
 		panic(fmt.Errorf("Invalid value for numBurnsFlag: %d", *flags.NumBurnsFlag))
 	}
-	return &SinglePlayerRunner{receiver: receiver, numBurnsPerRound: *flags.NumBurnsFlag}
+	return &SinglePlayerRunner{
+		ruleSet:               ruleSet,
+		receiver:              receiver,
+		numBurnsPerRound:      *flags.NumBurnsFlag,
+		prevailingWindOrdinal: getPrevailingWindOrdinal(),
+	}
+}
+
+// AddObserver adds an observer that is notified of every event of the game. Observers must be
+// added before the game is started.
+func (r *SinglePlayerRunner) AddObserver(observer GameObserver) {
+	r.observers = append(r.observers, observer)
 }
 
-// Start starts the game sequence. Returns an error if the game is already started (or ended), or
-// if the game is unable to start. This function returns when the game ends.
-func (r *SinglePlayerRunner) Start(deck domain.Deck) error {
+// Start starts the game sequence. This function returns the result of the game when the game
+// ends. Returns an error if the game is already started (or ended), if the game is unable to
+// start, e.g. the deck deals a hand that is invalid under the rule set (see
+// rules.ValidatePlayerGameState), or if the game is unable to proceed.
+func (r *SinglePlayerRunner) Start(deck domain.Deck) (*GameResult, error) {
 	if r.started {
-		return errors.New("Already started")
+		return nil, errors.New("Already started")
 	}
 	if deck.IsEmpty() {
-		return errors.New("Deck is empty")
+		return nil, errors.New("Deck is empty")
 	}
 
 	glog.V(2).Infof("Starting single player game")
@@ -112,24 +171,28 @@ func (r *SinglePlayerRunner) Start(deck domain.Deck) error {
 	r.deck = deck
 	err := r.initializePlayer()
 	if err != nil {
-		return errors.Wrapf(err, "unable to start game")
+		return nil, errors.Wrapf(err, "unable to start game")
 	}
 
-	// From here on, the game logic could throw exception to signal that the game is over.
-	r.startGameSequence()
-	return nil
+	// From here on, the game sequence returns a gameOverError to signal that the game is over.
+	result, err := getGameResult(r.startGameSequence())
+	if err != nil {
+		return nil, err
+	}
+	glog.V(2).Infof("Game over: %s\n", result)
+	return result, nil
 }
 
 func (r *SinglePlayerRunner) initializePlayer() error {
 	glog.V(2).Infof("Initializing hand\n")
 	hand := domain.NewHand()
-	err := rules.PopulateHands(r.deck, []*domain.Hand{hand})
+	err := rules.PopulateHands(r.deck, []*domain.Hand{hand}, r.ruleSet.GetNumTilesPerHand())
 	if err != nil {
 		return err
 	}
 
 	hand.Sort()
-	// In single player mode, the player's prevailing wind is always East (0).
+	// In single player mode, the player's seat wind is always East (0).
 	windOrdinal := 0
 	const numWindOrdinals = 4
 
@@ -140,21 +203,13 @@ func (r *SinglePlayerRunner) initializePlayer() error {
 	return nil
 }
 
-func (r *SinglePlayerRunner) startGameSequence() {
-	defer func() {
-		if r := recover(); r != nil {
-			if gameOverErr, ok := r.(*gameOverError); ok {
-				// TODO: implement proper panic recovery.
-				glog.V(2).Infof("Game over: %s\n", gameOverErr)
-				fmt.Printf("Game over: %s\n", gameOverErr)
-			} else {
-				panic(r)
-			}
-		}
-	}()
-
+func (r *SinglePlayerRunner) startGameSequence() error {
 	glog.V(2).Infof("Starting game sequence\n")
-	// TODO: notify observer of game start
+	r.observers.notify(&GameStartedEvent{
+		NumPlayers:            2,
+		NumRemainingTiles:     r.deck.NumRemainingTiles(),
+		PrevailingWindOrdinal: r.prevailingWindOrdinal,
+	})
 
 	numTilesToReplace := r.bulkMoveBonusTilesFromHand()
 	if numTilesToReplace > 0 {
@@ -163,7 +218,11 @@ func (r *SinglePlayerRunner) startGameSequence() {
 			glog.V(2).Infof("Replacing %d bonus tiles (round %d)\n",
 				numTilesToReplace, replacementRound)
 			for i := 0; i < numTilesToReplace; i++ {
-				tile := r.drawFromDeckBack()
+				tile, err := r.drawFromDeckBack()
+				if err != nil {
+					return err
+				}
+				r.observers.notify(&TileDrawnEvent{Seat: r.getPlayerSeat(), Tile: tile, FromBack: true})
 				r.addTileToHandNoCheck(tile)
 			}
 			numTilesToReplace = r.bulkMoveBonusTilesFromHand()
@@ -171,19 +230,20 @@ func (r *SinglePlayerRunner) startGameSequence() {
 		}
 	}
 
+	if err := rules.ValidatePlayerGameState(r.ruleSet, r.player, nil); err != nil {
+		return errors.Wrapf(err, "invalid initial hand")
+	}
 	r.sortHand()
-	fmt.Printf("Hand after replacement: %s\n", r.player.GetHand())
 
-	r.startPlayerRoundLoop()
+	return r.startPlayerRoundLoop()
 }
 
-func (r *SinglePlayerRunner) startPlayerRoundLoop() {
+// startPlayerRoundLoop runs rounds until the game is over.
+func (r *SinglePlayerRunner) startPlayerRoundLoop() error {
 	round := 1
 	playerMelded := false
 	for {
-		// TODO: Notify observer of round start
-		fmt.Println(separator)
-		fmt.Printf("Start of round %d\n", round)
+		r.observers.notify(&TurnStartedEvent{Seat: r.getPlayerSeat(), Turn: round})
 
 		if round == 1 || !playerMelded {
 			var source rules.OutTileSourceType
@@ -192,36 +252,48 @@ func (r *SinglePlayerRunner) startPlayerRoundLoop() {
 				source = rules.OutTileSourceTypeInitialHand
 			} else {
 				// Draw phase
-				tile = r.drawFromDeckFront()
-				source = rules.OutTileSourceTypeSelfDrawn
-				if round > 1 {
-					fmt.Printf("Drawn tile %s\n", tile)
+				var err error
+				tile, err = r.drawFromDeckFront()
+				if err != nil {
+					return err
 				}
+				source = rules.OutTileSourceTypeSelfDrawn
+				r.observers.notify(&TileDrawnEvent{Seat: r.getPlayerSeat(), Tile: tile})
 				if rules.IsEligibleForHand(tile.GetSuit()) {
 					r.addTileToHand(tile)
 				} else {
 					r.addTileToBonusArea(tile)
-					tile = r.replaceTileLoop()
+					tile, err = r.replaceTileLoop(false)
+					if err != nil {
+						return err
+					}
 					source = rules.OutTileSourceTypeSelfDrawnReplacement
 				}
 			}
 
-			fmt.Printf("Hand: %s\n", r.player.GetHand())
+			r.notifyHandUpdated()
 			// Player action phase
-			r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
+			_, err := r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
 				rules.NewOutTileSource(source, tile, nil))
+			if err != nil {
+				return err
+			}
 		}
 
 		playerMelded = false
 
 		// Burn phase
 		for x := 0; x < r.numBurnsPerRound; x++ {
-			fmt.Printf("Burn %d of %d in round %d\n", x+1, r.numBurnsPerRound, round)
+			glog.V(2).Infof("Burn %d of %d in round %d\n", x+1, r.numBurnsPerRound, round)
+			r.observers.notify(&TurnStartedEvent{Seat: r.getPseudoOpponentSeat(), Turn: round})
 			// Allow chow in the last burn
 			chowAllowed := x == r.numBurnsPerRound-1
-			melded := r.burnSingleTile(chowAllowed)
+			melded, err := r.burnSingleTile(chowAllowed)
+			if err != nil {
+				return err
+			}
 			if melded {
-				fmt.Printf("Exiting burn phase due to meld\n")
+				glog.V(2).Infof("Exiting burn phase due to meld\n")
 				playerMelded = true
 				break
 			}
@@ -230,89 +302,126 @@ func (r *SinglePlayerRunner) startPlayerRoundLoop() {
 	}
 }
 
-func (r *SinglePlayerRunner) burnSingleTile(chowAllowed bool) bool {
+// burnSingleTile draws a tile for the pseudo opponent and offers it to the player. Returns true
+// if the player melded the tile.
+func (r *SinglePlayerRunner) burnSingleTile(chowAllowed bool) (bool, error) {
 	if r.currentBurnTile != nil {
-		panic("There shouldn't be an active burn tile")
+		return false, fmt.Errorf("There shouldn't be an active burn tile")
 	}
 
-	tile := r.drawFromDeckFront()
-	round := 1
+	seat := r.getPseudoOpponentSeat()
+	tile, err := r.drawFromDeckFront()
+	if err != nil {
+		return false, err
+	}
+	r.observers.notify(&TileDrawnEvent{Seat: seat, Tile: tile})
 	for !rules.IsEligibleForHand(tile.GetSuit()) {
 		r.addTileToOtherBonusArea(tile)
-
-		fmt.Printf("Drawing a replacement tile from the back of deck (round %d)\n", round)
-		tile = r.drawFromDeckBack()
-		round++
+		tile, err = r.drawFromDeckBack()
+		if err != nil {
+			return false, err
+		}
+		r.observers.notify(&TileDrawnEvent{Seat: seat, Tile: tile, FromBack: true})
 	}
 
-	// TODO: add observer for "other" player about to discard tile
-	fmt.Printf("Burning tile %s\n", tile)
+	r.observers.notify(&TileDiscardedEvent{Seat: seat, Tile: tile})
 	r.currentBurnTile = tile
 
 	discardInfo := rules.NewDiscardInfo(r.pseudoOpponentGameState)
 	var cmdType ui.CommandType
 	if chowAllowed {
-		cmdType = r.promptAndExecutePlayerAction(
+		cmdType, err = r.promptAndExecutePlayerAction(
 			withCommands(ui.Pong, ui.Kong, ui.Chow, ui.Pass, ui.Out),
 			rules.NewOutTileSource(rules.OutTileSourceTypeDiscard, tile, discardInfo))
 	} else {
-		cmdType = r.promptAndExecutePlayerAction(
+		cmdType, err = r.promptAndExecutePlayerAction(
 			withCommands(ui.Pong, ui.Kong, ui.Pass, ui.Out),
 			rules.NewOutTileSource(rules.OutTileSourceTypeDiscard, tile, discardInfo))
 	}
 
+	if err != nil {
+		return false, err
+	}
+
 	melded := cmdType != ui.Pass
 	if !melded {
-		r.discardCurrentBurnTile()
+		if err := r.discardCurrentBurnTile(); err != nil {
+			return false, err
+		}
 	}
 
-	return melded
+	return melded, nil
 }
 
-func (r *SinglePlayerRunner) replaceTileLoop() *domain.Tile {
+// replaceTileLoop draws tiles from the back of the deck until a non-bonus tile is added to the hand,
+// and returns that tile. If afterKong is true, the first tile is a kong replacement.
+func (r *SinglePlayerRunner) replaceTileLoop(afterKong bool) (*domain.Tile, error) {
 	for round := 1; ; /* no-op */ round++ {
-		fmt.Printf("Drawing a replacement tile from the back of deck (round %d)\n", round)
-		tile := r.drawFromDeckBack()
+		glog.V(2).Infof("Drawing a replacement tile from the back of deck (round %d)\n", round)
+		tile, err := r.drawFromDeckBack()
+		if err != nil {
+			return nil, err
+		}
+		if afterKong && round == 1 {
+			r.observers.notify(&KongReplacementDrawnEvent{Seat: r.getPlayerSeat(), Tile: tile})
+		} else {
+			r.observers.notify(&TileDrawnEvent{Seat: r.getPlayerSeat(), Tile: tile, FromBack: true})
+		}
 		if rules.IsEligibleForHand(tile.GetSuit()) {
-			fmt.Printf("Adding replacement tile to hand: %s\n", tile)
 			r.addTileToHand(tile)
-			return tile
+			return tile, nil
 		}
 		// Else tile is a bonus tile, add it and repeat.
 		r.addTileToBonusArea(tile)
 	}
 }
 
+// promptAndExecutePlayerAction prompts the player until an action that proceeds the game is
+// executed, and returns the type of that action. The input being aborted ends the game.
 func (r *SinglePlayerRunner) promptAndExecutePlayerAction(
-	acceptedCommands ui.CommandTypes, outTileSource *rules.OutTileSource) ui.CommandType {
+	acceptedCommands ui.CommandTypes, outTileSource *rules.OutTileSource) (ui.CommandType, error) {
 	for {
-		cmd, err := r.receiver.PromptForCommand(acceptedCommands)
+		cmd, err := r.receiver.PromptForCommand(
+			withRiichiCommands(acceptedCommands, r.ruleSet, r.player))
 		if err != nil {
-			// TODO: this should be its own error struct. Something like IOError.
-			panic(newGameOverError(false))
+			return "", newGameOverError(newInputAbortedGameResult(r.getPlayerSeat(), err))
+		}
+		r.observers.notify(&CommandReceivedEvent{Seat: r.getPlayerSeat(), Command: cmd})
+		reason := getRiichiRejectionReason(cmd, r.ruleSet, r.player, outTileSource)
+		if reason != "" {
+			r.rejectCommand(reason)
+			continue
 		}
 
-		proceed := r.executePlayerAction(cmd, outTileSource)
+		proceed, err := r.executePlayerAction(cmd, outTileSource)
+		if err != nil {
+			return "", err
+		}
 		if proceed {
-			return cmd.GetCommandType()
+			return cmd.GetCommandType(), nil
 		}
 	}
 }
 
 func (r *SinglePlayerRunner) executePlayerAction(cmd *ui.Command,
-	outTileSource *rules.OutTileSource) bool {
+	outTileSource *rules.OutTileSource) (bool, error) {
 	switch cmd.GetCommandType() {
 	case ui.SortHand:
 		r.sortHand()
-		return false
+		return false, nil
 	case ui.ShowDiscardedTiles:
 		r.showDiscardedTiles()
-		return false
+		return false, nil
 	case ui.ShowMelded:
 		r.showMelded()
-		return false
+		return false, nil
+	case ui.Hint:
+		r.showHint()
+		return false, nil
 	case ui.DiscardTile:
-		return r.discardTile(cmd.GetTileIndexCommand().GetIndex())
+		return r.discardTile(cmd.GetTileIndexCommand().GetIndex()), nil
+	case ui.Riichi:
+		return r.declareRiichi(cmd.GetTileIndexCommand().GetIndex()), nil
 	case ui.Pong:
 		return r.declarePong()
 	case ui.Kong:
@@ -325,212 +434,310 @@ func (r *SinglePlayerRunner) executePlayerAction(cmd *ui.Command,
 		return r.declareChow(
 			cmd.GetTileIndexCommand2().GetIndex1(), cmd.GetTileIndexCommand2().GetIndex2())
 	case ui.Pass:
-		return true
+		return true, nil
 	case ui.Out:
 		return r.checkForOut(outTileSource)
 	}
-	fmt.Printf("Unhandled command: %s\n", cmd.GetCommandType())
-	return false
+	r.rejectCommand(fmt.Sprintf("Unhandled command: %s", cmd.GetCommandType()))
+	return false, nil
 }
 
-// checkForOut checks whether the current player state represents an Out. This function will
-// panic if the hand is an out hand, or return false if it is not an out hand.
-func (r *SinglePlayerRunner) checkForOut(outTileSource *rules.OutTileSource) bool {
-	counter := rules.NewOutPlanCalculator(rules.GetSuitsForGame(), r.player, outTileSource)
+// checkForOut checks whether the current player state represents an Out that qualifies under the
+// rule set. This function returns a gameOverError if the hand is an out hand, or false if it is not
+// an out hand.
+func (r *SinglePlayerRunner) checkForOut(outTileSource *rules.OutTileSource) (bool, error) {
+	counter := rules.NewOutPlanCalculatorForRuleSet(r.ruleSet, r.player, outTileSource)
 	plans := counter.Calculate()
 
 	if len(plans) > 0 {
-		fmt.Printf("Out: %s.\n", outTileSource)
+		context := rules.NewOutPlanScoringContext(
+			outTileSource, r.player, r.deck.NumRemainingTiles(), r.prevailingWindOrdinal)
+		scoredPlans := rules.GetQualifyingOutPlans(r.ruleSet,
+			r.ruleSet.GetOutPlansScorer().ScoreOutPlans(plans, context))
+		if len(scoredPlans) == 0 {
+			r.rejectCommand(fmt.Sprintf("Not an Out hand: no plan qualifies under %s rules!",
+				r.ruleSet.GetName()))
+			return false, nil
+		}
+		event := &OutDeclaredEvent{Seat: r.getPlayerSeat(), OutTileSource: outTileSource}
 		if *flags.ReportScoringFlag {
-			scorer := zj.NewOutPlansScorer()
-			context := rules.NewOutPlanScoringContext(
-				outTileSource, r.player, r.deck.NumRemainingTiles())
-			scoredPlans := scorer.ScoreOutPlans(plans, context)
-			fmt.Printf("Detailed scoring:\n")
-			fmt.Printf("%s\n", scoredPlans)
+			event.ScoredPlans = scoredPlans
+			event.ScoringContext = context
 		}
-		panic(newGameOverError(true))
+		r.observers.notify(event)
+		return false, newGameOverError(
+			newOutGameResult(r.getPlayerSeat(), outTileSource, scoredPlans))
 	}
-	fmt.Println("Not an Out hand!")
-	return false
+	r.rejectCommand("Not an Out hand!")
+	return false, nil
 }
 
 // Methods that manipulate player / deck state that should notify the observer.
 
+func (r *SinglePlayerRunner) getPlayerSeat() int {
+	return r.player.GetWindOrdinal()
+}
+
+func (r *SinglePlayerRunner) getPseudoOpponentSeat() int {
+	return r.pseudoOpponentGameState.GetWindOrdinal()
+}
+
 func (r *SinglePlayerRunner) sortHand() {
 	r.player.SortHand()
-	// TODO: Notify observer of hand sorted update.
-	fmt.Printf("Hand: %s\n", r.player.GetHand())
+	r.notifyHandUpdated()
+}
+
+func (r *SinglePlayerRunner) notifyHandUpdated() {
+	r.observers.notify(newHandUpdatedEvent(r.getPlayerSeat(), r.player))
 }
 
 func (r *SinglePlayerRunner) showDiscardedTiles() {
-	fmt.Printf("Discarded tiles: %s\n", r.player.GetDiscardedTiles())
+	r.showInfo(fmt.Sprintf("Discarded tiles: %s", r.player.GetDiscardedTiles()))
 }
 
 func (r *SinglePlayerRunner) showMelded() {
-	fmt.Printf("Melded groups: %s\n", r.player.GetMeldGroups())
+	r.showInfo(fmt.Sprintf("Melded groups: %s", r.player.GetMeldGroups()))
+}
+
+func (r *SinglePlayerRunner) showHint() {
+	advisor := rules.NewDiscardAdvisor(r.ruleSet, r.ruleSet.GetOutPlansScorer(), r.player,
+		[]*rules.PlayerGameState{r.pseudoOpponentGameState}, r.deck.NumRemainingTiles(),
+		r.prevailingWindOrdinal)
+	r.showInfo(fmt.Sprintf("Discard advice:\n%s", advisor.Advise()))
+}
+
+// showInfo notifies the observers of information for the player.
+func (r *SinglePlayerRunner) showInfo(info string) {
+	r.observers.notify(&InfoShownEvent{Seat: r.getPlayerSeat(), Info: info})
+}
+
+// notifyMeldDeclared notifies the observers that the player declared the meld group containing the
+// given tile.
+func (r *SinglePlayerRunner) notifyMeldDeclared(meldType ui.CommandType, tile *domain.Tile,
+	discarderSeat int) error {
+	event, err := newMeldDeclaredEvent(r.getPlayerSeat(), r.player, meldType, tile, discarderSeat)
+	if err != nil {
+		return err
+	}
+	r.observers.notify(event)
+	return nil
+}
+
+// rejectCommand notifies the observers that the last command of the player is rejected for the
+// given reason.
+func (r *SinglePlayerRunner) rejectCommand(reason string) {
+	r.observers.notify(&CommandRejectedEvent{Seat: r.getPlayerSeat(), Reason: reason})
 }
 
 func (r *SinglePlayerRunner) addTileToHand(t *domain.Tile) {
-	// TODO: add observer to update hand
 	r.player.AddTileToHand(t)
 }
 
 func (r *SinglePlayerRunner) addTileToHandNoCheck(t *domain.Tile) {
-	// TODO: add observer to update hand
 	r.player.AddTileToHandNoCheck(t)
 }
 
 func (r *SinglePlayerRunner) discardTile(index int) bool {
 	t, removed := r.player.DiscardTileAt(index)
 	if removed {
-		// TODO: notify observer
-		fmt.Printf("Discarded tile at %d: %s\n", index, t)
+		r.observers.notify(&TileDiscardedEvent{Seat: r.getPlayerSeat(), Tile: t})
+		r.showWaitsIfReady()
 	}
 	return removed
 }
 
-func (r *SinglePlayerRunner) discardCurrentBurnTile() {
+// declareRiichi declares riichi and discards the tile at the given index, if the hand stays ready
+// without that tile.
+func (r *SinglePlayerRunner) declareRiichi(index int) bool {
+	if !r.player.CanDeclareRiichi(r.ruleSet, index) {
+		r.rejectCommand(fmt.Sprintf("Cannot declare riichi by discarding tile at %d", index))
+		return false
+	}
+	t, _ := r.player.DiscardTileAt(index)
+	r.player.DeclareRiichi(r.ruleSet)
+	r.observers.notify(&RiichiDeclaredEvent{Seat: r.getPlayerSeat()})
+	r.observers.notify(&TileDiscardedEvent{Seat: r.getPlayerSeat(), Tile: t})
+	r.showWaitsIfReady()
+	return true
+}
+
+func (r *SinglePlayerRunner) showWaitsIfReady() {
+	shanten := rules.NewShantenCalculatorForRuleSet(r.ruleSet, r.player).Calculate()
+	if shanten != rules.ShantenReady {
+		return
+	}
+	calculator := rules.NewWaitCalculator(r.ruleSet, r.ruleSet.GetOutPlansScorer(), r.player,
+		[]*rules.PlayerGameState{r.pseudoOpponentGameState}, r.deck.NumRemainingTiles(),
+		r.prevailingWindOrdinal)
+	r.showInfo(fmt.Sprintf("Ready hand, waiting on: %s", calculator.Calculate()))
+}
+
+func (r *SinglePlayerRunner) discardCurrentBurnTile() error {
 	if r.currentBurnTile == nil {
-		panic(fmt.Errorf("There is no tile being burned"))
+		return fmt.Errorf("There is no tile being burned")
 	}
 
-	// TODO: notify observer
-	fmt.Printf("Moving burn tile %s to other discards\n", r.currentBurnTile)
-	r.pseudoOpponentGameState.AddTileToHand(r.currentBurnTile)
-	r.pseudoOpponentGameState.DiscardTileAt(0)
+	glog.V(2).Infof("Moving burn tile %s to other discards\n", r.currentBurnTile)
+	r.pseudoOpponentGameState.AddTileToDiscardArea(r.currentBurnTile)
 	r.currentBurnTile = nil
+	return nil
 }
 
-func (r *SinglePlayerRunner) declarePong() bool {
+func (r *SinglePlayerRunner) declarePong() (bool, error) {
 	if r.currentBurnTile == nil {
 		glog.V(2).Infof("There is no tile being burned")
-		return false
+		return false, nil
 	}
 
 	removed := r.player.DeclarePong(r.currentBurnTile)
 	if !removed {
-		fmt.Printf("Failed to declare pong\n")
-		return false
+		r.rejectCommand("Failed to declare pong")
+		return false, nil
+	}
+	err := r.notifyMeldDeclared(ui.Pong, r.currentBurnTile, r.getPseudoOpponentSeat())
+	if err != nil {
+		return false, err
 	}
-	// TODO: notify observer of meld area / hand change
-	fmt.Printf("Declared pong %s\n", r.currentBurnTile)
 	r.currentBurnTile = nil
-	r.promptAndExecutePlayerAction(withCommands(ui.DiscardTile), nil)
-	return removed
+	_, err = r.promptAndExecutePlayerAction(commandsAfterMelding, nil)
+	return removed, err
 }
 
-func (r *SinglePlayerRunner) declareKong() bool {
+func (r *SinglePlayerRunner) declareKong() (bool, error) {
 	if r.currentBurnTile == nil {
 		glog.V(2).Infof("There is no tile being burned")
-		return false
+		return false, nil
 	}
 
 	removed := r.player.DeclareKong(r.currentBurnTile)
 	if !removed {
-		fmt.Printf("Failed to declare kong\n")
-		return false
+		r.rejectCommand("Failed to declare kong")
+		return false, nil
 	}
 
-	// TODO: notify observer of meld area / hand change
-	fmt.Printf("Declared kong %s\n", r.currentBurnTile)
+	err := r.notifyMeldDeclared(ui.Kong, r.currentBurnTile, r.getPseudoOpponentSeat())
+	if err != nil {
+		return false, err
+	}
 	r.currentBurnTile = nil
 
 	// After drawing the replacement tile, the player may go out, or they must discard a tile.
-	replacementTile := r.replaceTileLoop()
-	r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
+	replacementTile, err := r.replaceTileLoop(true)
+	if err != nil {
+		return false, err
+	}
+	_, err = r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
 		rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawnReplacement, replacementTile, nil))
-	return removed
+	return removed, err
 }
 
-func (r *SinglePlayerRunner) declareConcealedKong(index int) bool {
+func (r *SinglePlayerRunner) declareConcealedKong(index int) (bool, error) {
 	t, removed := r.player.DeclareConcealedKong(index)
 	if !removed {
 		glog.V(2).Infof("Failed to declare concealed kong with tile at %d\n", index)
-		return false
+		return false, nil
 	}
 
-	// TODO: notify observer of meld area / hand change
-	fmt.Printf("Declared concealed kong %s\n", t)
+	if err := r.notifyMeldDeclared(ui.ConcealedKong, t, NoSeat); err != nil {
+		return false, err
+	}
 
 	// After drawing the replacement tile, the player may go out, or have another concealed kong.
 	// Note this may result in a recursion.
 	// TODO: don't do recursion?
-	replacementTile := r.replaceTileLoop()
-	r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
+	replacementTile, err := r.replaceTileLoop(true)
+	if err != nil {
+		return false, err
+	}
+	_, err = r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
 		rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawnReplacement, replacementTile, nil))
-	return removed
+	return removed, err
 }
 
-func (r *SinglePlayerRunner) declareAdditionalKong(index int) bool {
+func (r *SinglePlayerRunner) declareAdditionalKong(index int) (bool, error) {
 	t, removed := r.player.DeclareAdditionalKong(index)
 	if !removed {
 		glog.V(2).Infof("Failed to declare additional kong with tile at %d\n", index)
-		return false
+		return false, nil
 	}
 
-	// TODO: notify observer of meld area / hand change
-	fmt.Printf("Declared additional kong %s\n", t)
+	if err := r.notifyMeldDeclared(ui.AdditionalKong, t, NoSeat); err != nil {
+		return false, err
+	}
 
 	// After drawing the replacement tile, the player may go out, or have another concealed kong.
 	// Note this may result in a recursion.
 	// TODO: don't do recursion?
-	replacementTile := r.replaceTileLoop()
-	r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
+	replacementTile, err := r.replaceTileLoop(true)
+	if err != nil {
+		return false, err
+	}
+	_, err = r.promptAndExecutePlayerAction(commandsAfterDrawingTile,
 		rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawnReplacement, replacementTile, nil))
-	return removed
+	return removed, err
 }
 
-func (r *SinglePlayerRunner) declareChow(index1, index2 int) bool {
+func (r *SinglePlayerRunner) declareChow(index1, index2 int) (bool, error) {
 	if r.currentBurnTile == nil {
 		glog.V(2).Infof("There is no tile being burned")
-		return false
+		return false, nil
 	}
 
-	tiles, removed := r.player.DeclareChow(r.currentBurnTile, index1, index2)
+	_, removed := r.player.DeclareChow(r.currentBurnTile, index1, index2)
 	if !removed {
-		fmt.Printf("Failed to declared chow\n")
-		return false
+		r.rejectCommand("Failed to declare chow")
+		return false, nil
 	}
 
-	fmt.Printf("Declared chow %s\n", tiles)
-	// TODO: notify observer of meld area / hand change
+	err := r.notifyMeldDeclared(ui.Chow, r.currentBurnTile, r.getPseudoOpponentSeat())
+	if err != nil {
+		return false, err
+	}
 	r.currentBurnTile = nil
-	r.promptAndExecutePlayerAction(withCommands(ui.DiscardTile), nil)
-	return true
+	_, err = r.promptAndExecutePlayerAction(commandsAfterMelding, nil)
+	return true, err
 }
 
 func (r *SinglePlayerRunner) bulkMoveBonusTilesFromHand() int {
-	// TODO: notify observer of moved tiles.
-	return r.player.BulkMoveBonusTilesFromHand()
+	numBonusTiles := len(r.player.GetBonusTiles())
+	numMoved := r.player.BulkMoveBonusTilesFromHand()
+	for _, t := range r.player.GetBonusTiles()[numBonusTiles:] {
+		r.observers.notify(&BonusTileMovedEvent{Seat: r.getPlayerSeat(), Tile: t})
+	}
+	return numMoved
 }
 
 func (r *SinglePlayerRunner) addTileToBonusArea(t *domain.Tile) {
-	fmt.Printf("Adding tile to bonus area: %s\n", t)
 	r.player.AddTileToBonusArea(t)
-	// TODO: notify observer
+	r.observers.notify(&BonusTileMovedEvent{Seat: r.getPlayerSeat(), Tile: t})
 }
 
 func (r *SinglePlayerRunner) addTileToOtherBonusArea(t *domain.Tile) {
-	fmt.Printf("Adding tile to other bonus area: %s\n", t)
 	r.pseudoOpponentGameState.AddTileToBonusArea(t)
-	// TODO: notify observer
+	r.observers.notify(&BonusTileMovedEvent{Seat: r.getPseudoOpponentSeat(), Tile: t})
 }
 
-func (r *SinglePlayerRunner) drawFromDeckFront() *domain.Tile {
-	// TODO: notify observer of deck pop
+// drawFromDeckFront draws a tile from the front of the deck. If the deck is empty, the game is
+// drawn and a gameOverError is returned.
+func (r *SinglePlayerRunner) drawFromDeckFront() (*domain.Tile, error) {
 	tile, err := r.deck.PopFront()
 	if err != nil {
-		panic(newGameOverError(false))
+		return nil, r.endDrawnGame()
 	}
-	return tile
+	return tile, nil
 }
 
-func (r *SinglePlayerRunner) drawFromDeckBack() *domain.Tile {
-	// TODO: notify observer of deck pop
+// drawFromDeckBack draws a tile from the back of the deck. If the deck is empty, the game is
+// drawn and a gameOverError is returned.
+func (r *SinglePlayerRunner) drawFromDeckBack() (*domain.Tile, error) {
 	tile, err := r.deck.PopBack()
 	if err != nil {
-		panic(newGameOverError(false))
+		return nil, r.endDrawnGame()
 	}
-	return tile
+	return tile, nil
+}
+
+func (r *SinglePlayerRunner) endDrawnGame() error {
+	r.observers.notify(&GameDrawnEvent{})
+	return newGameOverError(newWallExhaustedGameResult())
 }
@@ -0,0 +1,351 @@
+package engine
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	// Registers the rule sets used in tests.
+	_ "github.com/derekimcheng/mj/rules/hk"
+	_ "github.com/derekimcheng/mj/rules/riichi"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// fillerTiles are never drawn in tests, but are required for dealing the hands.
+const fillerTiles = "123456789d123456789d123456789d123456789d1234w1234w1234w1234w123y"
+
+// eventRecorder is a GameObserver that keeps every event.
+type eventRecorder struct {
+	events []GameEvent
+}
+
+func (r *eventRecorder) OnGameEvent(event GameEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) getLastEvent() GameEvent {
+	if len(r.events) == 0 {
+		return nil
+	}
+	return r.events[len(r.events)-1]
+}
+
+// newDeckForTest returns a deck that deals the given front tiles first, in order, and the given
+// back tiles when drawing from the back, in order.
+func newDeckForTest(t *testing.T, front, back string) domain.Deck {
+	parser := shorthand.NewParser()
+	frontTiles, err := parser.ParseTiles(front + fillerTiles)
+	require.NoError(t, err)
+	backTiles, err := parser.ParseTiles(back)
+	require.NoError(t, err)
+	tiles := frontTiles
+	for i := len(backTiles) - 1; i >= 0; i-- {
+		tiles = append(tiles, backTiles[i])
+	}
+	return domain.NewDeck(tiles)
+}
+
+func parseTileForTest(t *testing.T, str string) *domain.Tile {
+	tiles, err := shorthand.NewParser().ParseTiles(str)
+	require.NoError(t, err)
+	require.Len(t, tiles, 1)
+	return tiles[0]
+}
+
+// runScriptedGame runs a single player game with the given deck and script, checks that the whole
+// script is used, and returns the result and the recorded events. The given observers are also
+// notified of the events.
+func runScriptedGame(t *testing.T, numBurns int, deck domain.Deck, script string,
+	observers ...GameObserver) (*GameResult, *eventRecorder) {
+	defer func(original int) { *flags.NumBurnsFlag = original }(*flags.NumBurnsFlag)
+	*flags.NumBurnsFlag = numBurns
+
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	receiver, err := ui.NewScriptedCommandReceiverFromReader(strings.NewReader(script))
+	require.NoError(t, err)
+	recorder := &eventRecorder{}
+	runner := NewSinglePlayerRunner(ruleSet, receiver)
+	runner.AddObserver(recorder)
+	for _, observer := range observers {
+		runner.AddObserver(observer)
+	}
+
+	result, err := runner.Start(deck)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.NoError(t, receiver.Verify())
+	return result, recorder
+}
+
+// requireOut checks that the game ended with an Out of the given source type.
+func requireOut(t *testing.T, result *GameResult, recorder *eventRecorder,
+	sourceType rules.OutTileSourceType) *OutDeclaredEvent {
+	require.True(t, result.IsOut(), "Game did not end with an Out: %s", result)
+	assert.Equal(t, 0, result.Seat)
+	assert.Equal(t, sourceType, result.OutTileSource.SourceType)
+	assert.NotNil(t, result.WinningPlan)
+
+	outEvent, ok := recorder.getLastEvent().(*OutDeclaredEvent)
+	require.True(t, ok, "Last event is not an Out: %s", recorder.getLastEvent())
+	assert.Equal(t, result.OutTileSource, outEvent.OutTileSource)
+	return outEvent
+}
+
+func countEvents(recorder *eventRecorder, predicate func(GameEvent) bool) int {
+	count := 0
+	for _, event := range recorder.events {
+		if predicate(event) {
+			count++
+		}
+	}
+	return count
+}
+
+func Test_SinglePlayer_InitialHandOut(t *testing.T) {
+	deck := newDeckForTest(t, "11122b123456789m", "")
+	result, recorder := runScriptedGame(t, 0, deck, "out\n")
+	requireOut(t, result, recorder, rules.OutTileSourceTypeInitialHand)
+}
+
+func Test_SinglePlayer_BonusReplacementAndSelfDrawnOut(t *testing.T) {
+	deck := newDeckForTest(t, "1112b1f123456789m2b", "5d")
+	result, recorder := runScriptedGame(t, 0, deck, `
+		# Hand after replacement: 1112b123456789m5d
+		discard 13
+		out
+	`)
+	outEvent := requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawn)
+	assert.Equal(t, 0, domain.CompareTiles(parseTileForTest(t, "2b"), outEvent.OutTileSource.Tile))
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*BonusTileMovedEvent)
+		return ok
+	}))
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		drawn, ok := event.(*TileDrawnEvent)
+		return ok && drawn.FromBack
+	}))
+}
+
+func Test_SinglePlayer_ConcealedKongReplacementOut(t *testing.T) {
+	deck := newDeckForTest(t, "55b1111234567m89m", "7m")
+	result, recorder := runScriptedGame(t, 0, deck, `
+		ckong 2
+		out
+	`)
+	requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawnReplacement)
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		meld, ok := event.(*MeldDeclaredEvent)
+		return ok && meld.MeldType == ui.ConcealedKong && len(meld.Tiles) == 4
+	}))
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*KongReplacementDrawnEvent)
+		return ok
+	}))
+}
+
+func Test_SinglePlayer_ChowAndDiscardOut(t *testing.T) {
+	// The burn tiles are 4m and 1d.
+	deck := newDeckForTest(t, "11m23m456b789b11d1w3y4m1d", "")
+	result, recorder := runScriptedGame(t, 1, deck, `
+		# Hand: 456789b1123m11d3y1w
+		discard 13
+		chow 8 9
+		discard 10
+		out
+	`)
+	outEvent := requireOut(t, result, recorder, rules.OutTileSourceTypeDiscard)
+	assert.Equal(t, 1, outEvent.OutTileSource.DiscardInfo.DiscardPlayer.GetWindOrdinal())
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		meld, ok := event.(*MeldDeclaredEvent)
+		return ok && meld.MeldType == ui.Chow && meld.DiscarderSeat == 1
+	}))
+}
+
+func Test_SinglePlayer_PongAndAdditionalKongOut(t *testing.T) {
+	// The burn tiles are 5m and 8d, followed by the drawn tile 5m.
+	deck := newDeckForTest(t, "55m123456789b9d1w2w5m8d5m", "9d")
+	result, recorder := runScriptedGame(t, 1, deck, `
+		# Hand: 123456789b55m9d1w2w
+		discard 12
+		pong
+		discard 10
+		pass
+		akong 10
+		out
+	`)
+	requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawnReplacement)
+	meldTypes := []ui.CommandType{}
+	for _, event := range recorder.events {
+		if meld, ok := event.(*MeldDeclaredEvent); ok {
+			meldTypes = append(meldTypes, meld.MeldType)
+		}
+	}
+	assert.Equal(t, []ui.CommandType{ui.Pong, ui.AdditionalKong}, meldTypes)
+}
+
+func Test_SinglePlayer_DrawnGame(t *testing.T) {
+	// Every tile is discarded as soon as it is drawn until the deck is empty.
+	deck := newDeckForTest(t, "", "")
+	var script strings.Builder
+	for i := 0; i <= deck.NumRemainingTiles()-14; i++ {
+		script.WriteString("discard 13\n")
+	}
+	result, recorder := runScriptedGame(t, 0, deck, script.String())
+	assert.Equal(t, GameEndReasonWallExhausted, result.Reason)
+	assert.Equal(t, NoSeat, result.Seat)
+	assert.Nil(t, result.WinningPlan)
+	_, ok := recorder.getLastEvent().(*GameDrawnEvent)
+	assert.True(t, ok, "Game did not end in a draw: %s", recorder.getLastEvent())
+}
+
+func Test_SinglePlayer_UnacceptedCommand(t *testing.T) {
+	defer func(original int) { *flags.NumBurnsFlag = original }(*flags.NumBurnsFlag)
+	*flags.NumBurnsFlag = 0
+
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	receiver := ui.NewScriptedCommandReceiver([]*ui.Command{ui.NewPongCommand()})
+	runner := NewSinglePlayerRunner(ruleSet, receiver)
+	result, err := runner.Start(newDeckForTest(t, "", ""))
+	require.NoError(t, err)
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+	assert.Equal(t, 0, result.Seat)
+	assert.Error(t, receiver.Err())
+	assert.Equal(t, receiver.Err(), result.InputErr)
+	assert.Error(t, receiver.Verify())
+}
+
+func Test_SinglePlayer_RejectedOut(t *testing.T) {
+	defer func(original int) { *flags.NumBurnsFlag = original }(*flags.NumBurnsFlag)
+	*flags.NumBurnsFlag = 0
+
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	receiver := ui.NewScriptedCommandReceiver([]*ui.Command{ui.NewOutCommand()})
+	recorder := &eventRecorder{}
+	runner := NewSinglePlayerRunner(ruleSet, receiver)
+	runner.AddObserver(recorder)
+	// The player is prompted again after the Out is rejected, which ends the script.
+	result, err := runner.Start(newDeckForTest(t, "", ""))
+	require.NoError(t, err)
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		rejected, ok := event.(*CommandRejectedEvent)
+		return ok && rejected.Seat == 0
+	}))
+}
+
+// runScriptedRiichiGame runs a single player game under the Riichi rules with the given deck and
+// script, and returns the result, the recorded events and the receiver.
+func runScriptedRiichiGame(t *testing.T, numBurns int, deck domain.Deck,
+	script string) (*GameResult, *eventRecorder, *ui.ScriptedCommandReceiver) {
+	defer func(original int) { *flags.NumBurnsFlag = original }(*flags.NumBurnsFlag)
+	*flags.NumBurnsFlag = numBurns
+
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameRiichi)
+	require.NoError(t, err)
+	receiver, err := ui.NewScriptedCommandReceiverFromReader(strings.NewReader(script))
+	require.NoError(t, err)
+	recorder := &eventRecorder{}
+	runner := NewSinglePlayerRunner(ruleSet, receiver)
+	runner.AddObserver(recorder)
+	result, err := runner.Start(deck)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	return result, recorder, receiver
+}
+
+func Test_SinglePlayer_RiichiSelfDrawnOut(t *testing.T) {
+	result, recorder, receiver := runScriptedRiichiGame(t, 0,
+		newDeckForTest(t, "123456789b5m23d99d", ""), `
+		# Discarding 1b does not leave a ready hand.
+		riichi 0
+		# Discards 5m, waiting on 1d or 4d.
+		riichi 9
+		out
+	`)
+	require.NoError(t, receiver.Verify())
+
+	requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawn)
+	assert.Equal(t, 1, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*RiichiDeclaredEvent)
+		return ok
+	}))
+	assert.Contains(t, rules.PatternNamesForTest(result.WinningPlan), "立直")
+}
+
+func Test_SinglePlayer_RiichiOnlyDiscardsDrawnTile(t *testing.T) {
+	result, recorder, receiver := runScriptedRiichiGame(t, 0,
+		newDeckForTest(t, "1113b456789m999d1w1b3b", ""), `
+		# Discards 1w, waiting on 2b or 3b.
+		riichi 13
+		# Draws 1b. The kong would leave a wait on 3b only.
+		ckong 0
+		# 4m is not the drawn tile.
+		discard 4
+		discard 13
+		# Draws 3b.
+		out
+	`)
+	require.NoError(t, receiver.Verify())
+
+	requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawn)
+	assert.Equal(t, 2, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*CommandRejectedEvent)
+		return ok
+	}))
+	assert.Equal(t, 0, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*MeldDeclaredEvent)
+		return ok
+	}))
+}
+
+func Test_SinglePlayer_RiichiConcealedKongKeepingWaits(t *testing.T) {
+	result, recorder, receiver := runScriptedRiichiGame(t, 0,
+		newDeckForTest(t, "111456789b23d99d1w1b", "1d"), `
+		# Discards 1w, waiting on 1d or 4d.
+		riichi 13
+		# Draws 1b. The kong keeps the waits.
+		ckong 0
+		out
+	`)
+	require.NoError(t, receiver.Verify())
+
+	requireOut(t, result, recorder, rules.OutTileSourceTypeSelfDrawnReplacement)
+	assert.Equal(t, 0, countEvents(recorder, func(event GameEvent) bool {
+		_, ok := event.(*CommandRejectedEvent)
+		return ok
+	}))
+}
+
+func Test_SinglePlayer_RiichiCannotMeldBurnTile(t *testing.T) {
+	result, _, receiver := runScriptedRiichiGame(t, 1,
+		newDeckForTest(t, "123456789b5m23d99d9d", ""), `
+		riichi 9
+		# The burn tile is 9d.
+		pong
+	`)
+	err := receiver.Verify()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unacceptable command 2 'pong'")
+	assert.Equal(t, GameEndReasonInputAborted, result.Reason)
+}
+
+func Test_SinglePlayer_InvalidInitialHand(t *testing.T) {
+	defer func(original int) { *flags.NumBurnsFlag = original }(*flags.NumBurnsFlag)
+	*flags.NumBurnsFlag = 0
+
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+	receiver := ui.NewScriptedCommandReceiver(nil)
+	runner := NewSinglePlayerRunner(ruleSet, receiver)
+	// The deck deals a fifth 1b.
+	result, err := runner.Start(newDeckForTest(t, "11111b23456789m", ""))
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -17,8 +17,12 @@ const (
 	AppModeDeck AppMode = "deck"
 	// AppModeSingle runs the single player mode.
 	AppModeSingle AppMode = "single"
+	// AppModeMulti runs the four player mode.
+	AppModeMulti AppMode = "multi"
 	// AppModeAnalyzeState analyzes and scores the input state.
 	AppModeAnalyzeState AppMode = "state"
+	// AppModeReplay steps through the game record given by RecordFileFlag.
+	AppModeReplay AppMode = "replay"
 )
 
 // RuleNameFlag specifies the MJ rule name.
@@ -32,6 +36,39 @@ const (
 	RuleNameHK RuleName = "hk"
 	// RuleNameZJ is Zung Jung MJ.
 	RuleNameZJ RuleName = "zj"
+	// RuleNameRiichi is Riichi (Japanese) MJ.
+	RuleNameRiichi RuleName = "riichi"
+	// RuleNameMCR is Chinese Official MJ, i.e. Mahjong Competition Rules.
+	RuleNameMCR RuleName = "mcr"
+	// RuleNameTW is Taiwanese 16-tile MJ.
+	RuleNameTW RuleName = "tw"
+)
+
+// SeedFlag specifies the seed used for shuffling the deck. The same seed and rule name always
+// result in the same deal. If 0, a seed is generated from the current time.
+var SeedFlag = flag.Int64("mj.seed", 0, "Seed for shuffling the deck, or 0 for a random seed")
+
+// RecordFileFlag specifies the file that the game record is saved to in single and multi player
+// modes, or loaded from in replay mode. No record is saved if empty.
+var RecordFileFlag = flag.String("mj.recordFile", "", "Game record file to save or replay")
+
+// PrevailingWindFlag specifies the prevailing wind, i.e. the wind of the round (1=E, 2=S, 3=W,
+// 4=N), of the game in single and multi player modes, or of the state to analyze.
+var PrevailingWindFlag = flag.Int("mj.prevailingWind", 1, "Prevailing wind (1=E, 2=S, 3=W, 4=N)")
+
+// OutputFlag specifies the format of the scoring results printed in the analyzer, single and multi
+// player modes. With json, the standard output only contains the results, and the other messages
+// of the game modes are printed to stderr.
+var OutputFlag = flag.String("mj.output", OutputFormatText, "Output format of scoring (text, json)")
+
+// OutputFormat specifies the format of the scoring results.
+type OutputFormat = string
+
+const (
+	// OutputFormatText prints human readable text.
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON prints one JSON object per line, as encoded by the report package.
+	OutputFormatJSON OutputFormat = "json"
 )
 
 //// Single player mode flags
@@ -41,3 +78,48 @@ var NumBurnsFlag = flag.Int("mj.numBurns", 0, "Number of tiles to burn in each r
 
 // ReportScoringFlag specifies whether to turn on detailed scoring report after an Out.
 var ReportScoringFlag = flag.Bool("mj.reportScoring", true, "Report detailed scoring after an Out")
+
+//// Multi player mode flags
+
+// NumHumanPlayersFlag specifies the number of seats played from the console in multi player mode,
+// starting from East. The remaining seats are played by bots.
+var NumHumanPlayersFlag = flag.Int("mj.numHumans", 1, "Number of human players in multi player mode")
+
+// BotLevelFlag specifies the level of the bots in multi player mode.
+var BotLevelFlag = flag.String("mj.botLevel", "greedy", "Level of bots (random, greedy, scoring)")
+
+//// State analyzer mode flags
+
+// StateFlag specifies the state to analyze in a single line, e.g.
+// "123b55m [111m] seat=2 source=d tile=5m". The state is prompted for if no state is given.
+var StateFlag = flag.String("mj.state", "", "State to analyze in the single-line state notation")
+
+// StateFileFlag specifies a file with one state to analyze per line, in the same notation as
+// StateFlag.
+var StateFileFlag = flag.String("mj.stateFile", "", "File of states to analyze, one per line")
+
+// HandFlag specifies the hand and meld groups of the state to analyze, not including the out
+// tile, e.g. "123b55m [111m]". The remaining state flags are only used if it is set.
+var HandFlag = flag.String("mj.hand", "", "Hand and meld groups of the state to analyze")
+
+// BonusTilesFlag specifies the bonus tiles of the state to analyze.
+var BonusTilesFlag = flag.String("mj.bonusTiles", "", "Bonus tiles of the state to analyze")
+
+// SeatWindFlag specifies the wind seat of the player (1=E, 2=S, 3=W, 4=N).
+var SeatWindFlag = flag.Int("mj.seatWind", 1, "Wind seat of the player (1=E, 2=S, 3=W, 4=N)")
+
+// OutSourceFlag specifies the out source of the state to analyze.
+var OutSourceFlag = flag.String("mj.outSource", "", "Out source (d, sd, sdr, ak, ih)")
+
+// OutTileFlag specifies the out tile of the state to analyze, unless the out source is ih.
+var OutTileFlag = flag.String("mj.outTile", "", "Out tile of the state to analyze")
+
+// LastTileFlag specifies whether the out tile is the last tile.
+var LastTileFlag = flag.Bool("mj.lastTile", false, "Whether the out tile is the last tile")
+
+// FirstDiscardFlag specifies whether the out tile is the first discard of the discarder.
+var FirstDiscardFlag = flag.Bool("mj.firstDiscard", false,
+	"Whether the out tile is the first discard of the discarder")
+
+// RiichiFlag specifies whether the player of the state to analyze has declared riichi.
+var RiichiFlag = flag.Bool("mj.riichi", false, "Whether the player has declared riichi")
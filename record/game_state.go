@@ -0,0 +1,323 @@
+package record
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/pkg/errors"
+)
+
+// GameState is the state of a recorded game after a number of entries are applied.
+type GameState struct {
+	// Players contains the state of each seat, indexed by wind ordinal.
+	Players           []*rules.PlayerGameState
+	NumRemainingTiles int
+	// DiscardedTile is the last discarded tile if it may still be claimed, or nil. It is not in
+	// the discard area of DiscarderSeat yet.
+	DiscardedTile *domain.Tile
+	DiscarderSeat int
+}
+
+// GetStateAt rebuilds the state of the game after the first numEntries entries are applied. The
+// state before any entry is the state right after the hands are dealt.
+func (r *GameRecord) GetStateAt(numEntries int) (*GameState, error) {
+	if numEntries < 0 || numEntries > len(r.Entries) {
+		return nil, fmt.Errorf("Entry count out of range [0, %d]: %d", len(r.Entries), numEntries)
+	}
+	builder, err := newStateBuilder(r.Header)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range r.Entries[:numEntries] {
+		if err := builder.apply(entry); err != nil {
+			return nil, errors.Wrapf(err, "unable to apply entry %d (%s)", i+1, entry)
+		}
+	}
+	return builder.getState(), nil
+}
+
+// defaultNumTilesPerHand is the number of tiles dealt to each hand of a record without
+// Header.NumTilesPerHand.
+const defaultNumTilesPerHand = 13
+
+// stateBuilder rebuilds the state of a game by applying recorded events to the dealt hands.
+type stateBuilder struct {
+	parser  *shorthand.Parser
+	deck    domain.Deck
+	players []*rules.PlayerGameState
+	// discardedTile is the tile that may be claimed, which is moved to the discard area of
+	// discarderSeat once an event other than a claim is applied.
+	discardedTile *domain.Tile
+	discarderSeat int
+}
+
+func newStateBuilder(header *Header) (*stateBuilder, error) {
+	parser := shorthand.NewParser()
+	wall, err := parser.ParseTiles(header.Wall)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse wall")
+	}
+	deck := domain.NewWall(wall, header.DeadWallSize)
+
+	var hands []*domain.Hand
+	for seat := 0; seat < header.NumPlayers; seat++ {
+		hands = append(hands, domain.NewHand())
+	}
+	if header.NumHands > 0 {
+		numTilesPerHand := header.NumTilesPerHand
+		if numTilesPerHand == 0 {
+			numTilesPerHand = defaultNumTilesPerHand
+		}
+		if err := rules.PopulateHands(deck, hands[:header.NumHands], numTilesPerHand); err != nil {
+			return nil, errors.Wrapf(err, "unable to deal hands")
+		}
+	}
+
+	b := &stateBuilder{parser: parser, deck: deck, discarderSeat: -1}
+	for seat, hand := range hands {
+		hand.Sort()
+		b.players = append(b.players, rules.NewPlayerGameState(hand, seat))
+	}
+	return b, nil
+}
+
+func (b *stateBuilder) getState() *GameState {
+	state := &GameState{
+		NumRemainingTiles: b.deck.NumRemainingTiles(),
+		DiscardedTile:     b.discardedTile,
+		DiscarderSeat:     b.discarderSeat,
+	}
+	for _, player := range b.players {
+		state.Players = append(state.Players, player.Copy())
+	}
+	return state
+}
+
+func (b *stateBuilder) apply(entry *Entry) error {
+	if entry.Kind != EntryKindEvent {
+		// Commands do not change the state by themselves.
+		return nil
+	}
+	// The discarded tile may be claimed until the next event other than a claim. The hands of the
+	// seats that are asked for a claim are updated before they are prompted.
+	keepsDiscardedTile := entry.Event == EventOutDeclared || entry.Event == EventHandUpdated ||
+		(entry.Event == EventMeldDeclared && entry.DiscarderSeat != nil)
+	if !keepsDiscardedTile {
+		b.moveDiscardedTileToDiscardArea()
+	}
+	if entry.Seat < 0 {
+		return nil
+	}
+	if entry.Seat >= len(b.players) {
+		return fmt.Errorf("Seat out of range: %d", entry.Seat)
+	}
+	player := b.players[entry.Seat]
+
+	switch entry.Event {
+	case EventTileDrawn:
+		tile, err := b.drawTile(entry.Tile, entry.FromBack)
+		if err != nil {
+			return err
+		}
+		player.AddTileToHandNoCheck(tile)
+	case EventKongReplacementDrawn:
+		tile, err := b.drawTile(entry.Tile, true)
+		if err != nil {
+			return err
+		}
+		player.AddTileToHandNoCheck(tile)
+	case EventBonusTileMoved:
+		tile, err := b.removeTileFromHand(player, entry.Tile)
+		if err != nil {
+			return err
+		}
+		player.AddTileToBonusArea(tile)
+	case EventHandUpdated:
+		return b.reorderHand(player, entry.Tiles)
+	case EventTileDiscarded:
+		tile, err := b.removeTileFromHand(player, entry.Tile)
+		if err != nil {
+			return err
+		}
+		b.discardedTile = tile
+		b.discarderSeat = entry.Seat
+	case EventMeldDeclared:
+		return b.declareMeld(player, entry)
+	}
+	return nil
+}
+
+func (b *stateBuilder) moveDiscardedTileToDiscardArea() {
+	if b.discardedTile != nil {
+		b.players[b.discarderSeat].AddTileToDiscardArea(b.discardedTile)
+	}
+	b.discardedTile = nil
+	b.discarderSeat = -1
+}
+
+// drawTile draws a tile from the deck and checks it against the recorded tile.
+func (b *stateBuilder) drawTile(recordedTile string, fromBack bool) (*domain.Tile, error) {
+	expectedTile, err := b.parseTile(recordedTile)
+	if err != nil {
+		return nil, err
+	}
+	var tile *domain.Tile
+	if fromBack {
+		tile, err = b.deck.PopBack()
+	} else {
+		tile, err = b.deck.PopFront()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isSameTile(tile, expectedTile) {
+		return nil, fmt.Errorf("Drawn tile %s does not match wall tile %s", expectedTile, tile)
+	}
+	return tile, nil
+}
+
+func (b *stateBuilder) declareMeld(player *rules.PlayerGameState, entry *Entry) error {
+	tile, err := b.parseTile(entry.Tile)
+	if err != nil {
+		return err
+	}
+
+	switch entry.MeldType {
+	case ui.ConcealedKong, ui.AdditionalKong:
+		index := findTileIndex(player.GetHand().GetTiles(), tile)
+		if index < 0 {
+			return fmt.Errorf("Tile %s not found in hand", tile)
+		}
+		declared := false
+		if entry.MeldType == ui.ConcealedKong {
+			_, declared = player.DeclareConcealedKong(index)
+		} else {
+			_, declared = player.DeclareAdditionalKong(index)
+		}
+		if !declared {
+			return fmt.Errorf("Failed to declare %s with tile %s", entry.MeldType, tile)
+		}
+		return nil
+	}
+
+	if b.discardedTile == nil || !isSameTile(b.discardedTile, tile) {
+		return fmt.Errorf("Tile %s was not discarded", tile)
+	}
+	claimedTile := b.discardedTile
+	declared := false
+	switch entry.MeldType {
+	case ui.Pong:
+		declared = player.DeclarePong(claimedTile)
+	case ui.Kong:
+		declared = player.DeclareKong(claimedTile)
+	case ui.Chow:
+		index1, index2, err := b.findChowIndices(player, claimedTile, entry.Tiles)
+		if err != nil {
+			return err
+		}
+		_, declared = player.DeclareChow(claimedTile, index1, index2)
+	default:
+		return fmt.Errorf("Unknown meld type %s", entry.MeldType)
+	}
+	if !declared {
+		return fmt.Errorf("Failed to declare %s with tile %s", entry.MeldType, tile)
+	}
+	b.discardedTile = nil
+	b.discarderSeat = -1
+	return nil
+}
+
+// findChowIndices returns the indices of the tiles in the hand that form the given chow with the
+// claimed tile.
+func (b *stateBuilder) findChowIndices(player *rules.PlayerGameState, claimedTile *domain.Tile,
+	recordedTiles string) (int, int, error) {
+	chowTiles, err := b.parser.ParseTiles(recordedTiles)
+	if err != nil {
+		return 0, 0, err
+	}
+	handTiles := player.GetHand().GetTiles()
+	var indices []int
+	claimed := false
+	for _, chowTile := range chowTiles {
+		if !claimed && isSameTile(chowTile, claimedTile) {
+			claimed = true
+			continue
+		}
+		index := findTileIndex(handTiles, chowTile)
+		if index < 0 {
+			return 0, 0, fmt.Errorf("Tile %s not found in hand", chowTile)
+		}
+		indices = append(indices, index)
+	}
+	if len(indices) != 2 {
+		return 0, 0, fmt.Errorf("Invalid chow %s for tile %s", recordedTiles, claimedTile)
+	}
+	return indices[0], indices[1], nil
+}
+
+// reorderHand orders the hand of the player as the recorded tiles.
+func (b *stateBuilder) reorderHand(player *rules.PlayerGameState, recordedTiles string) error {
+	tiles, err := b.parser.ParseTiles(recordedTiles)
+	if err != nil {
+		return err
+	}
+	remainingTiles := append(domain.Tiles{}, player.GetHand().GetTiles()...)
+	if len(tiles) != len(remainingTiles) {
+		return fmt.Errorf("Hand %s does not match recorded hand %s", remainingTiles, tiles)
+	}
+	var orderedTiles domain.Tiles
+	for _, tile := range tiles {
+		index := findTileIndex(remainingTiles, tile)
+		if index < 0 {
+			return fmt.Errorf("Tile %s not found in hand", tile)
+		}
+		orderedTiles = append(orderedTiles, remainingTiles[index])
+		remainingTiles = append(remainingTiles[:index], remainingTiles[index+1:]...)
+	}
+	player.GetHand().SetTiles(orderedTiles)
+	return nil
+}
+
+func (b *stateBuilder) removeTileFromHand(player *rules.PlayerGameState,
+	recordedTile string) (*domain.Tile, error) {
+	tile, err := b.parseTile(recordedTile)
+	if err != nil {
+		return nil, err
+	}
+	index := findTileIndex(player.GetHand().GetTiles(), tile)
+	if index < 0 {
+		return nil, fmt.Errorf("Tile %s not found in hand", tile)
+	}
+	removedTile, _ := player.RemoveTileFromHandAt(index)
+	return removedTile, nil
+}
+
+func (b *stateBuilder) parseTile(recordedTile string) (*domain.Tile, error) {
+	tiles, err := b.parser.ParseTiles(recordedTile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse tile %s", recordedTile)
+	}
+	if len(tiles) != 1 {
+		return nil, fmt.Errorf("Expected 1 tile, got %d: %s", len(tiles), recordedTile)
+	}
+	return tiles[0], nil
+}
+
+// findTileIndex returns the index of the first tile with the same suit and ordinal as the given
+// tile, or -1 if there is none.
+func findTileIndex(tiles domain.Tiles, tile *domain.Tile) int {
+	for i := range tiles {
+		if isSameTile(tiles[i], tile) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isSameTile returns whether the given tiles have the same suit, ordinal and variant, e.g. a red
+// five is recorded differently from the other fives.
+func isSameTile(tile1, tile2 *domain.Tile) bool {
+	return domain.CompareTiles(tile1, tile2) == 0 && tile1.GetVariant() == tile2.GetVariant()
+}
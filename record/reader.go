@@ -0,0 +1,91 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// maxLineSize is the maximum size of a line in a game record. The header contains the whole wall.
+const maxLineSize = 1024 * 1024
+
+// Read reads a game record in the format written by Writer. Returns an error if the record cannot
+// be parsed or is of an unsupported version.
+func Read(r io.Reader) (*GameRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrapf(err, "unable to read game record")
+		}
+		return nil, fmt.Errorf("Game record is empty")
+	}
+
+	header := &Header{}
+	if err := json.Unmarshal(scanner.Bytes(), header); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse game record header")
+	}
+	if header.Version != FormatVersion {
+		return nil, fmt.Errorf("Unsupported game record version %d, expected %d",
+			header.Version, FormatVersion)
+	}
+	if header.NumHands > header.NumPlayers {
+		return nil, fmt.Errorf("Invalid number of hands %d for %d players",
+			header.NumHands, header.NumPlayers)
+	}
+	if header.PrevailingWind < 0 || header.PrevailingWind >= rules.Winds.GetSize() {
+		return nil, fmt.Errorf("Invalid prevailing wind %d", header.PrevailingWind)
+	}
+
+	gameRecord := &GameRecord{Header: header}
+	for lineNumber := 2; scanner.Scan(); lineNumber++ {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse line %d of game record", lineNumber)
+		}
+		gameRecord.Entries = append(gameRecord.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to read game record")
+	}
+	return gameRecord, nil
+}
+
+// String ...
+func (e *Entry) String() string {
+	who := "Game"
+	if e.Seat >= 0 && e.Seat < rules.Winds.GetSize() {
+		who = rules.GetWindName(e.Seat)
+	}
+	if e.Kind == EntryKindCommand {
+		return fmt.Sprintf("%s: %s", who, e.Command)
+	}
+
+	str := fmt.Sprintf("%s: %s", who, e.Event)
+	switch e.Event {
+	case EventTurnStarted:
+		str += fmt.Sprintf(" %d", e.Turn)
+	case EventMeldDeclared:
+		str += fmt.Sprintf(" %s %s", e.MeldType, e.Tiles)
+	case EventOutDeclared:
+		str += fmt.Sprintf(" %s %s", e.OutSource, e.Tile)
+		if e.Score != nil {
+			str += fmt.Sprintf(", score %d", *e.Score)
+		}
+	case EventHandUpdated:
+		str += " " + e.Tiles
+	default:
+		if e.Tile != "" {
+			str += " " + e.Tile
+		}
+	}
+	return str
+}
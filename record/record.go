@@ -0,0 +1,92 @@
+package record
+
+// FormatVersion is the version of the game record format. Records of other versions are rejected
+// by Read.
+const FormatVersion = 1
+
+// A game record is a text file in the JSON lines format. The first line is the Header, and each
+// subsequent line is an Entry, in the order the commands were received and the events occurred.
+// Tiles are written in the shorthand form accepted by shorthand.Parser, e.g. "3b".
+
+// Header is the first line of a game record.
+type Header struct {
+	Version  int    `json:"version"`
+	RuleName string `json:"ruleName"`
+	Seed     int64  `json:"seed"`
+	// NumPlayers is the number of seats in the game.
+	NumPlayers int `json:"numPlayers"`
+	// PrevailingWind is the wind ordinal of the round. Records without it are in the East round.
+	PrevailingWind int `json:"prevailingWind,omitempty"`
+	// NumHands is the number of hands dealt from the wall, starting from East. Seats without a
+	// dealt hand (e.g. the pseudo opponent in single player mode) start with an empty hand.
+	NumHands int `json:"numHands"`
+	// NumTilesPerHand is the number of tiles dealt to each hand, not including the additional tile
+	// dealt to East. Records without it deal 13 tiles.
+	NumTilesPerHand int `json:"numTilesPerHand,omitempty"`
+	// Wall is the shorthand form of the deck before the hands are dealt, from front to back.
+	Wall string `json:"wall"`
+	// DeadWallSize is the number of tiles at the back of the wall that are never drawn from the
+	// front.
+	DeadWallSize int `json:"deadWallSize,omitempty"`
+}
+
+// EntryKind is the kind of an Entry.
+type EntryKind = string
+
+const (
+	// EntryKindCommand is an entry of a command received from a seat.
+	EntryKindCommand EntryKind = "command"
+	// EntryKindEvent is an entry of an event emitted by the engine.
+	EntryKindEvent EntryKind = "event"
+)
+
+// EventName is the name of the type of a recorded event.
+type EventName = string
+
+// Names of the recorded events, each corresponding to the engine event of the same name.
+const (
+	EventGameStarted          EventName = "GameStarted"
+	EventHandUpdated          EventName = "HandUpdated"
+	EventTurnStarted          EventName = "TurnStarted"
+	EventTileDrawn            EventName = "TileDrawn"
+	EventKongReplacementDrawn EventName = "KongReplacementDrawn"
+	EventBonusTileMoved       EventName = "BonusTileMoved"
+	EventTileDiscarded        EventName = "TileDiscarded"
+	EventMeldDeclared         EventName = "MeldDeclared"
+	EventRiichiDeclared       EventName = "RiichiDeclared"
+	EventOutDeclared          EventName = "OutDeclared"
+	EventGameDrawn            EventName = "GameDrawn"
+)
+
+// Entry is a single command or event of a game record. Only the fields relevant to the command or
+// event are set.
+type Entry struct {
+	Kind EntryKind `json:"kind"`
+	// Seat is the wind ordinal of the seat, or -1 if the entry applies to the whole game.
+	Seat int `json:"seat"`
+	// Command is the command in the console syntax, e.g. "discard 3".
+	Command string `json:"command,omitempty"`
+	// Event is the name of the event.
+	Event EventName `json:"event,omitempty"`
+	// Tile is the tile drawn, discarded, moved or claimed.
+	Tile string `json:"tile,omitempty"`
+	// Tiles are the tiles of the hand or the meld group.
+	Tiles string `json:"tiles,omitempty"`
+	// FromBack is true if the drawn tile replaces a bonus tile.
+	FromBack bool `json:"fromBack,omitempty"`
+	// MeldType is the command type of the declared meld, e.g. "pong".
+	MeldType string `json:"meldType,omitempty"`
+	// DiscarderSeat is the seat that discarded the claimed tile of a meld.
+	DiscarderSeat *int `json:"discarderSeat,omitempty"`
+	Turn          int  `json:"turn,omitempty"`
+	// OutSource is the source type of the Out tile, e.g. "Discard".
+	OutSource string `json:"outSource,omitempty"`
+	// Score is the total score of the best scored Out plan, if scoring is reported.
+	Score *int `json:"score,omitempty"`
+}
+
+// GameRecord is a game record loaded by Read.
+type GameRecord struct {
+	Header  *Header
+	Entries []*Entry
+}
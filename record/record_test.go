@@ -0,0 +1,131 @@
+package record
+
+import (
+	"bytes"
+	"github.com/derekimcheng/mj/bot"
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+	// Registers the rule set used in tests.
+	_ "github.com/derekimcheng/mj/rules/hk"
+	"github.com/derekimcheng/mj/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// recordBotGame plays a game between random bots with the given seed and returns its record.
+func recordBotGame(t *testing.T, seed int64) (*bytes.Buffer, int) {
+	ruleSet, err := rules.GetRuleSet(flags.RuleNameHK)
+	require.NoError(t, err)
+
+	var receivers []ui.CommandReceiver
+	for seat := 0; seat < engine.NumPlayers; seat++ {
+		strategy, err := bot.NewStrategy(bot.LevelRandom, rand.New(rand.NewSource(seed+int64(seat))))
+		require.NoError(t, err)
+		receivers = append(receivers, bot.NewBot(rules.GetWindName(seat), strategy))
+	}
+	deck := rules.NewShuffledDeckForRuleSet(ruleSet, seed)
+	numWallTiles := deck.NumRemainingTiles()
+
+	var buffer bytes.Buffer
+	writer := NewWriter(&buffer, ruleSet, seed, engine.NumPlayers, deck.GetRemainingTiles())
+	runner := engine.NewMultiPlayerRunner(ruleSet, receivers)
+	runner.AddObserver(writer)
+	_, err = runner.Start(deck)
+	require.NoError(t, err)
+	require.NoError(t, writer.Err())
+	return &buffer, numWallTiles
+}
+
+func countTiles(state *GameState) int {
+	numTiles := state.NumRemainingTiles
+	if state.DiscardedTile != nil {
+		numTiles++
+	}
+	for _, player := range state.Players {
+		numTiles += len(player.GetHand().GetTiles()) + len(player.GetBonusTiles()) +
+			len(player.GetDiscardedTiles())
+		for _, group := range player.GetMeldGroups() {
+			numTiles += len(group.GetTiles())
+		}
+	}
+	return numTiles
+}
+
+func Test_WriteAndRead(t *testing.T) {
+	buffer, _ := recordBotGame(t, 1)
+
+	gameRecord, err := Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, gameRecord.Header.Version)
+	assert.Equal(t, flags.RuleNameHK, gameRecord.Header.RuleName)
+	assert.Equal(t, int64(1), gameRecord.Header.Seed)
+	assert.Equal(t, engine.NumPlayers, gameRecord.Header.NumPlayers)
+	require.NotEmpty(t, gameRecord.Entries)
+	assert.Equal(t, EventGameStarted, gameRecord.Entries[0].Event)
+
+	numCommands := 0
+	for _, entry := range gameRecord.Entries {
+		if entry.Kind == EntryKindCommand {
+			numCommands++
+			_, err := ui.ParseCommand(entry.Command)
+			assert.NoError(t, err)
+		}
+	}
+	assert.NotZero(t, numCommands)
+
+	lastEntry := gameRecord.Entries[len(gameRecord.Entries)-1]
+	assert.Contains(t, []string{EventOutDeclared, EventGameDrawn}, lastEntry.Event)
+}
+
+func Test_GetStateAt(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		buffer, numWallTiles := recordBotGame(t, seed)
+		gameRecord, err := Read(buffer)
+		require.NoError(t, err)
+
+		initialState, err := gameRecord.GetStateAt(0)
+		require.NoError(t, err)
+		require.Len(t, initialState.Players, engine.NumPlayers)
+		// East is dealt the extra tile.
+		assert.Len(t, initialState.Players[0].GetHand().GetTiles(), 14)
+		assert.Len(t, initialState.Players[1].GetHand().GetTiles(), 13)
+
+		// No tile is lost at any move.
+		for i := 0; i <= len(gameRecord.Entries); i++ {
+			state, err := gameRecord.GetStateAt(i)
+			require.NoError(t, err, "seed %d, entry %d", seed, i)
+			assert.Equal(t, numWallTiles, countTiles(state), "seed %d, entry %d", seed, i)
+		}
+	}
+}
+
+func Test_GetStateAt_OutOfRange(t *testing.T) {
+	buffer, _ := recordBotGame(t, 1)
+	gameRecord, err := Read(buffer)
+	require.NoError(t, err)
+
+	_, err = gameRecord.GetStateAt(-1)
+	assert.Error(t, err)
+	_, err = gameRecord.GetStateAt(len(gameRecord.Entries) + 1)
+	assert.Error(t, err)
+}
+
+func Test_Read_Errors(t *testing.T) {
+	_, err := Read(strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = Read(strings.NewReader(`{"version":999,"ruleName":"hk"}`))
+	assert.Error(t, err)
+
+	_, err = Read(strings.NewReader(
+		`{"version":1,"ruleName":"hk","numPlayers":4,"numHands":4,"wall":""}` + "\nnot json\n"))
+	assert.Error(t, err)
+
+	_, err = Read(strings.NewReader(
+		`{"version":1,"ruleName":"hk","numPlayers":4,"numHands":4,"prevailingWind":4,"wall":""}`))
+	assert.Error(t, err)
+}
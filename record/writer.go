@@ -0,0 +1,135 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/engine"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// Writer is a GameObserver that writes the game record of the observed game. The header is written
+// when the game starts.
+type Writer struct {
+	encoder   *json.Encoder
+	formatter *shorthand.Formatter
+	header    *Header
+	// err is the first error encountered while writing. Nothing is written after an error.
+	err error
+}
+
+// NewWriter returns a new Writer that writes to the given writer a game played with the given
+// RuleSet. The wall is the deck before the hands are dealt, including its dead wall, and numHands
+// is the number of hands dealt from it.
+func NewWriter(w io.Writer, ruleSet rules.RuleSet, seed int64, numHands int,
+	wall domain.Tiles) *Writer {
+	formatter := shorthand.NewFormatter()
+	return &Writer{
+		encoder:   json.NewEncoder(w),
+		formatter: formatter,
+		header: &Header{
+			Version:         FormatVersion,
+			RuleName:        ruleSet.GetName(),
+			Seed:            seed,
+			NumHands:        numHands,
+			NumTilesPerHand: ruleSet.GetNumTilesPerHand(),
+			Wall:            formatter.FormatTiles(wall),
+			DeadWallSize:    ruleSet.GetDeadWallSize(),
+		},
+	}
+}
+
+// Err returns the first error encountered while writing, if any.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// OnGameEvent ... (GameObserver implementation)
+func (w *Writer) OnGameEvent(event engine.GameEvent) {
+	if started, ok := event.(*engine.GameStartedEvent); ok {
+		w.header.NumPlayers = started.NumPlayers
+		w.header.PrevailingWind = started.PrevailingWindOrdinal
+		w.write(w.header)
+	}
+	if entry := w.newEntry(event); entry != nil {
+		w.write(entry)
+	}
+}
+
+func (w *Writer) write(value interface{}) {
+	if w.err != nil {
+		return
+	}
+	if err := w.encoder.Encode(value); err != nil {
+		glog.V(2).Infof("Failed to write game record: %s\n", err)
+		w.err = errors.Wrapf(err, "unable to write game record")
+	}
+}
+
+// newEntry converts the given event into an entry, or returns nil if the event is only shown to
+// the players and does not change the state of the game.
+func (w *Writer) newEntry(event engine.GameEvent) *Entry {
+	entry := &Entry{Kind: EntryKindEvent, Seat: event.GetSeat()}
+	switch e := event.(type) {
+	case *engine.CommandReceivedEvent:
+		entry.Kind = EntryKindCommand
+		entry.Command = e.Command.String()
+	case *engine.GameStartedEvent:
+		entry.Event = EventGameStarted
+	case *engine.HandUpdatedEvent:
+		entry.Event = EventHandUpdated
+		entry.Tiles = w.formatter.FormatTiles(e.Tiles)
+	case *engine.TurnStartedEvent:
+		entry.Event = EventTurnStarted
+		entry.Turn = e.Turn
+	case *engine.TileDrawnEvent:
+		entry.Event = EventTileDrawn
+		entry.Tile = w.formatTile(e.Tile)
+		entry.FromBack = e.FromBack
+	case *engine.KongReplacementDrawnEvent:
+		entry.Event = EventKongReplacementDrawn
+		entry.Tile = w.formatTile(e.Tile)
+	case *engine.BonusTileMovedEvent:
+		entry.Event = EventBonusTileMoved
+		entry.Tile = w.formatTile(e.Tile)
+	case *engine.TileDiscardedEvent:
+		entry.Event = EventTileDiscarded
+		entry.Tile = w.formatTile(e.Tile)
+	case *engine.MeldDeclaredEvent:
+		entry.Event = EventMeldDeclared
+		entry.Tile = w.formatTile(e.Tile)
+		entry.Tiles = w.formatter.FormatTiles(e.Tiles)
+		entry.MeldType = e.MeldType
+		if e.DiscarderSeat != engine.NoSeat {
+			discarderSeat := e.DiscarderSeat
+			entry.DiscarderSeat = &discarderSeat
+		}
+	case *engine.RiichiDeclaredEvent:
+		entry.Event = EventRiichiDeclared
+	case *engine.OutDeclaredEvent:
+		entry.Event = EventOutDeclared
+		entry.OutSource = e.OutTileSource.SourceType.String()
+		if e.OutTileSource.Tile != nil {
+			entry.Tile = w.formatTile(e.OutTileSource.Tile)
+		}
+		if len(e.ScoredPlans) > 0 {
+			score := e.ScoredPlans[0].TotalScore
+			entry.Score = &score
+		}
+	case *engine.GameDrawnEvent:
+		entry.Event = EventGameDrawn
+	case *engine.ClaimOfferedEvent, *engine.CommandRejectedEvent, *engine.InfoShownEvent:
+		return nil
+	default:
+		panic(fmt.Errorf("Unhandled event type %T", event))
+	}
+	return entry
+}
+
+func (w *Writer) formatTile(tile *domain.Tile) string {
+	return w.formatter.FormatTiles(domain.Tiles{tile})
+}
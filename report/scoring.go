@@ -0,0 +1,119 @@
+// Package report encodes scoring results as JSON, so that they can be consumed by other tools.
+// Tiles and groups are written in the shorthand form accepted by shorthand.Parser, e.g. "3b" or
+// "[111m]". The field names are stable.
+package report
+
+import (
+	"encoding/json"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"io"
+)
+
+// Pattern is the JSON encoding of rules.Pattern.
+type Pattern struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// ScoredOutPlan is the JSON encoding of rules.ScoredOutPlan.
+type ScoredOutPlan struct {
+	// HandGroups are the groups formed by the tiles in the hand, e.g. "[123b][55m]".
+	HandGroups string `json:"handGroups"`
+	// MeldedGroups are the groups in the melded area, e.g. "[111m]".
+	MeldedGroups string    `json:"meldedGroups,omitempty"`
+	TotalScore   int       `json:"totalScore"`
+	Patterns     []Pattern `json:"patterns"`
+}
+
+// ScoringContext is the JSON encoding of rules.OutPlanScoringContext.
+type ScoringContext struct {
+	// Hand is the hand and meld groups of the player, e.g. "123b55m [111m]".
+	Hand       string `json:"hand"`
+	BonusTiles string `json:"bonusTiles,omitempty"`
+	SeatWind   string `json:"seatWind"`
+	// PrevailingWind is the wind of the round.
+	PrevailingWind string `json:"prevailingWind"`
+	// OutSource is the source type of the Out tile, e.g. "Discard".
+	OutSource string `json:"outSource"`
+	// OutTile is not set if the source type is the initial hand.
+	OutTile string `json:"outTile,omitempty"`
+	// DiscarderWind is only set if the Out tile is a discard.
+	DiscarderWind     string `json:"discarderWind,omitempty"`
+	NumRemainingTiles int    `json:"numRemainingTiles"`
+}
+
+// ScoringResult is the JSON encoding of the scored Out plans of a player state, and the context
+// they are scored with.
+type ScoringResult struct {
+	Context *ScoringContext  `json:"context"`
+	Plans   []*ScoredOutPlan `json:"plans"`
+}
+
+// NewScoredOutPlan returns the JSON encoding of the given scored plan.
+func NewScoredOutPlan(plan *rules.ScoredOutPlan) *ScoredOutPlan {
+	formatter := shorthand.NewFormatter()
+	patterns := []Pattern{}
+	for _, pattern := range plan.Patterns {
+		patterns = append(patterns, Pattern{Name: pattern.Name, Score: pattern.Score})
+	}
+	return &ScoredOutPlan{
+		HandGroups:   formatter.FormatTileGroups(plan.Plan.GetHandGroups()),
+		MeldedGroups: formatter.FormatTileGroups(plan.Plan.GetMeldedGroups()),
+		TotalScore:   plan.TotalScore,
+		Patterns:     patterns,
+	}
+}
+
+// NewScoringContext returns the JSON encoding of the given scoring context.
+func NewScoringContext(context *rules.OutPlanScoringContext) *ScoringContext {
+	formatter := shorthand.NewFormatter()
+	player := context.PlayerGameState
+	outTileSource := context.OutTileSource
+	encoded := &ScoringContext{
+		Hand:              formatter.FormatPlayerGameState(player),
+		BonusTiles:        formatter.FormatTiles(player.GetBonusTiles()),
+		SeatWind:          rules.GetWindName(player.GetWindOrdinal()),
+		PrevailingWind:    rules.GetWindName(context.PrevailingWindOrdinal),
+		OutSource:         outTileSource.SourceType.String(),
+		NumRemainingTiles: context.NumRemainingTilesInDeck,
+	}
+	if outTileSource.Tile != nil {
+		encoded.OutTile = formatter.FormatTiles(domain.Tiles{outTileSource.Tile})
+	}
+	if outTileSource.DiscardInfo != nil {
+		encoded.DiscarderWind =
+			rules.GetWindName(outTileSource.DiscardInfo.DiscardPlayer.GetWindOrdinal())
+	}
+	return encoded
+}
+
+// NewScoringResult returns the JSON encoding of the given scored plans and the context they are
+// scored with.
+func NewScoringResult(plans rules.ScoredOutPlans,
+	context *rules.OutPlanScoringContext) *ScoringResult {
+	result := &ScoringResult{Context: NewScoringContext(context), Plans: []*ScoredOutPlan{}}
+	for _, plan := range plans {
+		result.Plans = append(result.Plans, NewScoredOutPlan(plan))
+	}
+	return result
+}
+
+// WriteJSON writes the given value as JSON to the given writer, followed by a newline, so that a
+// sequence of values can be read one per line.
+func WriteJSON(w io.Writer, value interface{}) error {
+	return json.NewEncoder(w).Encode(value)
+}
+
+// StateResult is the JSON encoding of the analysis of a player state by the analyzer.
+type StateResult struct {
+	// Line is the line number of the state in the state file, or 0 if there is no state file.
+	Line int `json:"line,omitempty"`
+	// State is the state in the single-line state notation, if it is given in it.
+	State    string `json:"state,omitempty"`
+	RuleName string `json:"ruleName"`
+	// Error is set if the state cannot be analyzed, in which case there is no scoring result.
+	Error string `json:"error,omitempty"`
+	*ScoringResult
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/derekimcheng/mj/shorthand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ScoringResult_JSON(t *testing.T) {
+	parser := shorthand.NewParser()
+	handGroups, err := parser.ParseMeldGroups("[123b][456b][789b]")
+	require.NoError(t, err)
+	pairTiles, err := parser.ParseTiles("11m")
+	require.NoError(t, err)
+	handGroups = append(handGroups, rules.NewTileGroup(pairTiles, rules.TileGroupTypePair))
+	meldedGroups, err := parser.ParseMeldGroups("[111d]")
+	require.NoError(t, err)
+	tiles, err := parser.ParseTiles("123456789b1m")
+	require.NoError(t, err)
+	outTiles, err := parser.ParseTiles("1m")
+	require.NoError(t, err)
+
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	player := rules.NewExistingPlayerGameState(hand, 1, nil, nil, meldedGroups)
+	discarder := rules.NewExistingPlayerGameState(domain.NewHand(), 0, nil, nil, nil)
+	outTileSource := rules.NewOutTileSource(rules.OutTileSourceTypeDiscard, outTiles[0],
+		rules.NewDiscardInfo(discarder))
+	context := rules.NewOutPlanScoringContext(outTileSource, player, 42, 1)
+	plans := rules.ScoredOutPlans{rules.NewScoredOutPlan(rules.NewOutPlan(handGroups, meldedGroups),
+		2, rules.Patterns{rules.NewPattern("A", 1), rules.NewPattern("B", 1)})}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteJSON(&buffer, NewScoringResult(plans, context)))
+	assert.Equal(t, `{"context":{"hand":"123456789b1m [111d]","seatWind":"South",`+
+		`"prevailingWind":"South","outSource":"Discard","outTile":"1m","discarderWind":"East",`+
+		`"numRemainingTiles":42},`+
+		`"plans":[{"handGroups":"[123b][456b][789b][11m]","meldedGroups":"[111d]",`+
+		`"totalScore":2,"patterns":[{"name":"A","score":1},{"name":"B","score":1}]}]}`+"\n",
+		buffer.String())
+}
+
+func Test_StateResult_JSON(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteJSON(&buffer,
+		&StateResult{Line: 3, State: "11111m source=ih", RuleName: "hk", Error: "Invalid"}))
+	assert.Equal(t,
+		`{"line":3,"state":"11111m source=ih","ruleName":"hk","error":"Invalid"}`+"\n",
+		buffer.String())
+}
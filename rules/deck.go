@@ -1,62 +1,135 @@
 package rules
 
 import (
-	"github.com/derekimcheng/mj/flags"
 	"fmt"
 	"github.com/derekimcheng/mj/domain"
-	"github.com/golang/glog"
+	"github.com/derekimcheng/mj/flags"
+	"math/rand"
 )
 
-// tileCountRule specifies which suits are available and the count of tiles in each suit.
-type tileCountRule struct {
-	suit  *domain.Suit
-	count int
+// numBreakDice is the number of dice rolled to determine where the wall is broken.
+const numBreakDice = 3
+
+// TileCountRule specifies a suit that is available in a game and the count of tiles of each value
+// in the suit.
+type TileCountRule struct {
+	Suit  *domain.Suit
+	Count int
+}
+
+// TileCountRules is a list of TileCountRule for a game (HK, ZJ, etc.).
+type TileCountRules []TileCountRule
+
+// TileCountRulesHK is the set of rules for Hong Kong MJ.
+var TileCountRulesHK = TileCountRules{
+	{Dots, 4}, {Bamboo, 4}, {Characters, 4}, {Winds, 4}, {Dragons, 4}, {Flowers, 1}, {Seasons, 1},
+}
+
+// TileCountRulesZJ is the set of rules for Zung Jung MJ.
+var TileCountRulesZJ = TileCountRules{
+	{Dots, 4}, {Bamboo, 4}, {Characters, 4}, {Winds, 4}, {Dragons, 4},
 }
 
-// tileCountRules is a list of TileCountRule for a game (HK, ZJ, etc.).
-type tileCountRules []tileCountRule
+// TileCountRulesMCR is the set of rules for Chinese Official MJ, which has one of each flower
+// and season.
+var TileCountRulesMCR = TileCountRules{
+	{Dots, 4}, {Bamboo, 4}, {Characters, 4}, {Winds, 4}, {Dragons, 4}, {Flowers, 1}, {Seasons, 1},
+}
 
-// tileCountRulesHK is the set of rules for Hong Kong MJ.
-var tileCountRulesHK = tileCountRules{
+// TileCountRulesTW is the set of rules for Taiwanese MJ, which has one of each flower and season.
+var TileCountRulesTW = TileCountRules{
 	{Dots, 4}, {Bamboo, 4}, {Characters, 4}, {Winds, 4}, {Dragons, 4}, {Flowers, 1}, {Seasons, 1},
 }
 
-// tileCountRulesZJ is the set of rules for Zung Jung MJ.
-var tileCountRulesZJ = tileCountRules{
+// TileCountRulesRiichi is the set of rules for Riichi MJ, which has no bonus tiles.
+var TileCountRulesRiichi = TileCountRules{
 	{Dots, 4}, {Bamboo, 4}, {Characters, 4}, {Winds, 4}, {Dragons, 4},
 }
 
-// tileCountRulesMap is a map from the string abbreviation of a MJ rule name to its set of rules.
-var tileCountRulesMap = map[flags.RuleName]tileCountRules{
-	flags.RuleNameHK: tileCountRulesHK,
-	flags.RuleNameZJ: tileCountRulesZJ,
+// TileVariantRule specifies the number of tiles of a given suit and ordinal that are dealt with
+// the given variant instead of the regular tile, e.g. a red five.
+type TileVariantRule struct {
+	Suit    *domain.Suit
+	Ordinal int
+	Variant domain.TileVariant
+	Count   int
+}
+
+// TileVariantRules is a list of TileVariantRule for a game.
+type TileVariantRules []TileVariantRule
+
+// redFiveOrdinal is the ordinal of the five, which may be a red five in some rules.
+const redFiveOrdinal = 4
+
+// TileVariantRulesRiichi is the set of variant rules for Riichi MJ, which has one red five in each
+// simple suit.
+var TileVariantRulesRiichi = TileVariantRules{
+	{Dots, redFiveOrdinal, domain.TileVariantRed, 1},
+	{Bamboo, redFiveOrdinal, domain.TileVariantRed, 1},
+	{Characters, redFiveOrdinal, domain.TileVariantRed, 1},
 }
 
 // NewDeckForGame creates an unshuffled Deck with tiles according for the given rule, or an error if
 // the given rule does not exist.
 func NewDeckForGame(ruleName flags.RuleName) (domain.Deck, error) {
-	rules, found := tileCountRulesMap[ruleName]
-	if !found {
-		glog.V(2).Infof("Rule %s not found\n", ruleName)
-		return nil, fmt.Errorf("Rule %s not found", ruleName)
+	ruleSet, err := GetRuleSet(ruleName)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeckForRuleSet(ruleSet), nil
+}
+
+// NewDeckForRuleSet creates an unshuffled and unbroken wall with tiles and the dead wall size
+// according to the given RuleSet.
+func NewDeckForRuleSet(ruleSet RuleSet) domain.Deck {
+	return newWallForRuleSet(ruleSet)
+}
+
+// NewShuffledDeckForRuleSet creates a wall with tiles and the dead wall size according to the
+// given RuleSet. The wall is shuffled, then broken with a roll of the dice, using a random source
+// initialized with the given seed. The same RuleSet and seed always result in the same tile order.
+func NewShuffledDeckForRuleSet(ruleSet RuleSet, seed int64) domain.Deck {
+	wall := newWallForRuleSet(ruleSet)
+	rng := rand.New(rand.NewSource(seed))
+	wall.Shuffle(rng)
+	if err := wall.Break(domain.RollDice(rng, numBreakDice)); err != nil {
+		panic(err)
 	}
+	return wall
+}
+
+func newWallForRuleSet(ruleSet RuleSet) *domain.Wall {
 	var tiles []*domain.Tile
-	for _, rule := range rules {
-		tiles = addTilesForSuit(rule, tiles)
+	for _, rule := range ruleSet.GetTileCountRules() {
+		tiles = addTilesForSuit(rule, ruleSet.GetTileVariantRules(), tiles)
 	}
-	return domain.NewDeck(tiles), nil
+	return domain.NewWall(tiles, ruleSet.GetDeadWallSize())
 }
 
-func addTilesForSuit(rule tileCountRule, tiles []*domain.Tile) []*domain.Tile {
-	for ordinal := 0; ordinal < rule.suit.GetSize(); ordinal++ {
-		for id := 0; id < rule.count; id++ {
-			tile, err := domain.NewTile(rule.suit, ordinal, id)
+func addTilesForSuit(rule TileCountRule, variantRules TileVariantRules,
+	tiles []*domain.Tile) []*domain.Tile {
+	for ordinal := 0; ordinal < rule.Suit.GetSize(); ordinal++ {
+		for id := 0; id < rule.Count; id++ {
+			variant := getTileVariant(variantRules, rule.Suit, ordinal, id)
+			tile, err := domain.NewTileWithVariant(rule.Suit, ordinal, id, variant)
 			if err != nil {
 				panic(fmt.Errorf("Unable to create tile: suit=%s ordinal=%d id=%d",
-					rule.suit.GetName(), ordinal, id))
+					rule.Suit.GetName(), ordinal, id))
 			}
 			tiles = append(tiles, tile)
 		}
 	}
 	return tiles
 }
+
+// getTileVariant returns the variant of the tile with the given suit, ordinal and ID according to
+// the given rules. The tiles with the lowest IDs take the variant.
+func getTileVariant(variantRules TileVariantRules, suit *domain.Suit, ordinal,
+	id int) domain.TileVariant {
+	for _, rule := range variantRules {
+		if rule.Suit == suit && rule.Ordinal == ordinal && id < rule.Count {
+			return rule.Variant
+		}
+	}
+	return domain.TileVariantNone
+}
@@ -9,9 +9,8 @@ import (
 )
 
 func Test_NewDeckForGame(t *testing.T) {
-	deck, err := NewDeckForGame(flags.RuleNameHK)
+	deck := NewDeckForRuleSet(newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK))
 	require.NotNil(t, deck)
-	assert.NoError(t, err)
 
 	// Build a map from friendly name to count
 	nonBonusCounts := make(map[string]int)
@@ -49,3 +48,60 @@ func Test_NewDeckForGame_UnknownRule(t *testing.T) {
 	assert.Nil(t, deck)
 	assert.Error(t, err)
 }
+
+func Test_NewShuffledDeckForRuleSet(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK)
+	deck1 := NewShuffledDeckForRuleSet(ruleSet, 1234)
+	deck2 := NewShuffledDeckForRuleSet(ruleSet, 1234)
+	deck3 := NewShuffledDeckForRuleSet(ruleSet, 5678)
+
+	numDifferent := 0
+	for !deck1.IsEmpty() {
+		tile1, _ := deck1.PopFront()
+		tile2, _ := deck2.PopFront()
+		tile3, _ := deck3.PopFront()
+		require.NotNil(t, tile1)
+		assert.Equal(t, tile1.String(), tile2.String())
+		assert.Equal(t, tile1.GetID(), tile2.GetID())
+		if tile1.String() != tile3.String() || tile1.GetID() != tile3.GetID() {
+			numDifferent++
+		}
+	}
+	assert.True(t, deck2.IsEmpty())
+	assert.NotZero(t, numDifferent, "Different seeds resulted in the same tile order")
+}
+
+func Test_NewShuffledDeckForRuleSet_DeadWall(t *testing.T) {
+	ruleSet := &BaseRuleSet{
+		Name:           flags.RuleNameZJ,
+		TileCountRules: TileCountRulesZJ,
+		DeadWallSize:   14,
+	}
+	deck := NewShuffledDeckForRuleSet(ruleSet, 1234)
+	assert.Equal(t, 136-14, deck.NumRemainingTiles())
+	assert.Len(t, deck.GetRemainingTiles(), 136)
+
+	// Each replacement tile reduces the live wall by one.
+	_, err := deck.PopBack()
+	require.NoError(t, err)
+	assert.Equal(t, 136-15, deck.NumRemainingTiles())
+}
+
+func Test_NewDeckForRuleSet_RedFives(t *testing.T) {
+	ruleSet := &BaseRuleSet{
+		Name:             flags.RuleNameRiichi,
+		TileCountRules:   TileCountRulesRiichi,
+		TileVariantRules: TileVariantRulesRiichi,
+	}
+	deck := NewDeckForRuleSet(ruleSet)
+
+	redCounts := make(map[*domain.Suit]int)
+	for !deck.IsEmpty() {
+		tile, _ := deck.PopFront()
+		if tile.IsRed() {
+			assert.Equal(t, redFiveOrdinal, tile.GetOrdinal())
+			redCounts[tile.GetSuit()]++
+		}
+	}
+	assert.Equal(t, map[*domain.Suit]int{Dots: 1, Bamboo: 1, Characters: 1}, redCounts)
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"sort"
+	"strings"
+)
+
+// DiscardAdvice is the evaluation of discarding a single kind of tile from the hand.
+type DiscardAdvice struct {
+	Tile domain.TileBase
+	// Index is the index of the first tile in the hand with the same suit and ordinal as Tile.
+	Index int
+	// Shanten is the shanten number of the hand after the discard.
+	Shanten int
+	// UsefulTiles are the tiles that would reduce the shanten number if drawn after the discard.
+	UsefulTiles []domain.TileBase
+	// NumUsefulTiles is the total number of unseen copies of UsefulTiles, a.k.a. ukeire.
+	NumUsefulTiles int
+	// EstimatedValue is the average score of the best self-drawn Out over all unseen winning
+	// tiles. It is only estimated if the discard results in a ready hand, and is 0 otherwise.
+	EstimatedValue float64
+}
+
+// String ...
+func (a *DiscardAdvice) String() string {
+	str := fmt.Sprintf("Discard %s (index %d): shanten %d, %d useful tiles",
+		a.Tile, a.Index, a.Shanten, a.NumUsefulTiles)
+	if a.Shanten == ShantenReady {
+		str += fmt.Sprintf(", estimated value %.1f", a.EstimatedValue)
+	}
+	return str
+}
+
+// DiscardAdvices is a slice of DiscardAdvice.
+type DiscardAdvices []*DiscardAdvice
+
+// Len ... (sort.Sort implementation)
+func (as DiscardAdvices) Len() int {
+	return len(as)
+}
+
+// Swap ... (sort.Sort implementation)
+func (as DiscardAdvices) Swap(i, j int) {
+	as[i], as[j] = as[j], as[i]
+}
+
+// Less ... (sort.Sort implementation)
+func (as DiscardAdvices) Less(i, j int) bool {
+	if shantenDiff := as[i].Shanten - as[j].Shanten; shantenDiff != 0 {
+		return shantenDiff < 0
+	}
+	if usefulDiff := as[i].NumUsefulTiles - as[j].NumUsefulTiles; usefulDiff != 0 {
+		return usefulDiff > 0
+	}
+	if as[i].EstimatedValue != as[j].EstimatedValue {
+		return as[i].EstimatedValue > as[j].EstimatedValue
+	}
+	return as[i].Index < as[j].Index
+}
+
+// String ...
+func (as DiscardAdvices) String() string {
+	var strs []string
+	for i, a := range as {
+		strs = append(strs, fmt.Sprintf("  %d. %s", i+1, a))
+	}
+	return strings.Join(strs, "\n")
+}
+
+// DiscardAdvisor evaluates each possible discard of a hand containing the extra tile (e.g. 14
+// tiles minus melds).
+type DiscardAdvisor struct {
+	ruleSet RuleSet
+	scorer  OutPlansScorer
+	player  *PlayerGameState
+	// opponents are used for counting visible tiles.
+	opponents               []*PlayerGameState
+	numRemainingTilesInDeck int
+	prevailingWindOrdinal   int
+}
+
+// NewDiscardAdvisor creates a new DiscardAdvisor for the given player. The scorer may be nil, in
+// which case values are not estimated. The number of remaining tiles and the prevailing wind are
+// used for scoring.
+func NewDiscardAdvisor(ruleSet RuleSet, scorer OutPlansScorer, player *PlayerGameState,
+	opponents []*PlayerGameState, numRemainingTilesInDeck int,
+	prevailingWindOrdinal int) *DiscardAdvisor {
+	return &DiscardAdvisor{
+		ruleSet:                 ruleSet,
+		scorer:                  scorer,
+		player:                  player,
+		opponents:               opponents,
+		numRemainingTilesInDeck: numRemainingTilesInDeck,
+		prevailingWindOrdinal:   prevailingWindOrdinal,
+	}
+}
+
+// Advise returns the advice for discarding each distinct tile in the hand, best discard first.
+// It is an error to call this method if the hand does not contain the extra tile.
+func (a *DiscardAdvisor) Advise() DiscardAdvices {
+	handTiles := a.player.GetHand().GetTiles()
+	if len(handTiles)%3 != 2 {
+		panic(fmt.Errorf("Invalid number of tiles in hand for discarding: %d", len(handTiles)))
+	}
+	unseenCounts := countUnseenTiles(a.ruleSet, a.player, a.opponents)
+
+	var advices DiscardAdvices
+	considered := make(map[domain.TileBase]bool)
+	for index, tile := range handTiles {
+		if considered[tile.TileBase] {
+			continue
+		}
+		considered[tile.TileBase] = true
+
+		var remainingTiles domain.Tiles
+		remainingTiles = append(remainingTiles, handTiles[:index]...)
+		remainingTiles = append(remainingTiles, handTiles[index+1:]...)
+		advices = append(advices, a.evaluateDiscard(tile, index, remainingTiles, unseenCounts))
+	}
+
+	sort.Sort(advices)
+	return advices
+}
+
+func (a *DiscardAdvisor) evaluateDiscard(tile *domain.Tile, index int, remainingTiles domain.Tiles,
+	unseenCounts map[domain.TileBase]int) *DiscardAdvice {
+	options := a.ruleSet.GetOutPlanCalculatorOptions()
+	numMeldedGroups := len(a.player.GetMeldGroups())
+	shanten := newShantenCalculatorForTiles(a.ruleSet.GetSuits(), remainingTiles, numMeldedGroups,
+		options).Calculate()
+	advice := &DiscardAdvice{Tile: tile.TileBase, Index: index, Shanten: shanten}
+
+	for _, suit := range a.ruleSet.GetSuits() {
+		if !IsEligibleForHand(suit) {
+			continue
+		}
+		for ordinal := 0; ordinal < suit.GetSize(); ordinal++ {
+			tileBase := domain.NewTileBase(suit, ordinal)
+			if unseenCounts[tileBase] == 0 {
+				continue
+			}
+			drawnTile, err := domain.NewTile(suit, ordinal, waitTileID)
+			if err != nil {
+				panic(err)
+			}
+			tilesWithDraw := append(append(domain.Tiles{}, remainingTiles...), drawnTile)
+			newShanten := newShantenCalculatorForTiles(a.ruleSet.GetSuits(), tilesWithDraw,
+				numMeldedGroups, options).Calculate()
+			if newShanten < shanten {
+				advice.UsefulTiles = append(advice.UsefulTiles, tileBase)
+				advice.NumUsefulTiles += unseenCounts[tileBase]
+			}
+		}
+	}
+
+	if shanten == ShantenReady && a.scorer != nil {
+		advice.EstimatedValue = a.estimateValue(tile, remainingTiles)
+	}
+	return advice
+}
+
+// estimateValue returns the average score of the best self-drawn Out of the given ready hand,
+// weighted by the number of unseen copies of each winning tile.
+func (a *DiscardAdvisor) estimateValue(discardedTile *domain.Tile,
+	remainingTiles domain.Tiles) float64 {
+	player := a.player.copyWithHandTiles(remainingTiles)
+	// The discarded tile remains visible to the player.
+	player.discardedTiles = append(
+		append(domain.Tiles{}, a.player.GetDiscardedTiles()...), discardedTile)
+	waits := NewWaitCalculator(a.ruleSet, a.scorer, player, a.opponents,
+		a.numRemainingTilesInDeck, a.prevailingWindOrdinal).Calculate()
+	numUnseenTiles := waits.NumUnseenTiles()
+	if numUnseenTiles == 0 {
+		return 0
+	}
+	totalScore := 0
+	for _, wait := range waits {
+		if wait.BestSelfDrawnOut != nil {
+			totalScore += wait.NumUnseenTiles * wait.BestSelfDrawnOut.TotalScore
+		}
+	}
+	return float64(totalScore) / float64(numUnseenTiles)
+}
@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_DiscardAdvisor(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 0, 0, 1, 2),
+		createSuitTilesForTest(t, Characters, 8)), nil)
+
+	advices := NewDiscardAdvisor(ruleSet, &sourceTypeScorer{}, player, nil, 20, 0).Advise()
+	// One advice per distinct tile.
+	require.Len(t, advices, 13)
+
+	best := advices[0]
+	assert.Equal(t, domain.NewTileBase(Characters, 8), best.Tile)
+	assert.Equal(t, 13, best.Index)
+	assert.Equal(t, ShantenReady, best.Shanten)
+	assert.Equal(t, []domain.TileBase{
+		domain.NewTileBase(Bamboo, 0), domain.NewTileBase(Bamboo, 3)}, best.UsefulTiles)
+	// 2 copies of Bamboo 0 and 4 copies of Bamboo 3 are unseen.
+	assert.Equal(t, 6, best.NumUsefulTiles)
+	assert.Equal(t, 2.0, best.EstimatedValue)
+
+	// Discarding Bamboo 0 leaves a ready hand waiting on Characters 8, with fewer useful tiles.
+	assert.Equal(t, domain.NewTileBase(Bamboo, 0), advices[1].Tile)
+	assert.Equal(t, ShantenReady, advices[1].Shanten)
+	assert.Equal(t, 3, advices[1].NumUsefulTiles)
+
+	for _, advice := range advices[2:] {
+		assert.True(t, advice.Shanten > ShantenReady, "%s", advice)
+		assert.Zero(t, advice.EstimatedValue)
+	}
+
+	// The player's hand is left untouched.
+	assert.Equal(t, 14, player.GetHand().NumTiles())
+}
+
+func Test_DiscardAdvisor_PrefersMoreUsefulTiles(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	// Discarding either Bamboo 0 or Bamboo 8 leaves a one-shanten hand, but keeping the Bamboo 0
+	// pair accepts fewer tiles than keeping the open-ended Bamboo 7-8 shape.
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5),
+		createSuitTilesForTest(t, Characters, 0, 0, 4, 5),
+		createSuitTilesForTest(t, Bamboo, 0, 3, 4, 8)), nil)
+
+	advices := NewDiscardAdvisor(ruleSet, nil, player, nil, 20, 0).Advise()
+	require.NotEmpty(t, advices)
+	for i := 1; i < len(advices); i++ {
+		assert.False(t, advices.Less(i, i-1), "advices not sorted at %d", i)
+	}
+	assert.Equal(t, 1, advices[0].Shanten)
+	assert.Equal(t, domain.NewTileBase(Bamboo, 0), advices[0].Tile)
+}
+
+func Test_DiscardAdvisor_InvalidHand(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	player := createPlayerForTest(createSuitTilesForTest(t, Dots, 0, 1, 2, 3), nil)
+
+	assert.Panics(t, func() {
+		NewDiscardAdvisor(ruleSet, nil, player, nil, 20, 0).Advise()
+	})
+}
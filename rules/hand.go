@@ -7,18 +7,21 @@ import (
 )
 
 const (
-	numTilesPerHand        = 13
-	numTilesPerInitialDraw = 4
+	// standardNumTilesPerHand is the number of tiles dealt to each player in most rule sets, which
+	// form four sets and a pair with the additional tile.
+	standardNumTilesPerHand = 13
+	numTilesPerInitialDraw  = 4
 )
 
-// PopulateHands populates the given hands with the given deck. Returns nil on success, or error
-// if an error occurred.
-func PopulateHands(d domain.Deck, hands []*domain.Hand) error {
+// PopulateHands populates the given hands with the given deck, dealing the given number of tiles
+// to each hand and one more to the first hand. Returns nil on success, or error if an error
+// occurred.
+func PopulateHands(d domain.Deck, hands []*domain.Hand, numTilesPerHand int) error {
 	if len(hands) == 0 {
 		return errors.New("Must specify at least one hand")
 	}
 
-	numRequiredTiles := numTilesPerHand*numTilesPerInitialDraw + 1
+	numRequiredTiles := numTilesPerHand*len(hands) + 1
 	if d.NumRemainingTiles() < numRequiredTiles {
 		return fmt.Errorf("Not enough remaining tiles in deck, have %d, need %d",
 			d.NumRemainingTiles(), numRequiredTiles)
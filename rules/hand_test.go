@@ -9,22 +9,37 @@ import (
 )
 
 func Test_PopulateHands(t *testing.T) {
-	deck, err := NewDeckForGame(flags.RuleNameHK)
+	deck := NewDeckForRuleSet(newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK))
 	require.NotNil(t, deck)
-	assert.NoError(t, err)
 
 	var hands []*domain.Hand
 	numHands := 4
 	for x := 0; x < numHands; x++ {
 		hands = append(hands, domain.NewHand())
 	}
-	PopulateHands(deck, hands)
+	require.NoError(t, PopulateHands(deck, hands, standardNumTilesPerHand))
 
 	for i, h := range hands {
 		if i == 0 {
-			assert.Equal(t, numTilesPerHand+1, h.NumTiles())
+			assert.Equal(t, standardNumTilesPerHand+1, h.NumTiles())
 		} else {
-			assert.Equal(t, numTilesPerHand, h.NumTiles())
+			assert.Equal(t, standardNumTilesPerHand, h.NumTiles())
 		}
 	}
 }
+
+func Test_PopulateHands_SixteenTiles(t *testing.T) {
+	deck := NewDeckForRuleSet(newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK))
+
+	var hands []*domain.Hand
+	for x := 0; x < 4; x++ {
+		hands = append(hands, domain.NewHand())
+	}
+	require.NoError(t, PopulateHands(deck, hands, 16))
+
+	assert.Equal(t, 17, hands[0].NumTiles())
+	for _, h := range hands[1:] {
+		assert.Equal(t, 16, h.NumTiles())
+	}
+	assert.Equal(t, 144-4*16-1, deck.NumRemainingTiles())
+}
@@ -0,0 +1,72 @@
+package hk
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// numTilesPerBonusSuit is the number of tiles in a full set of Flowers or Seasons.
+const numTilesPerBonusSuit = 4
+
+// No Flowers (無花) : 1
+// Own Flower / Own Season (正花) : 1 per tile
+// Full Set of Flowers / Seasons (一台花) : 2 per set
+func bonusTiles(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	bonusTiles := context.PlayerGameState.GetBonusTiles()
+	if len(bonusTiles) == 0 {
+		return []*rules.Pattern{rules.NewPattern("無花", 1)}
+	}
+
+	countsBySuit := countBonusTilesBySuit(bonusTiles)
+	numOwnTiles := 0
+	numFullSets := 0
+	for suit, count := range countsBySuit {
+		if count == numTilesPerBonusSuit {
+			// A full set supersedes the own tile of the same suit.
+			numFullSets++
+			continue
+		}
+		for _, tile := range bonusTiles {
+			if tile.GetSuit() == suit &&
+				tile.GetOrdinal() == context.PlayerGameState.GetWindOrdinal() {
+				numOwnTiles++
+			}
+		}
+	}
+
+	var patterns []*rules.Pattern
+	if numOwnTiles > 0 {
+		patterns = append(patterns,
+			rules.NewPattern(fmt.Sprintf("正花 (%d)", numOwnTiles), numOwnTiles))
+	}
+	if numFullSets > 0 {
+		patterns = append(patterns,
+			rules.NewPattern(fmt.Sprintf("一台花 (%d)", numFullSets), 2*numFullSets))
+	}
+	return patterns
+}
+
+// Limit: All Flowers and Seasons (八仙過海)
+func allBonusTiles(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	countsBySuit := countBonusTilesBySuit(context.PlayerGameState.GetBonusTiles())
+	if countsBySuit[rules.Flowers] == numTilesPerBonusSuit &&
+		countsBySuit[rules.Seasons] == numTilesPerBonusSuit {
+		return []*rules.Pattern{rules.NewPattern("八仙過海", limitScore)}
+	}
+	return nil
+}
+
+// countBonusTilesBySuit returns a map from bonus suit to the number of distinct tiles of the suit.
+func countBonusTilesBySuit(tiles domain.Tiles) map[*domain.Suit]int {
+	seen := make(map[domain.TileBase]bool)
+	counts := make(map[*domain.Suit]int)
+	for _, tile := range tiles {
+		if seen[tile.TileBase] {
+			continue
+		}
+		seen[tile.TileBase] = true
+		counts[tile.GetSuit()]++
+	}
+	return counts
+}
@@ -0,0 +1,104 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/rules"
+	"sort"
+)
+
+const (
+	// limitScore is the maximum number of faan an Out can be worth. Limit hands are worth
+	// exactly this many faan.
+	limitScore = 13
+)
+
+// OutPlansScorer is an implementation of rules.OutPlansScorer based on Hong Kong (faan) rules.
+// The implementation assumes each plan contains a valid combination of tiles. Any invalid
+// combination may result in incorrect scoring.
+type OutPlansScorer struct{}
+
+// NewOutPlansScorer creates a new OutPlansScorer.
+func NewOutPlansScorer() *OutPlansScorer {
+	return &OutPlansScorer{}
+}
+
+// ScoreOutPlans ... (rules.OutPlansScorer implementation)
+func (s *OutPlansScorer) ScoreOutPlans(plans rules.OutPlans,
+	context *rules.OutPlanScoringContext) rules.ScoredOutPlans {
+	var scoredPlans rules.ScoredOutPlans
+	for _, plan := range plans {
+		scoredPlans = append(scoredPlans, s.scoreOutPlan(plan, context))
+	}
+
+	sort.Sort(scoredPlans)
+	return scoredPlans
+}
+
+func (s *OutPlansScorer) scoreOutPlan(plan rules.OutPlan, context *rules.OutPlanScoringContext) *rules.ScoredOutPlan {
+	// A limit hand is worth the limit regardless of any other pattern in the plan.
+	var limitPatterns rules.Patterns
+	for _, matchPattern := range limitHandFuncList {
+		limitPatterns = append(limitPatterns, matchPattern(plan, context)...)
+	}
+	if len(limitPatterns) > 0 {
+		sort.Sort(limitPatterns)
+		return rules.NewScoredOutPlan(plan, limitScore, limitPatterns)
+	}
+
+	var patterns rules.Patterns
+	for _, matchPattern := range matchPatternFuncList {
+		patterns = append(patterns, matchPattern(plan, context)...)
+	}
+
+	if len(patterns) == 0 {
+		patterns = append(patterns, rules.NewPattern("雞糊", 0))
+	}
+
+	sort.Sort(patterns)
+	totalScore := 0
+	for _, pattern := range patterns {
+		totalScore += pattern.Score
+	}
+	if totalScore > limitScore {
+		totalScore = limitScore
+	}
+
+	return rules.NewScoredOutPlan(plan, totalScore, patterns)
+}
+
+type matchPatternFunc func(rules.OutPlan, *rules.OutPlanScoringContext) []*rules.Pattern
+
+var matchPatternFuncList = []matchPatternFunc{
+	// Trivial patterns
+	commonHand,
+	concealedHand,
+	selfDrawn,
+	// One-suit patterns
+	oneSuit,
+	// Honor patterns
+	valueHonor,
+	threeDragons,
+	fourWinds,
+	// Triplets
+	allTriplets,
+	// Irregular hands
+	sevenPairs,
+	// Bonus tiles
+	bonusTiles,
+	// Incidental bonuses
+	finalDraw,
+	winOnKong,
+}
+
+// limitHandFuncList contains the limit hands (滿貫). Each matched limit hand is worth exactly
+// limitScore faan.
+var limitHandFuncList = []matchPatternFunc{
+	thirteenOrphans,
+	nineGates,
+	allHonors,
+	allTerminals,
+	fourConcealedTriplets,
+	fourKongs,
+	bigFourWinds,
+	winOnInitialRound,
+	allBonusTiles,
+}
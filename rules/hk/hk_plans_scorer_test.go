@@ -0,0 +1,117 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ScoreOutPlans_CommonHandSelfDrawn(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dots, 0, 1, 2),
+		domain.CreateTilesForTest(t, rules.Bamboo, 3, 4, 5),
+		domain.CreateTilesForTest(t, rules.Characters, 6, 7, 8, 1, 2, 3),
+		domain.CreateTilesForTest(t, rules.Bamboo, 8, 8),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+	player.AddTileToBonusArea(domain.CreateTileForTest(t, rules.Flowers, 1))
+	outTileSource := rules.NewOutTileSource(
+		rules.OutTileSourceTypeSelfDrawn, hand.GetTiles()[0], nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	// 平糊 + 門前清 + 自摸 + 正花
+	assert.Equal(t, 4, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"平糊", "門前清", "自摸", "正花 (1)"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_ChickenHand(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dots, 1, 2),
+		domain.CreateTilesForTest(t, rules.Bamboo, 4, 4, 4),
+		domain.CreateTilesForTest(t, rules.Characters, 6, 7, 8),
+		domain.CreateTilesForTest(t, rules.Bamboo, 8, 8),
+	))
+	meldTiles := domain.CreateTilesForTest(t, rules.Dots, 4, 5, 6)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypeChow)}
+	bonusTiles := domain.Tiles{domain.CreateTileForTest(t, rules.Seasons, 0)}
+	player := rules.NewExistingPlayerGameState(hand, 2, bonusTiles, nil, meldGroups)
+	discardInfo := rules.NewDiscardInfo(rules.NewExistingPlayerGameState(
+		domain.NewHand(), 1, nil, domain.Tiles{nil}, nil))
+	outTileSource := rules.NewOutTileSource(rules.OutTileSourceTypeDiscard,
+		domain.CreateTileForTest(t, rules.Dots, 0), discardInfo)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	assert.Equal(t, 0, scoredPlans[0].TotalScore)
+	assert.Equal(t, []string{"雞糊"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_FourConcealedTripletsIsLimit(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.CreateTilesForTest(t, rules.Dots,
+		1, 1, 1, 3, 3, 3, 5, 5, 5, 7, 7, 7, 8, 8))
+	player := rules.NewPlayerGameState(hand, 0)
+	outTileSource := rules.NewOutTileSource(
+		rules.OutTileSourceTypeSelfDrawn, hand.GetTiles()[0], nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	// Four concealed triplets is a limit hand.
+	assert.Equal(t, limitScore, scoredPlans[0].TotalScore)
+	assert.Equal(t, []string{"四暗刻"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_ThirteenOrphans(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dots, 0, 8),
+		domain.CreateTilesForTest(t, rules.Bamboo, 0, 8),
+		domain.CreateTilesForTest(t, rules.Characters, 0, 8),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 1, 2, 3),
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 1, 2, 2),
+	))
+	player := rules.NewPlayerGameState(hand, 3)
+	outTileSource := rules.NewOutTileSource(
+		rules.OutTileSourceTypeSelfDrawn, hand.GetTiles()[0], nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	assert.Equal(t, limitScore, scoredPlans[0].TotalScore)
+	assert.Equal(t, []string{"十三么"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_PrevailingWind(t *testing.T) {
+	player := createWindPongPlayerForTest(t, 2)
+
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Bamboo, 8), 2)
+	assert.Equal(t, 3, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"門前清", "無花", "圈風"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_DoubleWind(t *testing.T) {
+	player := createWindPongPlayerForTest(t, 1)
+
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Bamboo, 8), 1)
+	// The South wind is both the Seat Wind and the Prevailing Wind.
+	assert.Equal(t, 4, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"門前清", "無花", "門風", "圈風"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+// createWindPongPlayerForTest returns South with a concealed pong of the given wind, waiting on
+// the 9 of Bamboo.
+func createWindPongPlayerForTest(t *testing.T, windOrdinal int) *rules.PlayerGameState {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Winds, windOrdinal, windOrdinal, windOrdinal),
+		domain.CreateTilesForTest(t, rules.Dots, 1, 2, 3),
+		domain.CreateTilesForTest(t, rules.Bamboo, 3, 4, 5),
+		domain.CreateTilesForTest(t, rules.Characters, 6, 7, 8),
+		domain.CreateTilesForTest(t, rules.Bamboo, 8),
+	))
+	return rules.NewPlayerGameState(hand, 1)
+}
@@ -0,0 +1,101 @@
+package hk
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Dragon Pong (三元牌) : 1 per set
+// Seat Wind (門風) : 1
+// Prevailing Wind (圈風) : 1
+//
+// A set of a wind that is both the Seat Wind and the Prevailing Wind scores both.
+func valueHonor(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numDragons := 0
+	hasSeatWind := false
+	hasPrevailingWind := false
+	for _, group := range getAllGroups(plan) {
+		if !group.IsKanType() {
+			continue
+		}
+		firstTile := group.GetTiles()[0]
+		suit := firstTile.GetSuit()
+		if suit.GetSuitType() != domain.SuitTypeHonor {
+			continue
+		}
+		if !rules.IsWindSuit(suit) {
+			numDragons++
+			continue
+		}
+		if firstTile.GetOrdinal() == context.PlayerGameState.GetWindOrdinal() {
+			hasSeatWind = true
+		}
+		if firstTile.GetOrdinal() == context.PrevailingWindOrdinal {
+			hasPrevailingWind = true
+		}
+	}
+
+	var patterns []*rules.Pattern
+	if numDragons > 0 {
+		patterns = append(patterns,
+			rules.NewPattern(fmt.Sprintf("三元牌 (%d)", numDragons), numDragons))
+	}
+	if hasSeatWind {
+		patterns = append(patterns, rules.NewPattern("門風", 1))
+	}
+	if hasPrevailingWind {
+		patterns = append(patterns, rules.NewPattern("圈風", 1))
+	}
+	return patterns
+}
+
+// Small Three Dragons (小三元) : 5
+// Great Three Dragons (大三元) : 8
+func threeDragons(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numKans, numPairs := countHonorSets(plan, false)
+	if numKans == 2 && numPairs == 1 {
+		return []*rules.Pattern{rules.NewPattern("小三元", 5)}
+	} else if numKans == 3 {
+		return []*rules.Pattern{rules.NewPattern("大三元", 8)}
+	}
+	return nil
+}
+
+// Small Four Winds (小四喜) : 6
+// Big Four Winds is a limit hand and is handled by bigFourWinds().
+func fourWinds(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numKans, numPairs := countHonorSets(plan, true)
+	if numKans == 3 && numPairs == 1 {
+		return []*rules.Pattern{rules.NewPattern("小四喜", 6)}
+	}
+	return nil
+}
+
+// Limit: Big Four Winds (大四喜)
+func bigFourWinds(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numKans, _ := countHonorSets(plan, true)
+	if numKans == 4 {
+		return []*rules.Pattern{rules.NewPattern("大四喜", limitScore)}
+	}
+	return nil
+}
+
+// countHonorSets returns the number of kan groups and pair groups of either the Wind suit (if
+// winds is true) or the Dragon suit (otherwise).
+func countHonorSets(plan rules.OutPlan, winds bool) (int, int) {
+	numKans := 0
+	numPairs := 0
+	for _, group := range getAllGroups(plan) {
+		suit := group.GetTiles()[0].GetSuit()
+		if suit.GetSuitType() != domain.SuitTypeHonor || rules.IsWindSuit(suit) != winds {
+			continue
+		}
+		if group.IsKanType() {
+			numKans++
+		} else if group.GetGroupType() == rules.TileGroupTypePair {
+			numPairs++
+		}
+	}
+	return numKans, numPairs
+}
@@ -0,0 +1,41 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Final Draw (海底撈月) : 1
+func finalDraw(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	if context.NumRemainingTilesInDeck > 0 ||
+		!rules.IsSelfDrawnType(context.OutTileSource.SourceType) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("海底撈月", 1)}
+}
+
+// Win on Kong (槓上開花) : 1
+// Robbing a Kong (搶槓) : 1
+func winOnKong(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	switch context.OutTileSource.SourceType {
+	case rules.OutTileSourceTypeSelfDrawnReplacement:
+		return []*rules.Pattern{rules.NewPattern("槓上開花", 1)}
+	case rules.OutTileSourceTypeAdditionalKong:
+		return []*rules.Pattern{rules.NewPattern("搶槓", 1)}
+	}
+	return nil
+}
+
+// Limit: Heavenly Hand (天糊)
+// Limit: Earthly Hand (地糊)
+func winOnInitialRound(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	if context.OutTileSource.SourceType == rules.OutTileSourceTypeInitialHand {
+		return []*rules.Pattern{rules.NewPattern("天糊", limitScore)}
+	}
+	if context.OutTileSource.SourceType == rules.OutTileSourceTypeDiscard {
+		discardPlayer := context.OutTileSource.DiscardInfo.DiscardPlayer
+		if discardPlayer.GetWindOrdinal() == 0 && len(discardPlayer.GetDiscardedTiles()) == 0 {
+			return []*rules.Pattern{rules.NewPattern("地糊", limitScore)}
+		}
+	}
+	return nil
+}
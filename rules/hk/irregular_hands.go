@@ -0,0 +1,24 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Seven Pairs (七對子) : 4
+func sevenPairs(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	handGroups := plan.GetHandGroups()
+	if len(handGroups) != 1 || handGroups[0].GetGroupType() != rules.TileGroupTypeSevenPairs {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("七對子", 4)}
+}
+
+// Limit: Thirteen Orphans (十三么)
+func thirteenOrphans(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	handGroups := plan.GetHandGroups()
+	if len(handGroups) != 1 ||
+		handGroups[0].GetGroupType() != rules.TileGroupTypeThirteenOrphans {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("十三么", limitScore)}
+}
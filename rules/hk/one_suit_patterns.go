@@ -0,0 +1,95 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Mixed One-Suit (混一色) : 3
+// Pure One-Suit (清一色) : 7
+func oneSuit(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	allGroups := getAllGroups(plan)
+	for _, group := range allGroups {
+		if group.GetGroupType() == rules.TileGroupTypeThirteenOrphans {
+			return nil
+		}
+	}
+	suitCount, hasHonorTiles := countSimpleSuits(getTilesToCheck(allGroups))
+	if suitCount != oneSimpleSuit {
+		// All Honors is a limit hand and is handled by allHonors().
+		return nil
+	}
+	if hasHonorTiles {
+		return []*rules.Pattern{rules.NewPattern("混一色", 3)}
+	}
+	return []*rules.Pattern{rules.NewPattern("清一色", 7)}
+}
+
+// Limit: All Honors (字一色)
+func allHonors(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	allGroups := getAllGroups(plan)
+	for _, group := range allGroups {
+		if group.GetGroupType() == rules.TileGroupTypeThirteenOrphans {
+			return nil
+		}
+	}
+	suitCount, hasHonorTiles := countSimpleSuits(getTilesToCheck(allGroups))
+	if suitCount != noSimpleSuits || !hasHonorTiles {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("字一色", limitScore)}
+}
+
+// Limit: Nine Gates (九子連環)
+func nineGates(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	if len(plan.GetMeldedGroups()) > 0 || context.OutTileSource.Tile == nil {
+		return nil
+	}
+	handGroups := plan.GetHandGroups()
+	for _, group := range handGroups {
+		if isSpecialGroup(group) {
+			return nil
+		}
+	}
+	suitCount, hasHonorTiles := countSimpleSuits(getTilesToCheck(handGroups))
+	if hasHonorTiles || suitCount != oneSimpleSuit {
+		return nil
+	}
+	// The hand before the out tile must be 1112345678999 of one suit.
+	suitSize := handGroups[0].GetTiles()[0].GetSuit().GetSize()
+	counts := make([]int, suitSize)
+	for _, group := range handGroups {
+		for _, tile := range group.GetTiles() {
+			counts[tile.GetOrdinal()]++
+		}
+	}
+	counts[context.OutTileSource.Tile.GetOrdinal()]--
+
+	for i, count := range counts {
+		expectedCount := 1
+		if i == 0 || i == suitSize-1 {
+			expectedCount = 3
+		}
+		if count != expectedCount {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("九子連環", limitScore)}
+}
+
+// Limit: All Terminals (清么九)
+func allTerminals(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	allGroups := getAllGroups(plan)
+	for _, group := range allGroups {
+		if group.GetGroupType() == rules.TileGroupTypeChow ||
+			group.GetGroupType() == rules.TileGroupTypeThirteenOrphans {
+			return nil
+		}
+	}
+	for _, tile := range getTilesToCheck(allGroups) {
+		if tile.GetSuit().GetSuitType() != domain.SuitTypeSimple || !tile.IsTerminal() {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("清么九", limitScore)}
+}
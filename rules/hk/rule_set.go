@@ -0,0 +1,25 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+)
+
+func init() {
+	rules.RegisterRuleSet(NewRuleSet())
+}
+
+// NewRuleSet returns the rules.RuleSet for Hong Kong MJ.
+func NewRuleSet() rules.RuleSet {
+	return &rules.BaseRuleSet{
+		Name:            flags.RuleNameHK,
+		TileCountRules:  rules.TileCountRulesHK,
+		NumTilesPerHand: 13,
+		SpecialHands: []rules.TileGroupType{
+			rules.TileGroupTypeSevenPairs,
+			rules.TileGroupTypeThirteenOrphans,
+		},
+		Scorer:           NewOutPlansScorer(),
+		AllowRobbingKong: true,
+	}
+}
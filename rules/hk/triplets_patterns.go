@@ -0,0 +1,59 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// All Triplets (對對糊) : 3
+func allTriplets(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	for _, group := range getAllGroups(plan) {
+		if !group.IsKanType() && group.GetGroupType() != rules.TileGroupTypePair {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("對對糊", 3)}
+}
+
+// Limit: Four Concealed Triplets (四暗刻)
+func fourConcealedTriplets(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numConcealedTriplets := 0
+	for _, group := range plan.GetMeldedGroups() {
+		if group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+			numConcealedTriplets++
+		}
+	}
+	var outTileBase *domain.TileBase
+	if rules.IsExternalOutSourceType(context.OutTileSource.SourceType) {
+		outTileBase = &context.OutTileSource.Tile.TileBase
+	}
+	for _, group := range plan.GetHandGroups() {
+		if group.GetGroupType() != rules.TileGroupTypePong {
+			continue
+		}
+		// A triplet completed by another player's tile is considered exposed.
+		if outTileBase != nil && group.GetTiles()[0].TileBase == *outTileBase {
+			continue
+		}
+		numConcealedTriplets++
+	}
+	if numConcealedTriplets == 4 {
+		return []*rules.Pattern{rules.NewPattern("四暗刻", limitScore)}
+	}
+	return nil
+}
+
+// Limit: Four Kongs (十八羅漢)
+func fourKongs(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	numKongs := 0
+	for _, group := range plan.GetMeldedGroups() {
+		if group.GetGroupType() == rules.TileGroupTypeKong ||
+			group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+			numKongs++
+		}
+	}
+	if numKongs == 4 {
+		return []*rules.Pattern{rules.NewPattern("十八羅漢", limitScore)}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Common Hand (平糊) : 1
+func commonHand(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	for _, group := range getAllGroups(plan) {
+		if group.GetGroupType() != rules.TileGroupTypeChow &&
+			group.GetGroupType() != rules.TileGroupTypePair {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("平糊", 1)}
+}
+
+// Concealed Hand (門前清) : 1
+func concealedHand(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	for _, group := range plan.GetMeldedGroups() {
+		if group.GetGroupType() != rules.TileGroupTypeConcealedKong {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("門前清", 1)}
+}
+
+// Self-drawn (自摸) : 1
+func selfDrawn(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
+	if !rules.IsSelfDrawnType(context.OutTileSource.SourceType) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("自摸", 1)}
+}
@@ -0,0 +1,68 @@
+package hk
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// getAllGroups returns the hand groups and melded groups of the given plan as a new slice.
+func getAllGroups(plan rules.OutPlan) rules.TileGroups {
+	var allGroups rules.TileGroups
+	allGroups = append(allGroups, plan.GetHandGroups()...)
+	allGroups = append(allGroups, plan.GetMeldedGroups()...)
+	return allGroups
+}
+
+// isSpecialGroup returns whether the group represents an irregular hand as a whole.
+func isSpecialGroup(group *rules.TileGroup) bool {
+	return group.GetGroupType() == rules.TileGroupTypeSevenPairs ||
+		group.GetGroupType() == rules.TileGroupTypeThirteenOrphans
+}
+
+// getTilesToCheck returns one representative tile per set of the given groups. Seven Pairs groups
+// contribute one tile per pair, and all other groups contribute their first tile.
+func getTilesToCheck(groups rules.TileGroups) domain.Tiles {
+	var tiles domain.Tiles
+	for _, group := range groups {
+		groupTiles := group.GetTiles()
+		if group.GetGroupType() == rules.TileGroupTypeSevenPairs {
+			for i := 0; i < len(groupTiles); i += 2 {
+				tiles = append(tiles, groupTiles[i])
+			}
+		} else {
+			tiles = append(tiles, groupTiles[0])
+		}
+	}
+	return tiles
+}
+
+type simpleSuitCount int
+
+const (
+	noSimpleSuits simpleSuitCount = iota
+	oneSimpleSuit
+	moreThanOneSimpleSuits
+)
+
+// countSimpleSuits returns whether the tiles span zero, one or more simple suits, and whether
+// there is at least one honor tile.
+func countSimpleSuits(tiles domain.Tiles) (simpleSuitCount, bool) {
+	hasHonorTiles := false
+	var simpleSuit *domain.Suit
+	for _, tile := range tiles {
+		switch tile.GetSuit().GetSuitType() {
+		case domain.SuitTypeSimple:
+			if simpleSuit == nil {
+				simpleSuit = tile.GetSuit()
+			} else if simpleSuit != tile.GetSuit() {
+				return moreThanOneSimpleSuits, hasHonorTiles
+			}
+		case domain.SuitTypeHonor:
+			hasHonorTiles = true
+		}
+	}
+	if simpleSuit != nil {
+		return oneSimpleSuit, hasHonorTiles
+	}
+	return noSimpleSuits, hasHonorTiles
+}
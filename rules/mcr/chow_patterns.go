@@ -0,0 +1,240 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"sort"
+)
+
+// Chows
+
+// Pure Terminal Chows (一色双龙会) : 64
+// Quadruple Chow (一色四同顺) : 48
+// Four Pure Shifted Chows (一色四步高) : 32
+// Three-Suited Terminal Chows (三色双龙会) : 16
+func fourChows(hand *winningHand) []*rules.Pattern {
+	chows := hand.getChows()
+	pair := hand.getPair()
+	if len(chows) != 4 || pair == nil {
+		return nil
+	}
+	heads := getChowHeads(chows)
+	sortChowHeads(heads)
+
+	if isTerminalChows(heads[0], heads[1]) && isTerminalChows(heads[2], heads[3]) &&
+		isFive(pair.GetTiles()[0]) {
+		if heads[0].suit == heads[2].suit && heads[0].suit == pair.GetTiles()[0].GetSuit() {
+			return []*rules.Pattern{rules.NewPattern("一色双龙会", 64)}
+		}
+		if heads[0].suit != heads[2].suit && heads[0].suit != pair.GetTiles()[0].GetSuit() &&
+			heads[2].suit != pair.GetTiles()[0].GetSuit() {
+			return []*rules.Pattern{rules.NewPattern("三色双龙会", 16)}
+		}
+	}
+	if !isSameSuit(heads...) {
+		return nil
+	}
+	shift := heads[1].ordinal - heads[0].ordinal
+	for i := 2; i < len(heads); i++ {
+		if heads[i].ordinal-heads[i-1].ordinal != shift {
+			return nil
+		}
+	}
+	switch shift {
+	case 0:
+		return []*rules.Pattern{rules.NewPattern("一色四同顺", 48)}
+	case 1, 2:
+		return []*rules.Pattern{rules.NewPattern("一色四步高", 32)}
+	}
+	return nil
+}
+
+// Pure Triple Chow (一色三同顺) : 24
+// Pure Straight (清龙) : 16
+// Pure Shifted Chows (一色三步高) : 16
+// Mixed Straight (花龙) : 8
+// Mixed Triple Chow (三色三同顺) : 8
+// Mixed Shifted Chows (三色三步高) : 6
+func threeChows(hand *winningHand) []*rules.Pattern {
+	pattern, _ := findThreeChowsPattern(getChowHeads(hand.getChows()))
+	if pattern == nil {
+		return nil
+	}
+	return []*rules.Pattern{pattern}
+}
+
+// Pure Double Chow (一般高) : 1
+// Mixed Double Chow (喜相逢) : 1
+// Short Straight (连六) : 1
+// Two Terminal Chows (老少副) : 1
+//
+// Following the account-once principle, each chow may be combined with each other chow only once
+// to form a fan, including in a fan of three chows. Hence at most n-1 of these fans count for n
+// chows.
+func twoChows(hand *winningHand) []*rules.Pattern {
+	heads := getChowHeads(hand.getChows())
+	components := make([]int, len(heads))
+	for i := range components {
+		components[i] = i
+	}
+	// find returns the index of the chow representing the chows combined with the given chow.
+	var find func(int) int
+	find = func(i int) int {
+		if components[i] != i {
+			components[i] = find(components[i])
+		}
+		return components[i]
+	}
+
+	if _, indices := findThreeChowsPattern(heads); indices != nil {
+		for _, i := range indices[1:] {
+			components[find(i)] = find(indices[0])
+		}
+	}
+
+	var patterns []*rules.Pattern
+	for i := range heads {
+		for j := i + 1; j < len(heads); j++ {
+			if find(i) == find(j) {
+				continue
+			}
+			pattern := getTwoChowsPattern(heads[i], heads[j])
+			if pattern == nil {
+				continue
+			}
+			patterns = append(patterns, pattern)
+			components[find(j)] = find(i)
+		}
+	}
+	return patterns
+}
+
+// All Chows (平和) : 2
+func allChows(hand *winningHand) []*rules.Pattern {
+	pair := hand.getPair()
+	if len(hand.getChows()) != 4 || pair == nil || isHonor(pair.GetTiles()[0]) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("平和", 2)}
+}
+
+// findThreeChowsPattern returns the most valuable fan formed by three of the chows with the given
+// heads, and the indices of these chows. It returns nil if there is none.
+func findThreeChowsPattern(heads []chowHead) (*rules.Pattern, []int) {
+	var bestPattern *rules.Pattern
+	var bestIndices []int
+	for i := 0; i < len(heads); i++ {
+		for j := i + 1; j < len(heads); j++ {
+			for k := j + 1; k < len(heads); k++ {
+				triple := []chowHead{heads[i], heads[j], heads[k]}
+				sortChowHeads(triple)
+				pattern := getThreeChowsPattern(triple)
+				if pattern != nil && (bestPattern == nil || pattern.Score > bestPattern.Score) {
+					bestPattern = pattern
+					bestIndices = []int{i, j, k}
+				}
+			}
+		}
+	}
+	return bestPattern, bestIndices
+}
+
+// getThreeChowsPattern returns the fan formed by the chows with the given heads in order, or nil
+// if there is none.
+func getThreeChowsPattern(heads []chowHead) *rules.Pattern {
+	first, second, third := heads[0], heads[1], heads[2]
+	shift1 := second.ordinal - first.ordinal
+	shift2 := third.ordinal - second.ordinal
+	if isSameSuit(heads...) {
+		switch {
+		case shift1 == 0 && shift2 == 0:
+			return rules.NewPattern("一色三同顺", 24)
+		case shift1 == 3 && shift2 == 3:
+			return rules.NewPattern("清龙", 16)
+		case shift1 == shift2 && (shift1 == 1 || shift1 == 2):
+			return rules.NewPattern("一色三步高", 16)
+		}
+		return nil
+	}
+	if first.suit == second.suit || first.suit == third.suit || second.suit == third.suit {
+		return nil
+	}
+	// The chows of different suits are compared in ordinal order.
+	ordinals := []int{first.ordinal, second.ordinal, third.ordinal}
+	sort.Ints(ordinals)
+	shift1 = ordinals[1] - ordinals[0]
+	shift2 = ordinals[2] - ordinals[1]
+	switch {
+	case shift1 == 3 && shift2 == 3:
+		return rules.NewPattern("花龙", 8)
+	case shift1 == 0 && shift2 == 0:
+		return rules.NewPattern("三色三同顺", 8)
+	case shift1 == 1 && shift2 == 1:
+		return rules.NewPattern("三色三步高", 6)
+	}
+	return nil
+}
+
+// getTwoChowsPattern returns the fan formed by the chows with the given heads, or nil if there is
+// none.
+func getTwoChowsPattern(first, second chowHead) *rules.Pattern {
+	if first.suit != second.suit {
+		if first.ordinal == second.ordinal {
+			return rules.NewPattern("喜相逢", 1)
+		}
+		return nil
+	}
+	switch {
+	case first.ordinal == second.ordinal:
+		return rules.NewPattern("一般高", 1)
+	case isTerminalChows(first, second) || isTerminalChows(second, first):
+		return rules.NewPattern("老少副", 1)
+	case first.ordinal-second.ordinal == 3 || second.ordinal-first.ordinal == 3:
+		return rules.NewPattern("连六", 1)
+	}
+	return nil
+}
+
+// chowHead is the suit and the ordinal of the lowest tile of a chow.
+type chowHead struct {
+	suit    *domain.Suit
+	ordinal int
+}
+
+func getChowHeads(chows rules.TileGroups) []chowHead {
+	var heads []chowHead
+	for _, chow := range chows {
+		tile := chow.GetTiles()[0]
+		for _, t := range chow.GetTiles()[1:] {
+			if t.GetOrdinal() < tile.GetOrdinal() {
+				tile = t
+			}
+		}
+		heads = append(heads, chowHead{suit: tile.GetSuit(), ordinal: tile.GetOrdinal()})
+	}
+	return heads
+}
+
+// sortChowHeads sorts the given heads by suit, then by ordinal.
+func sortChowHeads(heads []chowHead) {
+	sort.Slice(heads, func(i, j int) bool {
+		if heads[i].suit != heads[j].suit {
+			return heads[i].suit.GetName() < heads[j].suit.GetName()
+		}
+		return heads[i].ordinal < heads[j].ordinal
+	})
+}
+
+// isTerminalChows returns whether the given heads are the chows 123 and 789 of the same suit.
+func isTerminalChows(low, high chowHead) bool {
+	return low.suit == high.suit && low.ordinal == 0 && high.ordinal == 6
+}
+
+func isSameSuit(heads ...chowHead) bool {
+	for _, head := range heads[1:] {
+		if head.suit != heads[0].suit {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// impliedFans is a map from the name of a fan to the names of the fans it implies. Following the
+// non-repeat principle, an implied fan is not counted when the implying fan is. Fans that can be
+// implied by only some of the sets of a hand (e.g. 幺九刻 of a Dragon Pung) are instead excluded
+// by the functions matching them.
+var impliedFans = map[string][]string{
+	// 88
+	"大四喜":  {"三风刻", "碰碰和", "圈风刻", "门风刻"},
+	"大三元":  {"双箭刻", "箭刻"},
+	"绿一色":  {"混一色"},
+	"九莲宝灯": {"清一色", "不求人", "门前清", "无字", "缺一门", "幺九刻"},
+	"四杠":   {"三杠", "双明杠", "双暗杠", "明暗杠", "明杠", "暗杠", "碰碰和", "单钓将"},
+	"连七对":  {"七对", "清一色", "不求人", "门前清", "单钓将", "无字", "缺一门"},
+	"十三幺":  {"五门齐", "不求人", "门前清", "单钓将", "混幺九"},
+	// 64
+	"清幺九":   {"混幺九", "碰碰和", "幺九刻", "全带幺", "双同刻", "无字"},
+	"小四喜":   {"三风刻"},
+	"小三元":   {"双箭刻", "箭刻"},
+	"字一色":   {"混幺九", "碰碰和", "幺九刻", "全带幺"},
+	"四暗刻":   {"三暗刻", "双暗刻", "碰碰和", "不求人", "门前清"},
+	"一色双龙会": {"平和", "清一色", "一般高", "老少副", "无字", "缺一门"},
+	// 48
+	"一色四同顺": {"一色三同顺", "一色三节高", "一般高", "四归一"},
+	"一色四节高": {"一色三同顺", "一色三节高", "碰碰和"},
+	// 32
+	"一色四步高": {"一色三步高", "连六", "老少副"},
+	"三杠":    {"双明杠", "双暗杠", "明暗杠", "明杠", "暗杠"},
+	"混幺九":   {"碰碰和", "幺九刻", "全带幺"},
+	// 24
+	"七对":    {"不求人", "门前清", "单钓将"},
+	"七星不靠":  {"全不靠", "五门齐", "不求人", "门前清", "单钓将"},
+	"全双刻":   {"碰碰和", "断幺", "无字"},
+	"清一色":   {"无字", "缺一门"},
+	"一色三同顺": {"一色三节高", "一般高"},
+	"一色三节高": {"一色三同顺"},
+	"全大":    {"大于五", "无字"},
+	"全中":    {"断幺", "无字"},
+	"全小":    {"小于五", "无字"},
+	// 16
+	"清龙":    {"连六", "老少副"},
+	"三色双龙会": {"喜相逢", "老少副", "平和", "无字"},
+	"全带五":   {"断幺", "无字"},
+	"三同刻":   {"双同刻"},
+	"三暗刻":   {"双暗刻"},
+	// 12
+	"全不靠": {"五门齐", "不求人", "门前清", "单钓将"},
+	"大于五": {"无字"},
+	"小于五": {"无字"},
+	// 8
+	"推不倒":  {"缺一门"},
+	"妙手回春": {"自摸"},
+	"杠上开花": {"自摸"},
+	"抢杠和":  {"和绝张"},
+	// 6
+	"全求人": {"单钓将"},
+	"双箭刻": {"箭刻"},
+	"双暗杠": {"暗杠", "双暗刻"},
+	// 5
+	"明暗杠": {"明杠", "暗杠"},
+	// 4
+	"不求人": {"门前清", "自摸"},
+	"双明杠": {"明杠"},
+	// 2
+	"平和": {"无字"},
+	"断幺": {"无字"},
+}
+
+// removeImpliedPatterns returns the given patterns without those whose fan is implied by the fan
+// of another pattern.
+func removeImpliedPatterns(patterns rules.Patterns) rules.Patterns {
+	implied := make(map[string]bool)
+	for _, pattern := range patterns {
+		for _, name := range impliedFans[pattern.Name] {
+			implied[name] = true
+		}
+	}
+	var result rules.Patterns
+	for _, pattern := range patterns {
+		if !implied[pattern.Name] {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Honors
+
+// numWindPungsForFan is the number of wind pungs from which wind pungs score a wind fan rather than
+// Pung of Terminals or Honors.
+const numWindPungsForFan = 3
+
+// Big Four Winds (大四喜) : 88
+// Little Four Winds (小四喜) : 64
+// Big Three Winds (三风刻) : 12
+func winds(hand *winningHand) []*rules.Pattern {
+	numWindPungs := countHonorPungs(hand, rules.Winds)
+	pair := hand.getPair()
+	switch {
+	case numWindPungs == 4:
+		return []*rules.Pattern{rules.NewPattern("大四喜", 88)}
+	case numWindPungs == 3 && pair != nil && pair.GetTiles()[0].GetSuit() == rules.Winds:
+		return []*rules.Pattern{rules.NewPattern("小四喜", 64)}
+	case numWindPungs == 3:
+		return []*rules.Pattern{rules.NewPattern("三风刻", 12)}
+	}
+	return nil
+}
+
+// Big Three Dragons (大三元) : 88
+// Little Three Dragons (小三元) : 64
+// Two Dragon Pungs (双箭刻) : 6
+// Dragon Pung (箭刻) : 2
+func dragons(hand *winningHand) []*rules.Pattern {
+	numDragonPungs := countHonorPungs(hand, rules.Dragons)
+	pair := hand.getPair()
+	switch {
+	case numDragonPungs == 3:
+		return []*rules.Pattern{rules.NewPattern("大三元", 88)}
+	case numDragonPungs == 2 && pair != nil && pair.GetTiles()[0].GetSuit() == rules.Dragons:
+		return []*rules.Pattern{rules.NewPattern("小三元", 64)}
+	case numDragonPungs == 2:
+		return []*rules.Pattern{rules.NewPattern("双箭刻", 6)}
+	case numDragonPungs == 1:
+		return []*rules.Pattern{rules.NewPattern("箭刻", 2)}
+	}
+	return nil
+}
+
+// Prevailing Wind (圈风刻) : 2
+func prevailingWind(hand *winningHand) []*rules.Pattern {
+	for _, pung := range hand.getPungs() {
+		if hand.isPrevailingWind(pung.group.GetTiles()[0]) {
+			return []*rules.Pattern{rules.NewPattern("圈风刻", 2)}
+		}
+	}
+	return nil
+}
+
+// Seat Wind (门风刻) : 2
+func seatWind(hand *winningHand) []*rules.Pattern {
+	for _, pung := range hand.getPungs() {
+		if hand.isSeatWind(pung.group.GetTiles()[0]) {
+			return []*rules.Pattern{rules.NewPattern("门风刻", 2)}
+		}
+	}
+	return nil
+}
+
+// All Honors (字一色) : 64
+func allHonors(hand *winningHand) []*rules.Pattern {
+	for _, tile := range hand.getAllTiles() {
+		if !isHonor(tile) {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("字一色", 64)}
+}
+
+// Pung of Terminals or Honors (幺九刻) : 1 for each pung of terminals, or of winds that do not
+// score another fan
+func pungsOfTerminalsOrHonors(hand *winningHand) []*rules.Pattern {
+	numWindPungs := countHonorPungs(hand, rules.Winds)
+	var patterns []*rules.Pattern
+	for _, pung := range hand.getPungs() {
+		tile := pung.group.GetTiles()[0]
+		switch {
+		case tile.GetSuit() == rules.Dragons:
+			continue
+		case tile.GetSuit() == rules.Winds:
+			if numWindPungs >= numWindPungsForFan || hand.isSeatWind(tile) ||
+				hand.isPrevailingWind(tile) {
+				continue
+			}
+		case !tile.IsTerminal():
+			continue
+		}
+		patterns = append(patterns, rules.NewPattern("幺九刻", 1))
+	}
+	return patterns
+}
+
+// countHonorPungs returns the number of pungs of the given honor suit.
+func countHonorPungs(hand *winningHand, suit *domain.Suit) int {
+	count := 0
+	for _, pung := range hand.getPungs() {
+		if pung.group.GetTiles()[0].GetSuit() == suit {
+			count++
+		}
+	}
+	return count
+}
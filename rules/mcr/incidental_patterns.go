@@ -0,0 +1,148 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Incidental
+
+// flowerTilesName is the name of the Flower Tiles pattern, which does not count towards the
+// minimum score of an Out.
+const flowerTilesName = "花牌"
+
+// numCopiesForLastTile is the number of visible copies of the out tile for it to be the last tile.
+const numCopiesForLastTile = 3
+
+// Fully Concealed Hand (不求人) : 4
+// Concealed Hand (门前清) : 2
+// Self-Drawn (自摸) : 1
+func concealedOrSelfDrawn(hand *winningHand) []*rules.Pattern {
+	switch concealed, selfDrawn := hand.isConcealed(), hand.isSelfDrawn(); {
+	case concealed && selfDrawn:
+		return []*rules.Pattern{rules.NewPattern("不求人", 4)}
+	case concealed:
+		return []*rules.Pattern{rules.NewPattern("门前清", 2)}
+	case selfDrawn:
+		return []*rules.Pattern{rules.NewPattern("自摸", 1)}
+	}
+	return nil
+}
+
+// Melded Hand (全求人) : 6
+func meldedHand(hand *winningHand) []*rules.Pattern {
+	meldedGroups := hand.plan.GetMeldedGroups()
+	winningGroup := hand.getWinningGroup()
+	if len(meldedGroups) != 4 || !rules.IsExternalOutSourceType(hand.context.OutTileSource.SourceType) ||
+		winningGroup == nil || winningGroup.GetGroupType() != rules.TileGroupTypePair {
+		return nil
+	}
+	for _, group := range meldedGroups {
+		if group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("全求人", 6)}
+}
+
+// Edge Wait (边张) : 1
+// Closed Wait (嵌张) : 1
+// Single Wait (单钓将) : 1
+//
+// A wait fan only counts if the out tile is the only tile that would have completed the hand.
+func waits(hand *winningHand) []*rules.Pattern {
+	winningGroup := hand.getWinningGroup()
+	if hand.numWaits != 1 || winningGroup == nil {
+		return nil
+	}
+	switch winningGroup.GetGroupType() {
+	case rules.TileGroupTypePair:
+		return []*rules.Pattern{rules.NewPattern("单钓将", 1)}
+	case rules.TileGroupTypeChow:
+		outOrdinal := hand.context.OutTileSource.Tile.GetOrdinal()
+		low := getChowHeads(rules.TileGroups{winningGroup})[0].ordinal
+		switch {
+		case outOrdinal == low+1:
+			return []*rules.Pattern{rules.NewPattern("嵌张", 1)}
+		case low == 0 && outOrdinal == 2, low == 6 && outOrdinal == 6:
+			return []*rules.Pattern{rules.NewPattern("边张", 1)}
+		}
+	}
+	return nil
+}
+
+// Last Tile Draw (妙手回春) : 8
+// Last Tile Claim (海底捞月) : 8
+func lastTileDrawOrClaim(hand *winningHand) []*rules.Pattern {
+	if hand.context.NumRemainingTilesInDeck > 0 {
+		return nil
+	}
+	switch hand.context.OutTileSource.SourceType {
+	case rules.OutTileSourceTypeSelfDrawn, rules.OutTileSourceTypeSelfDrawnReplacement:
+		return []*rules.Pattern{rules.NewPattern("妙手回春", 8)}
+	case rules.OutTileSourceTypeDiscard:
+		return []*rules.Pattern{rules.NewPattern("海底捞月", 8)}
+	}
+	return nil
+}
+
+// Out with Replacement Tile (杠上开花) : 8
+// Robbing the Kong (抢杠和) : 8
+func winOnKong(hand *winningHand) []*rules.Pattern {
+	switch hand.context.OutTileSource.SourceType {
+	case rules.OutTileSourceTypeSelfDrawnReplacement:
+		return []*rules.Pattern{rules.NewPattern("杠上开花", 8)}
+	case rules.OutTileSourceTypeAdditionalKong:
+		return []*rules.Pattern{rules.NewPattern("抢杠和", 8)}
+	}
+	return nil
+}
+
+// Last Tile (和绝张) : 4
+//
+// The tiles visible to the scorer are the melds and discards of the player and of the discarder.
+func lastTile(hand *winningHand) []*rules.Pattern {
+	outTileSource := hand.context.OutTileSource
+	outTile := outTileSource.Tile
+	if outTile == nil {
+		return nil
+	}
+	players := []*rules.PlayerGameState{hand.context.PlayerGameState}
+	if outTileSource.DiscardInfo != nil {
+		players = append(players, outTileSource.DiscardInfo.DiscardPlayer)
+	}
+
+	// The same tile may be visible in multiple areas, e.g. a discarded tile that was melded.
+	seen := map[*domain.Tile]bool{outTile: true}
+	numCopies := 0
+	countCopies := func(tiles domain.Tiles) {
+		for _, tile := range tiles {
+			if tile == nil || seen[tile] {
+				continue
+			}
+			seen[tile] = true
+			if domain.CompareTiles(tile, outTile) == 0 {
+				numCopies++
+			}
+		}
+	}
+	for _, player := range players {
+		for _, group := range player.GetMeldGroups() {
+			countCopies(group.GetTiles())
+		}
+		countCopies(player.GetDiscardedTiles())
+	}
+	if numCopies < numCopiesForLastTile {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("和绝张", 4)}
+}
+
+// Flower Tiles (花牌) : 1 for each bonus tile
+func flowerTiles(hand *winningHand) []*rules.Pattern {
+	var patterns []*rules.Pattern
+	for range hand.context.PlayerGameState.GetBonusTiles() {
+		patterns = append(patterns, rules.NewPattern(flowerTilesName, 1))
+	}
+	return patterns
+}
@@ -0,0 +1,118 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/rules"
+	"sort"
+)
+
+// OutPlansScorer is an implementation of rules.OutPlansScorer based on Chinese Official rules
+// (Mahjong Competition Rules). The score of each fan pattern is its points. Fans implied by another
+// fan in the same plan are not counted, following the non-repeat and exclusion principles. The
+// implementation assumes each plan contains a valid combination of tiles. Any invalid combination
+// may result in incorrect scoring.
+type OutPlansScorer struct {
+	// ruleSet is used to find the waits of the hand.
+	ruleSet rules.RuleSet
+}
+
+// NewOutPlansScorer creates a new OutPlansScorer. The given RuleSet is used to find the waits of
+// the hand, which affect the wait fans.
+func NewOutPlansScorer(ruleSet rules.RuleSet) *OutPlansScorer {
+	return &OutPlansScorer{ruleSet: ruleSet}
+}
+
+// ScoreOutPlans ... (rules.OutPlansScorer implementation)
+func (s *OutPlansScorer) ScoreOutPlans(plans rules.OutPlans,
+	context *rules.OutPlanScoringContext) rules.ScoredOutPlans {
+	numWaits := rules.CountWaitsBeforeOut(s.ruleSet, context)
+	var scoredPlans rules.ScoredOutPlans
+	for _, plan := range plans {
+		scoredPlans = append(scoredPlans, s.scoreOutPlan(plan, context, numWaits))
+	}
+
+	sort.Sort(scoredPlans)
+	return scoredPlans
+}
+
+// scoreOutPlan scores the given plan with the winning group that is worth the most points.
+func (s *OutPlansScorer) scoreOutPlan(plan rules.OutPlan, context *rules.OutPlanScoringContext,
+	numWaits int) *rules.ScoredOutPlan {
+	var bestPlan *rules.ScoredOutPlan
+	for _, hand := range newWinningHands(plan, context, numWaits) {
+		scoredPlan := s.scoreWinningHand(hand)
+		if bestPlan == nil || scoredPlan.TotalScore > bestPlan.TotalScore {
+			bestPlan = scoredPlan
+		}
+	}
+	return bestPlan
+}
+
+func (s *OutPlansScorer) scoreWinningHand(hand *winningHand) *rules.ScoredOutPlan {
+	var patterns rules.Patterns
+	for _, matchPattern := range matchPatternFuncList {
+		patterns = append(patterns, matchPattern(hand)...)
+	}
+	patterns = removeImpliedPatterns(patterns)
+
+	if len(patterns) == 0 {
+		patterns = append(patterns, rules.NewPattern("无番和", 8))
+	}
+	// Flower Tiles are not a fan, and do not prevent a Chicken Hand.
+	patterns = append(patterns, flowerTiles(hand)...)
+
+	sort.Sort(patterns)
+	totalScore := 0
+	for _, pattern := range patterns {
+		totalScore += pattern.Score
+	}
+	return rules.NewScoredOutPlan(hand.plan, totalScore, patterns)
+}
+
+type matchPatternFunc func(*winningHand) []*rules.Pattern
+
+var matchPatternFuncList = []matchPatternFunc{
+	// Special hands
+	thirteenOrphans,
+	sevenPairs,
+	honorsAndKnittedTiles,
+	knittedStraight,
+	nineGates,
+	// Honors
+	winds,
+	dragons,
+	prevailingWind,
+	seatWind,
+	allHonors,
+	pungsOfTerminalsOrHonors,
+	// Suits and tiles
+	allGreen,
+	oneSuit,
+	terminals,
+	tileRanges,
+	allSimples,
+	reversibleTiles,
+	allTypes,
+	voidedSuitOrNoHonors,
+	allEvenPungs,
+	allFives,
+	outsideHand,
+	tileHogs,
+	// Chows
+	fourChows,
+	threeChows,
+	twoChows,
+	allChows,
+	// Pungs and kongs
+	kongs,
+	concealedPungs,
+	allPungs,
+	shiftedPungs,
+	similarPungs,
+	// Incidental
+	concealedOrSelfDrawn,
+	meldedHand,
+	waits,
+	lastTileDrawOrClaim,
+	winOnKong,
+	lastTile,
+}
@@ -0,0 +1,156 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// createMeldedPlayerForTest returns a player with a melded chow of Bamboo 123 and a melded pong of
+// Dots 5, and the given tiles in hand.
+func createMeldedPlayerForTest(t *testing.T, bonusTiles domain.Tiles,
+	tiles domain.Tiles) *rules.PlayerGameState {
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	chowTiles := domain.CreateTilesForTest(t, rules.Bamboo, 0, 1, 2)
+	pongTiles := domain.CreateTilesForTest(t, rules.Dots, 4, 4, 4)
+	meldGroups := rules.TileGroups{
+		rules.NewTileGroup(chowTiles, rules.TileGroupTypeChow),
+		rules.NewTileGroup(pongTiles, rules.TileGroupTypePong),
+	}
+	return rules.NewExistingPlayerGameState(hand, 1, bonusTiles, nil, meldGroups)
+}
+
+func Test_ScoreOutPlans_ChickenHand(t *testing.T) {
+	player := createMeldedPlayerForTest(t, nil, domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Characters, 1, 2, 3, 5, 6),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 0),
+	))
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 7))
+	assert.Equal(t, 8, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"无番和"}, rules.PatternNamesForTest(scoredPlans[0]))
+	assert.True(t, NewRuleSet().IsQualifyingOut(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_FlowerTilesDoNotQualify(t *testing.T) {
+	var bonusTiles domain.Tiles
+	for ordinal := 0; ordinal < 4; ordinal++ {
+		bonusTiles = append(bonusTiles, domain.ConcatTilesForTest(
+			domain.CreateTilesForTest(t, rules.Flowers, ordinal),
+			domain.CreateTilesForTest(t, rules.Seasons, ordinal),
+		)...)
+	}
+	player := createMeldedPlayerForTest(t, bonusTiles, domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Characters, 1, 2, 3, 5, 6),
+		domain.CreateTilesForTest(t, rules.Dots, 8, 8),
+	))
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 7))
+	// No Honors and 8 Flower Tiles, which do not count towards the minimum of 8.
+	assert.Equal(t, 9, scoredPlans[0].TotalScore)
+	assert.Len(t, scoredPlans[0].Patterns, 9)
+	assert.Contains(t, rules.PatternNamesForTest(scoredPlans[0]), "无字")
+	assert.False(t, NewRuleSet().IsQualifyingOut(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_WindPungs(t *testing.T) {
+	createPlayer := func(windOrdinal int) *rules.PlayerGameState {
+		return createMeldedPlayerForTest(t, nil, domain.ConcatTilesForTest(
+			domain.CreateTilesForTest(t, rules.Characters, 1, 2, 3),
+			domain.CreateTilesForTest(t, rules.Winds, windOrdinal, windOrdinal, windOrdinal),
+			domain.CreateTilesForTest(t, rules.Characters, 8),
+		))
+	}
+
+	// A pung of East in the East round is a Prevailing Wind, and not a Pung of Terminals or Honors.
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), createPlayer(0),
+		rules.DiscardSourceForTest(t, rules.Characters, 8), 0)
+	assert.Contains(t, rules.PatternNamesForTest(scoredPlans[0]), "圈风刻")
+	assert.NotContains(t, rules.PatternNamesForTest(scoredPlans[0]), "门风刻")
+	assert.NotContains(t, rules.PatternNamesForTest(scoredPlans[0]), "幺九刻")
+
+	// The same pung in the South round is only a Pung of Terminals or Honors for South.
+	scoredPlans = rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), createPlayer(0),
+		rules.DiscardSourceForTest(t, rules.Characters, 8), 1)
+	assert.NotContains(t, rules.PatternNamesForTest(scoredPlans[0]), "圈风刻")
+	assert.Contains(t, rules.PatternNamesForTest(scoredPlans[0]), "幺九刻")
+
+	// A pung of South for South in the South round is both.
+	scoredPlans = rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), createPlayer(1),
+		rules.DiscardSourceForTest(t, rules.Characters, 8), 1)
+	assert.Contains(t, rules.PatternNamesForTest(scoredPlans[0]), "圈风刻")
+	assert.Contains(t, rules.PatternNamesForTest(scoredPlans[0]), "门风刻")
+	assert.NotContains(t, rules.PatternNamesForTest(scoredPlans[0]), "幺九刻")
+}
+
+func Test_ScoreOutPlans_FullFlushExcludesImpliedFans(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.CreateTilesForTest(t, rules.Characters,
+		0, 1, 2, 0, 1, 2, 3, 4, 5, 7, 7, 7, 8, 8))
+	player := rules.NewPlayerGameState(hand, 1)
+	outTileSource := rules.NewOutTileSource(
+		rules.OutTileSourceTypeSelfDrawn, hand.GetTiles()[13], nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	// No Honors, One Voided Suit, Concealed Hand and Self-Drawn are implied. The second chow of 123
+	// may only be combined with 456 once, through the first.
+	assert.Equal(t, 30, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"清一色", "一般高", "连六", "不求人"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_SevenPairs(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Characters, 0, 0),
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 1),
+		domain.CreateTilesForTest(t, rules.Dots, 3, 3, 4, 4),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 0),
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 0),
+		domain.CreateTilesForTest(t, rules.Characters, 8),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 8))
+	assert.Equal(t, 30, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"七对", "五门齐"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_ThirteenOrphans(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 0, 8),
+		domain.CreateTilesForTest(t, rules.Characters, 0, 8),
+		domain.CreateTilesForTest(t, rules.Dots, 0, 8),
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 1, 2),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 1, 2, 3),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Bamboo, 0))
+	assert.Equal(t, 88, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"十三幺"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_LesserHonorsAndKnittedTiles(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 0, 3, 6),
+		domain.CreateTilesForTest(t, rules.Characters, 1, 4, 7),
+		domain.CreateTilesForTest(t, rules.Dots, 2),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 1, 2, 3),
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 1),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 5))
+	assert.Equal(t, 12, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"全不靠"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
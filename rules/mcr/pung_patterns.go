@@ -0,0 +1,176 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"sort"
+)
+
+// Pungs and kongs
+
+// Four Kongs (四杠) : 88
+// Three Kongs (三杠) : 32
+// Two Concealed Kongs (双暗杠) : 6
+// Concealed and Melded Kongs (明暗杠) : 5
+// Two Melded Kongs (双明杠) : 4
+// Concealed Kong (暗杠) : 2
+// Melded Kong (明杠) : 1
+func kongs(hand *winningHand) []*rules.Pattern {
+	numConcealedKongs, numMeldedKongs := 0, 0
+	for _, group := range hand.plan.GetMeldedGroups() {
+		switch group.GetGroupType() {
+		case rules.TileGroupTypeConcealedKong:
+			numConcealedKongs++
+		case rules.TileGroupTypeKong:
+			numMeldedKongs++
+		}
+	}
+	switch numKongs := numConcealedKongs + numMeldedKongs; {
+	case numKongs == 4:
+		return []*rules.Pattern{rules.NewPattern("四杠", 88)}
+	case numKongs == 3:
+		return []*rules.Pattern{rules.NewPattern("三杠", 32)}
+	case numConcealedKongs == 2:
+		return []*rules.Pattern{rules.NewPattern("双暗杠", 6)}
+	case numKongs == 2 && numConcealedKongs == 1:
+		return []*rules.Pattern{rules.NewPattern("明暗杠", 5)}
+	case numMeldedKongs == 2:
+		return []*rules.Pattern{rules.NewPattern("双明杠", 4)}
+	case numConcealedKongs == 1:
+		return []*rules.Pattern{rules.NewPattern("暗杠", 2)}
+	case numMeldedKongs == 1:
+		return []*rules.Pattern{rules.NewPattern("明杠", 1)}
+	}
+	return nil
+}
+
+// Four Concealed Pungs (四暗刻) : 64
+// Three Concealed Pungs (三暗刻) : 16
+// Two Concealed Pungs (双暗刻) : 2
+func concealedPungs(hand *winningHand) []*rules.Pattern {
+	numConcealedPungs := 0
+	for _, pung := range hand.getPungs() {
+		if pung.concealed {
+			numConcealedPungs++
+		}
+	}
+	switch numConcealedPungs {
+	case 4:
+		return []*rules.Pattern{rules.NewPattern("四暗刻", 64)}
+	case 3:
+		return []*rules.Pattern{rules.NewPattern("三暗刻", 16)}
+	case 2:
+		return []*rules.Pattern{rules.NewPattern("双暗刻", 2)}
+	}
+	return nil
+}
+
+// All Pungs (碰碰和) : 6
+func allPungs(hand *winningHand) []*rules.Pattern {
+	if !hand.isStandard() || len(hand.getPungs()) != 4 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("碰碰和", 6)}
+}
+
+// Four Pure Shifted Pungs (一色四节高) : 48
+// Pure Shifted Pungs (一色三节高) : 24
+// Mixed Shifted Pungs (三色三节高) : 8
+func shiftedPungs(hand *winningHand) []*rules.Pattern {
+	tiles := getSimplePungTiles(hand)
+	if len(tiles) == 4 && isShiftedPungs(tiles) && isSameSuitPungs(tiles) {
+		return []*rules.Pattern{rules.NewPattern("一色四节高", 48)}
+	}
+	var best *rules.Pattern
+	forEachPungTriple(tiles, func(triple domain.Tiles) {
+		if !isShiftedPungs(triple) {
+			return
+		}
+		if isSameSuitPungs(triple) {
+			best = rules.NewPattern("一色三节高", 24)
+		} else if best == nil && isDistinctSuitPungs(triple) {
+			best = rules.NewPattern("三色三节高", 8)
+		}
+	})
+	if best == nil {
+		return nil
+	}
+	return []*rules.Pattern{best}
+}
+
+// Triple Pung (三同刻) : 16
+// Double Pung (双同刻) : 2 for each two pungs of the same number in different suits
+func similarPungs(hand *winningHand) []*rules.Pattern {
+	tiles := getSimplePungTiles(hand)
+	var patterns []*rules.Pattern
+	forEachPungTriple(tiles, func(triple domain.Tiles) {
+		if patterns == nil && isDistinctSuitPungs(triple) &&
+			triple[0].GetOrdinal() == triple[2].GetOrdinal() {
+			patterns = append(patterns, rules.NewPattern("三同刻", 16))
+		}
+	})
+	if patterns != nil {
+		return patterns
+	}
+	for i := range tiles {
+		for j := i + 1; j < len(tiles); j++ {
+			if tiles[i].GetOrdinal() == tiles[j].GetOrdinal() {
+				patterns = append(patterns, rules.NewPattern("双同刻", 2))
+			}
+		}
+	}
+	return patterns
+}
+
+// getSimplePungTiles returns a tile of each pung of a simple suit, sorted by ordinal.
+func getSimplePungTiles(hand *winningHand) domain.Tiles {
+	var tiles domain.Tiles
+	for _, pung := range hand.getPungs() {
+		tile := pung.group.GetTiles()[0]
+		if !isHonor(tile) {
+			tiles = append(tiles, tile)
+		}
+	}
+	sort.SliceStable(tiles, func(i, j int) bool {
+		return tiles[i].GetOrdinal() < tiles[j].GetOrdinal()
+	})
+	return tiles
+}
+
+// forEachPungTriple calls the given function with each three of the given tiles, in order.
+func forEachPungTriple(tiles domain.Tiles, f func(domain.Tiles)) {
+	for i := 0; i < len(tiles); i++ {
+		for j := i + 1; j < len(tiles); j++ {
+			for k := j + 1; k < len(tiles); k++ {
+				f(domain.Tiles{tiles[i], tiles[j], tiles[k]})
+			}
+		}
+	}
+}
+
+// isShiftedPungs returns whether the given tiles, sorted by ordinal, have consecutive ordinals.
+func isShiftedPungs(tiles domain.Tiles) bool {
+	for i := 1; i < len(tiles); i++ {
+		if tiles[i].GetOrdinal() != tiles[i-1].GetOrdinal()+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func isSameSuitPungs(tiles domain.Tiles) bool {
+	for _, tile := range tiles[1:] {
+		if tile.GetSuit() != tiles[0].GetSuit() {
+			return false
+		}
+	}
+	return true
+}
+
+func isDistinctSuitPungs(tiles domain.Tiles) bool {
+	suits := make(map[*domain.Suit]bool)
+	for _, tile := range tiles {
+		suits[tile.GetSuit()] = true
+	}
+	return len(suits) == len(tiles)
+}
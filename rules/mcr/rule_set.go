@@ -0,0 +1,51 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// minOutScore is the minimum score of an Out, not counting Flower Tiles.
+const minOutScore = 8
+
+func init() {
+	rules.RegisterRuleSet(NewRuleSet())
+}
+
+// ruleSet is the rules.RuleSet for Chinese Official MJ. Flower Tiles do not count towards the
+// minimum score of an Out.
+type ruleSet struct {
+	rules.BaseRuleSet
+}
+
+// NewRuleSet returns the rules.RuleSet for Chinese Official MJ.
+func NewRuleSet() rules.RuleSet {
+	rs := &ruleSet{
+		BaseRuleSet: rules.BaseRuleSet{
+			Name:            flags.RuleNameMCR,
+			TileCountRules:  rules.TileCountRulesMCR,
+			NumTilesPerHand: 13,
+			SpecialHands: []rules.TileGroupType{
+				rules.TileGroupTypeSevenPairs,
+				rules.TileGroupTypeThirteenOrphans,
+				rules.TileGroupTypeHonorsAndKnitted,
+				rules.TileGroupTypeKnittedStraight,
+			},
+			AllowRobbingKong: true,
+			MinOutScore:      minOutScore,
+		},
+	}
+	rs.Scorer = NewOutPlansScorer(rs)
+	return rs
+}
+
+// IsQualifyingOut ... (rules.RuleSet implementation)
+func (rs *ruleSet) IsQualifyingOut(plan *rules.ScoredOutPlan) bool {
+	score := plan.TotalScore
+	for _, pattern := range plan.Patterns {
+		if pattern.Name == flowerTilesName {
+			score -= pattern.Score
+		}
+	}
+	return score >= rs.MinOutScore
+}
@@ -0,0 +1,96 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Special hands
+
+// Thirteen Orphans (十三幺) : 88
+func thirteenOrphans(hand *winningHand) []*rules.Pattern {
+	if hand.getSpecialGroup(rules.TileGroupTypeThirteenOrphans) == nil {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("十三幺", 88)}
+}
+
+// Seven Shifted Pairs (连七对) : 88
+// Seven Pairs (七对) : 24
+func sevenPairs(hand *winningHand) []*rules.Pattern {
+	group := hand.getSpecialGroup(rules.TileGroupTypeSevenPairs)
+	if group == nil {
+		return nil
+	}
+	tiles := group.GetTiles()
+	isShifted := tiles[0].GetSuit().GetSuitType() == domain.SuitTypeSimple
+	for i := 2; i < len(tiles); i += 2 {
+		if tiles[i].GetSuit() != tiles[0].GetSuit() ||
+			tiles[i].GetOrdinal() != tiles[i-2].GetOrdinal()+1 {
+			isShifted = false
+		}
+	}
+	if isShifted {
+		return []*rules.Pattern{rules.NewPattern("连七对", 88)}
+	}
+	return []*rules.Pattern{rules.NewPattern("七对", 24)}
+}
+
+// Greater Honors and Knitted Tiles (七星不靠) : 24
+// Lesser Honors and Knitted Tiles (全不靠) : 12
+func honorsAndKnittedTiles(hand *winningHand) []*rules.Pattern {
+	group := hand.getSpecialGroup(rules.TileGroupTypeHonorsAndKnitted)
+	if group == nil {
+		return nil
+	}
+	numHonors := 0
+	for _, tile := range group.GetTiles() {
+		if isHonor(tile) {
+			numHonors++
+		}
+	}
+	if numHonors == rules.Winds.GetSize()+rules.Dragons.GetSize() {
+		return []*rules.Pattern{rules.NewPattern("七星不靠", 24)}
+	}
+	return []*rules.Pattern{rules.NewPattern("全不靠", 12)}
+}
+
+// Knitted Straight (组合龙) : 12
+func knittedStraight(hand *winningHand) []*rules.Pattern {
+	if hand.getSpecialGroup(rules.TileGroupTypeKnittedStraight) == nil {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("组合龙", 12)}
+}
+
+// Nine Gates (九莲宝灯) : 88
+func nineGates(hand *winningHand) []*rules.Pattern {
+	outTile := hand.context.OutTileSource.Tile
+	if len(hand.plan.GetMeldedGroups()) > 0 || outTile == nil {
+		return nil
+	}
+	suit := outTile.GetSuit()
+	if suit.GetSuitType() != domain.SuitTypeSimple {
+		return nil
+	}
+	// The hand must wait on 1112345678999 of the suit. To compute what was in the hand, subtract
+	// the out tile from the tiles in the plan.
+	counts := make([]int, suit.GetSize())
+	for _, tile := range hand.getAllTiles() {
+		if tile.GetSuit() != suit {
+			return nil
+		}
+		counts[tile.GetOrdinal()]++
+	}
+	counts[outTile.GetOrdinal()]--
+	for i, count := range counts {
+		expectedCount := 1
+		if i == 0 || i == suit.GetSize()-1 {
+			expectedCount = 3
+		}
+		if count != expectedCount {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("九莲宝灯", 88)}
+}
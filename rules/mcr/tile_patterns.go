@@ -0,0 +1,248 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Suits and tiles
+
+var (
+	// greenTileSet is the set of tiles of All Green: 23468 of Bamboo and the green dragon.
+	greenTileSet = newTileBaseSet(
+		domain.NewTileBase(rules.Bamboo, 1), domain.NewTileBase(rules.Bamboo, 2),
+		domain.NewTileBase(rules.Bamboo, 3), domain.NewTileBase(rules.Bamboo, 5),
+		domain.NewTileBase(rules.Bamboo, 7), domain.NewTileBase(rules.Dragons, 1))
+	// reversibleTileSet is the set of tiles of Reversible Tiles: 1234589 of Dots, 245689 of
+	// Bamboo and the white dragon.
+	reversibleTileSet = newTileBaseSet(
+		domain.NewTileBase(rules.Dots, 0), domain.NewTileBase(rules.Dots, 1),
+		domain.NewTileBase(rules.Dots, 2), domain.NewTileBase(rules.Dots, 3),
+		domain.NewTileBase(rules.Dots, 4), domain.NewTileBase(rules.Dots, 7),
+		domain.NewTileBase(rules.Dots, 8), domain.NewTileBase(rules.Bamboo, 1),
+		domain.NewTileBase(rules.Bamboo, 3), domain.NewTileBase(rules.Bamboo, 4),
+		domain.NewTileBase(rules.Bamboo, 5), domain.NewTileBase(rules.Bamboo, 7),
+		domain.NewTileBase(rules.Bamboo, 8), domain.NewTileBase(rules.Dragons, 2))
+)
+
+func newTileBaseSet(tileBases ...domain.TileBase) map[domain.TileBase]bool {
+	set := make(map[domain.TileBase]bool)
+	for _, tileBase := range tileBases {
+		set[tileBase] = true
+	}
+	return set
+}
+
+// All Green (绿一色) : 88
+func allGreen(hand *winningHand) []*rules.Pattern {
+	if !allTilesInSet(hand, greenTileSet) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("绿一色", 88)}
+}
+
+// Reversible Tiles (推不倒) : 8
+func reversibleTiles(hand *winningHand) []*rules.Pattern {
+	if !allTilesInSet(hand, reversibleTileSet) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("推不倒", 8)}
+}
+
+func allTilesInSet(hand *winningHand, set map[domain.TileBase]bool) bool {
+	for _, tile := range hand.getAllTiles() {
+		if !set[tile.TileBase] {
+			return false
+		}
+	}
+	return true
+}
+
+// Full Flush (清一色) : 24
+// Half Flush (混一色) : 6
+func oneSuit(hand *winningHand) []*rules.Pattern {
+	simpleSuits, hasHonors := getSuits(hand)
+	if len(simpleSuits) != 1 {
+		return nil
+	}
+	if hasHonors {
+		return []*rules.Pattern{rules.NewPattern("混一色", 6)}
+	}
+	return []*rules.Pattern{rules.NewPattern("清一色", 24)}
+}
+
+// All Types (五门齐) : 6
+func allTypes(hand *winningHand) []*rules.Pattern {
+	simpleSuits, _ := getSuits(hand)
+	hasWinds, hasDragons := false, false
+	for _, tile := range hand.getAllTiles() {
+		hasWinds = hasWinds || tile.GetSuit() == rules.Winds
+		hasDragons = hasDragons || tile.GetSuit() == rules.Dragons
+	}
+	if len(simpleSuits) != 3 || !hasWinds || !hasDragons {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("五门齐", 6)}
+}
+
+// One Voided Suit (缺一门) : 1
+// No Honors (无字) : 1
+func voidedSuitOrNoHonors(hand *winningHand) []*rules.Pattern {
+	var patterns []*rules.Pattern
+	simpleSuits, hasHonors := getSuits(hand)
+	if len(simpleSuits) == 2 {
+		patterns = append(patterns, rules.NewPattern("缺一门", 1))
+	}
+	if !hasHonors {
+		patterns = append(patterns, rules.NewPattern("无字", 1))
+	}
+	return patterns
+}
+
+// getSuits returns the set of simple suits in the hand, and whether the hand has honors.
+func getSuits(hand *winningHand) (map[*domain.Suit]bool, bool) {
+	simpleSuits := make(map[*domain.Suit]bool)
+	hasHonors := false
+	for _, tile := range hand.getAllTiles() {
+		if isHonor(tile) {
+			hasHonors = true
+		} else {
+			simpleSuits[tile.GetSuit()] = true
+		}
+	}
+	return simpleSuits, hasHonors
+}
+
+// All Terminals (清幺九) : 64
+// All Terminals and Honors (混幺九) : 32
+func terminals(hand *winningHand) []*rules.Pattern {
+	hasTerminals, hasHonors := false, false
+	for _, tile := range hand.getAllTiles() {
+		if !isTerminalOrHonor(tile) {
+			return nil
+		}
+		hasTerminals = hasTerminals || tile.IsTerminal()
+		hasHonors = hasHonors || isHonor(tile)
+	}
+	if hand.getSpecialGroup(rules.TileGroupTypeThirteenOrphans) != nil || !hasTerminals {
+		return nil
+	}
+	if hasHonors {
+		return []*rules.Pattern{rules.NewPattern("混幺九", 32)}
+	}
+	return []*rules.Pattern{rules.NewPattern("清幺九", 64)}
+}
+
+// Upper Tiles (全大) : 24
+// Middle Tiles (全中) : 24
+// Lower Tiles (全小) : 24
+// Upper Four (大于五) : 12
+// Lower Four (小于五) : 12
+func tileRanges(hand *winningHand) []*rules.Pattern {
+	minOrdinal, maxOrdinal := -1, -1
+	for _, tile := range hand.getAllTiles() {
+		if isHonor(tile) {
+			return nil
+		}
+		if minOrdinal < 0 || tile.GetOrdinal() < minOrdinal {
+			minOrdinal = tile.GetOrdinal()
+		}
+		if tile.GetOrdinal() > maxOrdinal {
+			maxOrdinal = tile.GetOrdinal()
+		}
+	}
+	// The ordinals are 0-based, i.e. 6 is the tile 7.
+	switch {
+	case minOrdinal >= 6:
+		return []*rules.Pattern{rules.NewPattern("全大", 24)}
+	case minOrdinal >= 3 && maxOrdinal <= 5:
+		return []*rules.Pattern{rules.NewPattern("全中", 24)}
+	case maxOrdinal <= 2:
+		return []*rules.Pattern{rules.NewPattern("全小", 24)}
+	case minOrdinal >= 5:
+		return []*rules.Pattern{rules.NewPattern("大于五", 12)}
+	case maxOrdinal <= 3:
+		return []*rules.Pattern{rules.NewPattern("小于五", 12)}
+	}
+	return nil
+}
+
+// All Simples (断幺) : 2
+func allSimples(hand *winningHand) []*rules.Pattern {
+	for _, tile := range hand.getAllTiles() {
+		if isTerminalOrHonor(tile) {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("断幺", 2)}
+}
+
+// All Even Pungs (全双刻) : 24
+func allEvenPungs(hand *winningHand) []*rules.Pattern {
+	if !hand.isStandard() || len(hand.getPungs()) != 4 {
+		return nil
+	}
+	for _, tile := range hand.getAllTiles() {
+		// The ordinals are 0-based, i.e. odd ordinals are even tiles.
+		if isHonor(tile) || tile.GetOrdinal()%2 == 0 {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("全双刻", 24)}
+}
+
+// All Fives (全带五) : 16
+func allFives(hand *winningHand) []*rules.Pattern {
+	if !hand.isStandard() || !allGroupsContain(hand, isFive) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("全带五", 16)}
+}
+
+// Outside Hand (全带幺) : 4
+func outsideHand(hand *winningHand) []*rules.Pattern {
+	if !hand.isStandard() || !allGroupsContain(hand, isTerminalOrHonor) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("全带幺", 4)}
+}
+
+// allGroupsContain returns whether each group of the hand contains a tile that matches the given
+// function.
+func allGroupsContain(hand *winningHand, matches func(*domain.Tile) bool) bool {
+	for _, group := range hand.getAllGroups() {
+		found := false
+		for _, tile := range group.GetTiles() {
+			found = found || matches(tile)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isFive(tile *domain.Tile) bool {
+	return !isHonor(tile) && tile.GetOrdinal() == 4
+}
+
+// Tile Hog (四归一) : 2 for each four of a kind that is not a kong
+func tileHogs(hand *winningHand) []*rules.Pattern {
+	counts := make(map[domain.TileBase]int)
+	for _, group := range hand.getAllGroups() {
+		if group.GetGroupType() == rules.TileGroupTypeKong ||
+			group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+			continue
+		}
+		for _, tile := range group.GetTiles() {
+			counts[tile.TileBase]++
+		}
+	}
+	var patterns []*rules.Pattern
+	for _, count := range counts {
+		if count == 4 {
+			patterns = append(patterns, rules.NewPattern("四归一", 2))
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,185 @@
+package mcr
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// winningHand is an Out plan together with the hand group completed by the out tile. The same plan
+// may be read with different winning groups, which affects the wait, and whether a pong completed
+// by a discard is concealed.
+type winningHand struct {
+	plan    rules.OutPlan
+	context *rules.OutPlanScoringContext
+	// winningGroup is the index of the hand group completed by the out tile, or -1 if there is
+	// none.
+	winningGroup int
+	// numWaits is the number of kinds of tiles that would have completed the hand.
+	numWaits int
+}
+
+// pung is a pong or kong of a winning hand.
+type pung struct {
+	group     *rules.TileGroup
+	concealed bool
+}
+
+// newWinningHands returns a winningHand for each hand group of the given plan that may have been
+// completed by the out tile.
+func newWinningHands(plan rules.OutPlan, context *rules.OutPlanScoringContext,
+	numWaits int) []*winningHand {
+	var hands []*winningHand
+	outTile := context.OutTileSource.Tile
+	if outTile != nil {
+		for i, group := range plan.GetHandGroups() {
+			if !containsTile(group.GetTiles(), outTile) {
+				continue
+			}
+			hands = append(hands, &winningHand{
+				plan:         plan,
+				context:      context,
+				winningGroup: i,
+				numWaits:     numWaits,
+			})
+		}
+	}
+	if len(hands) == 0 {
+		hands = append(hands, &winningHand{
+			plan:         plan,
+			context:      context,
+			winningGroup: -1,
+			numWaits:     numWaits,
+		})
+	}
+	return hands
+}
+
+// getAllGroups returns the hand groups followed by the melded groups.
+func (h *winningHand) getAllGroups() rules.TileGroups {
+	var groups rules.TileGroups
+	groups = append(groups, h.plan.GetHandGroups()...)
+	return append(groups, h.plan.GetMeldedGroups()...)
+}
+
+// getAllTiles returns the tiles of all groups.
+func (h *winningHand) getAllTiles() domain.Tiles {
+	var tiles domain.Tiles
+	for _, group := range h.getAllGroups() {
+		tiles = append(tiles, group.GetTiles()...)
+	}
+	return tiles
+}
+
+// getChows returns the hand and melded chows.
+func (h *winningHand) getChows() rules.TileGroups {
+	var chows rules.TileGroups
+	for _, group := range h.getAllGroups() {
+		if group.GetGroupType() == rules.TileGroupTypeChow {
+			chows = append(chows, group)
+		}
+	}
+	return chows
+}
+
+// getPungs returns the pongs and kongs of the hand. A pong in the hand is concealed unless it is
+// completed by a discard.
+func (h *winningHand) getPungs() []pung {
+	var pungs []pung
+	for i, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == rules.TileGroupTypePong {
+			pungs = append(pungs,
+				pung{group: group, concealed: h.isSelfDrawn() || i != h.winningGroup})
+		}
+	}
+	for _, group := range h.plan.GetMeldedGroups() {
+		switch group.GetGroupType() {
+		case rules.TileGroupTypePong, rules.TileGroupTypeKong:
+			pungs = append(pungs, pung{group: group})
+		case rules.TileGroupTypeConcealedKong:
+			pungs = append(pungs, pung{group: group, concealed: true})
+		}
+	}
+	return pungs
+}
+
+// getPair returns the pair of a standard hand, or nil if there is none.
+func (h *winningHand) getPair() *rules.TileGroup {
+	for _, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == rules.TileGroupTypePair {
+			return group
+		}
+	}
+	return nil
+}
+
+// getSpecialGroup returns the group of a special hand of the given type, or nil if the hand is not
+// one.
+func (h *winningHand) getSpecialGroup(groupType rules.TileGroupType) *rules.TileGroup {
+	for _, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == groupType {
+			return group
+		}
+	}
+	return nil
+}
+
+// isStandard returns whether the hand consists of four sets and a pair.
+func (h *winningHand) isStandard() bool {
+	return h.getPair() != nil && h.getSpecialGroup(rules.TileGroupTypeKnittedStraight) == nil
+}
+
+// getWinningGroup returns the hand group completed by the out tile, or nil if there is none.
+func (h *winningHand) getWinningGroup() *rules.TileGroup {
+	if h.winningGroup < 0 {
+		return nil
+	}
+	return h.plan.GetHandGroups()[h.winningGroup]
+}
+
+// isConcealed returns whether no tile is melded from another player.
+func (h *winningHand) isConcealed() bool {
+	for _, group := range h.plan.GetMeldedGroups() {
+		if group.GetGroupType() != rules.TileGroupTypeConcealedKong {
+			return false
+		}
+	}
+	return true
+}
+
+// isSelfDrawn returns whether the out tile is drawn by the player.
+func (h *winningHand) isSelfDrawn() bool {
+	sourceType := h.context.OutTileSource.SourceType
+	return rules.IsSelfDrawnType(sourceType) || sourceType == rules.OutTileSourceTypeInitialHand
+}
+
+// isSeatWind returns whether the given tile is the wind of the player.
+func (h *winningHand) isSeatWind(tile *domain.Tile) bool {
+	return tile.GetSuit() == rules.Winds &&
+		tile.GetOrdinal() == h.context.PlayerGameState.GetWindOrdinal()
+}
+
+// isPrevailingWind returns whether the given tile is the wind of the round.
+func (h *winningHand) isPrevailingWind(tile *domain.Tile) bool {
+	return tile.GetSuit() == rules.Winds && tile.GetOrdinal() == h.context.PrevailingWindOrdinal
+}
+
+// isHonor returns whether the given tile is an honor tile.
+func isHonor(tile *domain.Tile) bool {
+	return tile.GetSuit().GetSuitType() == domain.SuitTypeHonor
+}
+
+// isTerminalOrHonor returns whether the given tile is a terminal or an honor tile.
+func isTerminalOrHonor(tile *domain.Tile) bool {
+	return isHonor(tile) || tile.IsTerminal()
+}
+
+// containsTile returns whether the given tiles contain a tile with the same suit and ordinal as the
+// given tile.
+func containsTile(tiles domain.Tiles, tile *domain.Tile) bool {
+	for _, t := range tiles {
+		if domain.CompareTiles(t, tile) == 0 {
+			return true
+		}
+	}
+	return false
+}
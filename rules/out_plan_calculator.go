@@ -1,53 +1,92 @@
 package rules
 
 import (
-	"fmt"
 	"github.com/derekimcheng/mj/domain"
 	"github.com/golang/glog"
 	"sort"
 )
 
-type specialPlanMatcherFunc func(numRemainingTiles int, inventory *tileInventory) TileGroups
+const (
+	// numSevenPairs is the number of pairs of the "Seven Pairs" hand.
+	numSevenPairs = 7
+	// numHonorsAndKnittedTiles is the number of distinct tiles of the "Honors and Knitted Tiles"
+	// hand.
+	numHonorsAndKnittedTiles = 14
+)
+
+// specialPlanMatcherFunc returns the groups of a special hand if the remaining tiles in the
+// inventory form one, or nil otherwise. numTilesPerOut is the number of tiles of an Out hand
+// without melds.
+type specialPlanMatcherFunc func(numTilesPerOut, numRemainingTiles int,
+	inventory *tileInventory) TileGroups
 
 var (
-	specialPlanMatchers = []specialPlanMatcherFunc{
-		matchSevenPairs,
-		matchThirteenOrphans,
+	// specialPlanMatchers is a map from special hand type to its matcher.
+	specialPlanMatchers = map[TileGroupType]specialPlanMatcherFunc{
+		TileGroupTypeSevenPairs:       matchSevenPairs,
+		TileGroupTypeThirteenOrphans:  matchThirteenOrphans,
+		TileGroupTypeHonorsAndKnitted: matchHonorsAndKnitted,
+		TileGroupTypeKnittedStraight:  matchKnittedStraight,
+	}
+
+	// defaultOutPlanCalculatorOptions recognizes Seven Pairs and Thirteen Orphans.
+	defaultOutPlanCalculatorOptions = OutPlanCalculatorOptions{
+		NumTilesPerHand: standardNumTilesPerHand,
+		SpecialHands:    []TileGroupType{TileGroupTypeSevenPairs, TileGroupTypeThirteenOrphans},
 	}
 )
 
+// OutPlanCalculatorOptions contains rule-dependent options for OutPlanCalculator.
+type OutPlanCalculatorOptions struct {
+	// NumTilesPerHand is the number of tiles dealt to each player. An Out hand has one more tile.
+	NumTilesPerHand int
+	// SpecialHands is the list of special hands that are recognized as an Out, e.g.
+	// TileGroupTypeSevenPairs. Special hand types without a matcher are ignored.
+	SpecialHands []TileGroupType
+}
+
 // OutPlanCalculator calculates Out plans for a given state.
 type OutPlanCalculator struct {
 	handInventory    tileInventory
 	meldedGroups     TileGroups
+	options          OutPlanCalculatorOptions
 	computedOutPlans *OutPlans
 }
 
-// NewOutPlanCalculator creates a new OutPlanCalculator with the given state.
+// NewOutPlanCalculator creates a new OutPlanCalculator with the given state, for a game of 13
+// tiles per hand. Seven Pairs and Thirteen Orphans are recognized.
 func NewOutPlanCalculator(suits []*domain.Suit, player *PlayerGameState,
 	outTileSource *OutTileSource) *OutPlanCalculator {
-	inventory := make(tileInventory)
-	for _, s := range suits {
-		inventory[s] = make([][]*domain.Tile, s.GetSize())
-	}
-	allTiles := player.GetHand().GetTiles()
+	return NewOutPlanCalculatorWithOptions(suits, player, outTileSource,
+		defaultOutPlanCalculatorOptions)
+}
+
+// NewOutPlanCalculatorForRuleSet creates a new OutPlanCalculator with the given state, using the
+// suits and options of the given RuleSet.
+func NewOutPlanCalculatorForRuleSet(ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource) *OutPlanCalculator {
+	return NewOutPlanCalculatorWithOptions(ruleSet.GetSuits(), player, outTileSource,
+		ruleSet.GetOutPlanCalculatorOptions())
+}
+
+// NewOutPlanCalculatorWithOptions creates a new OutPlanCalculator with the given state and options.
+func NewOutPlanCalculatorWithOptions(suits []*domain.Suit, player *PlayerGameState,
+	outTileSource *OutTileSource, options OutPlanCalculatorOptions) *OutPlanCalculator {
+	var allTiles domain.Tiles
+	allTiles = append(allTiles, player.GetHand().GetTiles()...)
 	if IsExternalOutSourceType(outTileSource.SourceType) {
 		allTiles = append(allTiles, outTileSource.Tile)
 	}
-	for _, t := range allTiles {
-		if !IsEligibleForHand(t.GetSuit()) {
-			panic(fmt.Errorf("Hand should not contain ineligible tiles when counting, got %s", t))
-		}
-		tiles := inventory[t.GetSuit()][t.GetOrdinal()]
-		inventory[t.GetSuit()][t.GetOrdinal()] = append(tiles, t)
-	}
+	inventory := newTileInventory(suits, allTiles)
 
-	meldedGroupsCopy := append(player.GetMeldGroups())
+	var meldedGroupsCopy TileGroups
+	meldedGroupsCopy = append(meldedGroupsCopy, player.GetMeldGroups()...)
 	sort.Sort(meldedGroupsCopy)
 
 	return &OutPlanCalculator{
 		handInventory:    inventory,
 		meldedGroups:     meldedGroupsCopy,
+		options:          options,
 		computedOutPlans: nil}
 }
 
@@ -81,8 +120,13 @@ func (c *OutPlanCalculator) computeSpecialPlans(
 	numRemainingTiles int,
 	inventory *tileInventory,
 	outPlansSoFar *OutPlans) {
-	for _, matcher := range specialPlanMatchers {
-		groups := matcher(numRemainingTiles, inventory)
+	for _, specialHand := range c.options.SpecialHands {
+		matcher, found := specialPlanMatchers[specialHand]
+		if !found {
+			glog.V(2).Infof("No matcher for special hand %s\n", specialHand)
+			continue
+		}
+		groups := matcher(c.options.NumTilesPerHand+1, numRemainingTiles, inventory)
 		if groups != nil {
 			*outPlansSoFar = append(*outPlansSoFar, c.generateNewOutPlan(groups))
 		}
@@ -91,8 +135,8 @@ func (c *OutPlanCalculator) computeSpecialPlans(
 
 // IsSevenPairs returns an OutPlan if the hand represents the "Seven Pairs" Out hand. Note that
 // four of a kind is considered as two pairs.
-func matchSevenPairs(numRemainingTiles int, inventory *tileInventory) TileGroups {
-	if numRemainingTiles != 14 {
+func matchSevenPairs(numTilesPerOut, numRemainingTiles int, inventory *tileInventory) TileGroups {
+	if numRemainingTiles != numTilesPerOut || numTilesPerOut != 2*numSevenPairs {
 		return nil
 	}
 
@@ -107,7 +151,7 @@ func matchSevenPairs(numRemainingTiles int, inventory *tileInventory) TileGroups
 			outTiles = append(outTiles, tiles...)
 		}
 	}
-	if numPairs != 7 {
+	if numPairs != numSevenPairs {
 		return nil
 	}
 
@@ -115,8 +159,9 @@ func matchSevenPairs(numRemainingTiles int, inventory *tileInventory) TileGroups
 }
 
 // matchThirteenOrphans returns an OutPlan if the hand represents the "Thirteen Orphans" Out hand.
-func matchThirteenOrphans(numRemainingTiles int, inventory *tileInventory) TileGroups {
-	if numRemainingTiles != 14 {
+func matchThirteenOrphans(numTilesPerOut, numRemainingTiles int,
+	inventory *tileInventory) TileGroups {
+	if numRemainingTiles != numTilesPerOut || numTilesPerOut != len(thirteenOrphanTiles)+1 {
 		return nil
 	}
 
@@ -140,6 +185,126 @@ func matchThirteenOrphans(numRemainingTiles int, inventory *tileInventory) TileG
 	return TileGroups{NewTileGroup(outTiles, TileGroupTypeThirteenOrphans)}
 }
 
+// matchHonorsAndKnitted returns an OutPlan if the hand represents the "Honors and Knitted Tiles"
+// Out hand, i.e. 14 distinct tiles that are honors or in the same knitted sequence.
+func matchHonorsAndKnitted(numTilesPerOut, numRemainingTiles int,
+	inventory *tileInventory) TileGroups {
+	if numRemainingTiles != numTilesPerOut || numTilesPerOut != numHonorsAndKnittedTiles {
+		return nil
+	}
+
+	for _, suitOrder := range knittedSuitOrders {
+		var outTiles domain.Tiles
+		matched := true
+		for _, suit := range suits {
+			for ordinal, tiles := range (*inventory)[suit] {
+				if len(tiles) == 0 {
+					continue
+				}
+				if len(tiles) > 1 || (CanChow(suit) && !isKnittedTile(suitOrder, suit, ordinal)) {
+					matched = false
+					break
+				}
+				outTiles = append(outTiles, tiles[0])
+			}
+		}
+		if matched {
+			return TileGroups{NewTileGroup(outTiles, TileGroupTypeHonorsAndKnitted)}
+		}
+	}
+	return nil
+}
+
+// matchKnittedStraight returns an OutPlan if the hand represents the "Knitted Straight" Out hand,
+// i.e. the 9 tiles of a knitted sequence, and a set and a pair. The set may be melded.
+func matchKnittedStraight(numTilesPerOut, numRemainingTiles int,
+	inventory *tileInventory) TileGroups {
+	// The set may be melded.
+	if numRemainingTiles != numTilesPerOut && numRemainingTiles != numTilesPerOut-3 {
+		return nil
+	}
+
+	for _, suitOrder := range knittedSuitOrders {
+		var knittedTiles domain.Tiles
+		for ordinal := 0; ordinal < 9; ordinal++ {
+			tiles := (*inventory)[suitOrder[ordinal%3]][ordinal]
+			if len(tiles) == 0 {
+				break
+			}
+			knittedTiles = append(knittedTiles, tiles[0])
+		}
+		if len(knittedTiles) != 9 {
+			continue
+		}
+
+		// Take out the knitted tiles from the inventory, and match the rest of the hand.
+		for ordinal := 0; ordinal < 9; ordinal++ {
+			suitTiles := (*inventory)[suitOrder[ordinal%3]]
+			suitTiles[ordinal] = suitTiles[ordinal][1:]
+		}
+		groups := matchSetAndPair(numRemainingTiles-9, inventory)
+		// Restore previous state.
+		for ordinal, tile := range knittedTiles {
+			suitTiles := (*inventory)[suitOrder[ordinal%3]]
+			suitTiles[ordinal] = append(domain.Tiles{tile}, suitTiles[ordinal]...)
+		}
+
+		if groups != nil {
+			return append(TileGroups{NewTileGroup(knittedTiles, TileGroupTypeKnittedStraight)},
+				groups...)
+		}
+	}
+	return nil
+}
+
+// matchSetAndPair returns the groups if the given number of tiles in the inventory form a pair,
+// or a set and a pair. It returns nil otherwise.
+func matchSetAndPair(numRemainingTiles int, inventory *tileInventory) TileGroups {
+	var tiles domain.Tiles
+	for _, suit := range suits {
+		for _, suitTiles := range (*inventory)[suit] {
+			tiles = append(tiles, suitTiles...)
+		}
+	}
+	if len(tiles) != numRemainingTiles || (len(tiles) != 2 && len(tiles) != 5) {
+		return nil
+	}
+
+	for i := 0; i+1 < len(tiles); i++ {
+		if domain.CompareTiles(tiles[i], tiles[i+1]) != 0 {
+			continue
+		}
+		pair := NewTileGroup(domain.Tiles{tiles[i], tiles[i+1]}, TileGroupTypePair)
+		if len(tiles) == 2 {
+			return TileGroups{pair}
+		}
+		var setTiles domain.Tiles
+		setTiles = append(setTiles, tiles[:i]...)
+		setTiles = append(setTiles, tiles[i+2:]...)
+		if setType, ok := getSetType(setTiles); ok {
+			return TileGroups{NewTileGroup(setTiles, setType), pair}
+		}
+	}
+	return nil
+}
+
+// getSetType returns whether the given 3 tiles in suit and ordinal order form a pong or a chow,
+// and the type of the set.
+func getSetType(tiles domain.Tiles) (TileGroupType, bool) {
+	first, second, third := tiles[0], tiles[1], tiles[2]
+	if first.GetSuit() != second.GetSuit() || first.GetSuit() != third.GetSuit() {
+		return TileGroupTypePong, false
+	}
+	if first.GetOrdinal() == second.GetOrdinal() && first.GetOrdinal() == third.GetOrdinal() {
+		return TileGroupTypePong, true
+	}
+	if CanChow(first.GetSuit()) && second.GetOrdinal() == first.GetOrdinal()+1 &&
+		third.GetOrdinal() == second.GetOrdinal()+1 {
+		return TileGroupTypeChow, true
+	}
+	return TileGroupTypePong, false
+}
+
 func (c *OutPlanCalculator) computeOutPlansHelper(
 	numRemainingTiles int,
 	inventory *tileInventory,
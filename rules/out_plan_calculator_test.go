@@ -437,3 +437,96 @@ func Test_ComputeOutPlans_PairAndAChow(t *testing.T) {
 
 	assert.Equal(t, expected, plans)
 }
+
+func Test_ComputeOutPlans_HonorsAndKnitted(t *testing.T) {
+	tiles := concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 3, 6),
+		createSuitTilesForTest(t, Bamboo, 1, 4),
+		createSuitTilesForTest(t, Characters, 2, 5, 8),
+		createSuitTilesForTest(t, Winds, 0, 1, 2),
+		createSuitTilesForTest(t, Dragons, 0, 1, 2))
+	player := createPlayerForTest(tiles, nil)
+	options := OutPlanCalculatorOptions{
+		NumTilesPerHand: standardNumTilesPerHand,
+		SpecialHands:    []TileGroupType{TileGroupTypeHonorsAndKnitted},
+	}
+
+	plans := NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Len(t, plans, 1)
+	assert.Len(t, plans[0].GetHandGroups(), 1)
+	assert.Equal(t, TileGroupTypeHonorsAndKnitted, plans[0].GetHandGroups()[0].GetGroupType())
+	assert.Len(t, plans[0].GetHandGroups()[0].GetTiles(), 14)
+
+	// Tiles of two knitted sequences in the same suit do not match.
+	tiles[0] = domain.CreateTileForTest(t, Dots, 1)
+	player = createPlayerForTest(tiles, nil)
+	plans = NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Empty(t, plans)
+}
+
+func Test_ComputeOutPlans_KnittedStraight(t *testing.T) {
+	knittedTiles := concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 3, 6),
+		createSuitTilesForTest(t, Bamboo, 1, 4, 7),
+		createSuitTilesForTest(t, Characters, 2, 5, 8))
+	options := OutPlanCalculatorOptions{
+		NumTilesPerHand: standardNumTilesPerHand,
+		SpecialHands:    []TileGroupType{TileGroupTypeKnittedStraight},
+	}
+
+	// The rest of the hand must be a pair with a melded set.
+	tiles := concatTiles(knittedTiles, createSuitTilesForTest(t, Winds, 3, 1))
+	player := createPlayerForTest(tiles, TileGroups{
+		NewTileGroup(createSuitTilesForTest(t, Dragons, 0, 0, 0), TileGroupTypePong),
+	})
+	plans := NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Empty(t, plans)
+
+	tiles = concatTiles(knittedTiles, createSuitTilesForTest(t, Dots, 3, 4, 5),
+		createSuitTilesForTest(t, Winds, 3, 3))
+	player = createPlayerForTest(tiles, nil)
+	plans = NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Len(t, plans, 1)
+	groups := plans[0].GetHandGroups()
+	assert.Len(t, groups, 3)
+	assert.Equal(t, TileGroupTypeKnittedStraight, groups[0].GetGroupType())
+	assert.Equal(t, TileGroupTypeChow, groups[1].GetGroupType())
+	assert.Equal(t, createSuitTilesForTest(t, Dots, 3, 4, 5), groups[1].GetTiles())
+	assert.Equal(t, TileGroupTypePair, groups[2].GetGroupType())
+}
+
+func Test_ComputeOutPlans_SixteenTileHand(t *testing.T) {
+	options := OutPlanCalculatorOptions{
+		NumTilesPerHand: 16,
+		SpecialHands:    []TileGroupType{TileGroupTypeSevenPairs, TileGroupTypeThirteenOrphans},
+	}
+
+	// Five sets and a pair.
+	tiles := concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 4, 4, 4),
+		createSuitTilesForTest(t, Bamboo, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Characters, 7, 7),
+		createSuitTilesForTest(t, Winds, 2, 2, 2))
+	player := createPlayerForTest(tiles, nil)
+	plans := NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Len(t, plans, 1)
+	assert.Len(t, plans[0].GetHandGroups(), 6)
+
+	// Seven pairs and a melded set is not an Out in a game of 16 tiles per hand.
+	tiles = concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 0, 4, 4, 8, 8),
+		createSuitTilesForTest(t, Bamboo, 2, 2),
+		createSuitTilesForTest(t, Winds, 0, 0, 1, 1),
+		createSuitTilesForTest(t, Dragons, 0, 0))
+	player = createPlayerForTest(tiles, TileGroups{
+		NewTileGroup(createSuitTilesForTest(t, Dragons, 2, 2, 2), TileGroupTypePong),
+	})
+	plans = NewOutPlanCalculatorWithOptions(GetSuitsForGame(), player,
+		createOutTileSourceForTest(tiles[0]), options).Calculate()
+	assert.Empty(t, plans)
+}
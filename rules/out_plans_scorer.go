@@ -98,15 +98,18 @@ type OutPlanScoringContext struct {
 	OutTileSource           *OutTileSource
 	PlayerGameState         *PlayerGameState
 	NumRemainingTilesInDeck int
+	// PrevailingWindOrdinal is the wind of the round, as opposed to the seat wind of the player.
+	PrevailingWindOrdinal int
 }
 
 // NewOutPlanScoringContext ...
-func NewOutPlanScoringContext(outTileSource *OutTileSource,
-	playerGameState *PlayerGameState, numRemainingTilesInDeck int) *OutPlanScoringContext {
+func NewOutPlanScoringContext(outTileSource *OutTileSource, playerGameState *PlayerGameState,
+	numRemainingTilesInDeck int, prevailingWindOrdinal int) *OutPlanScoringContext {
 	return &OutPlanScoringContext{
 		OutTileSource:           outTileSource,
 		PlayerGameState:         playerGameState,
 		NumRemainingTilesInDeck: numRemainingTilesInDeck,
+		PrevailingWindOrdinal:   prevailingWindOrdinal,
 	}
 }
 
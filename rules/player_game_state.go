@@ -15,6 +15,8 @@ type PlayerGameState struct {
 	discardedTiles domain.Tiles
 	meldGroups     TileGroups
 	windOrdinal    int
+	// riichiDeclared is set once the player declares riichi in Riichi MJ.
+	riichiDeclared bool
 }
 
 // NewPlayerGameState creates a blank PlayerGameState object.
@@ -32,21 +34,56 @@ func NewPlayerGameState(hand *domain.Hand, windOrdinal int) *PlayerGameState {
 }
 
 // NewExistingPlayerGameState creates a PlayerGameState object with existing states. Used in
-// analyzer only. No validation is performed on the input.
-func NewExistingPlayerGameState(hand *domain.Hand, windOrdinal int, discardedTiles domain.Tiles,
-	meldGroups TileGroups) *PlayerGameState {
+// analyzer only. No validation is performed on the input; see ValidatePlayerGameState.
+func NewExistingPlayerGameState(hand *domain.Hand, windOrdinal int, bonusTiles domain.Tiles,
+	discardedTiles domain.Tiles, meldGroups TileGroups) *PlayerGameState {
 	if hand == nil {
 		panic(errors.New("Given hand cannot be nil"))
 	}
 	return &PlayerGameState{
 		hand:           hand,
-		bonusTiles:     nil,
+		bonusTiles:     bonusTiles,
 		discardedTiles: discardedTiles,
 		meldGroups:     meldGroups,
 		windOrdinal:    windOrdinal,
 	}
 }
 
+// copyWithHandTiles returns a shallow copy of the state with a new hand consisting of the given
+// tiles. The remaining areas are shared with the original state and must not be modified.
+func (s *PlayerGameState) copyWithHandTiles(tiles domain.Tiles) *PlayerGameState {
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	stateCopy := *s
+	stateCopy.hand = hand
+	return &stateCopy
+}
+
+// Copy returns a copy of the state that can be modified without affecting the original state,
+// e.g. to evaluate a meld before declaring it. The tiles themselves are shared.
+func (s *PlayerGameState) Copy() *PlayerGameState {
+	hand := domain.NewHand()
+	hand.SetTiles(append(domain.Tiles{}, s.hand.GetTiles()...))
+	var meldGroups TileGroups
+	for _, group := range s.meldGroups {
+		meldGroups = append(meldGroups,
+			NewTileGroup(append(domain.Tiles{}, group.GetTiles()...), group.GetGroupType()))
+	}
+	stateCopy := NewExistingPlayerGameState(hand, s.windOrdinal,
+		append(domain.Tiles{}, s.bonusTiles...), append(domain.Tiles{}, s.discardedTiles...),
+		meldGroups)
+	stateCopy.riichiDeclared = s.riichiDeclared
+	return stateCopy
+}
+
+// PublicCopy returns a copy of the state with an empty hand, i.e. the information that is visible
+// to other players.
+func (s *PlayerGameState) PublicCopy() *PlayerGameState {
+	stateCopy := s.Copy()
+	stateCopy.hand = domain.NewHand()
+	return stateCopy
+}
+
 // SortHand sorts the tiles in the player's hand.
 func (s *PlayerGameState) SortHand() {
 	s.hand.Sort()
@@ -103,6 +140,111 @@ func (s *PlayerGameState) DiscardTileAt(index int) (*domain.Tile, bool) {
 	return t, true
 }
 
+// RemoveTileFromHandAt removes the tile at the given index of the player's hand without moving it
+// into the discard area, e.g. while other players may still claim it. Returns whether the
+// operation was successful, and if so, also returns the removed tile.
+func (s *PlayerGameState) RemoveTileFromHandAt(index int) (*domain.Tile, bool) {
+	t, err := s.hand.RemoveTile(index)
+	if err != nil {
+		glog.V(2).Infof("Failed to remove tile at %d: %s\n", index, err)
+		return nil, false
+	}
+	return t, true
+}
+
+// AddTileToDiscardArea adds the given tile, which is no longer in the player's hand, to the
+// discard area.
+func (s *PlayerGameState) AddTileToDiscardArea(t *domain.Tile) {
+	s.discardedTiles = append(s.discardedTiles, t)
+}
+
+// CanDeclarePong returns whether the player can declare a pong using the given tile.
+func (s *PlayerGameState) CanDeclarePong(t *domain.Tile) bool {
+	return CanPong(t.GetSuit()) && s.countSimilarTilesInHand(t) >= 2
+}
+
+// CanDeclareKong returns whether the player can declare a kong using the given tile.
+func (s *PlayerGameState) CanDeclareKong(t *domain.Tile) bool {
+	return CanPong(t.GetSuit()) && s.countSimilarTilesInHand(t) >= 3
+}
+
+// CanDeclareChow returns whether the player can declare a chow using the given tile and the tiles
+// at the given indices.
+func (s *PlayerGameState) CanDeclareChow(t *domain.Tile, index1, index2 int) bool {
+	_, ok := s.getChowTiles(t, index1, index2)
+	return ok
+}
+
+// FindChowIndices returns the pairs of hand indices that can be used to declare a chow with the
+// given tile. Only one pair is returned for each distinct chow.
+func (s *PlayerGameState) FindChowIndices(t *domain.Tile) [][2]int {
+	var indices [][2]int
+	seen := make(map[[3]domain.TileBase]bool)
+	if !CanChow(t.GetSuit()) {
+		return nil
+	}
+	tiles := s.hand.GetTiles()
+	isCandidate := func(tile *domain.Tile) bool {
+		diff := tile.GetOrdinal() - t.GetOrdinal()
+		return tile.GetSuit() == t.GetSuit() && diff != 0 && diff >= -2 && diff <= 2
+	}
+	for index1 := range tiles {
+		if !isCandidate(tiles[index1]) {
+			continue
+		}
+		for index2 := index1 + 1; index2 < len(tiles); index2++ {
+			if !isCandidate(tiles[index2]) {
+				continue
+			}
+			chowTiles, ok := s.getChowTiles(t, index1, index2)
+			if !ok {
+				continue
+			}
+			key := [3]domain.TileBase{
+				chowTiles[0].TileBase, chowTiles[1].TileBase, chowTiles[2].TileBase}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			indices = append(indices, [2]int{index1, index2})
+		}
+	}
+	return indices
+}
+
+// FindConcealedKongIndices returns the index of one tile in the hand for each concealed kong that
+// can be declared.
+func (s *PlayerGameState) FindConcealedKongIndices() []int {
+	var indices []int
+	seen := make(map[domain.TileBase]bool)
+	for index, tile := range s.hand.GetTiles() {
+		if seen[tile.TileBase] || !CanPong(tile.GetSuit()) {
+			continue
+		}
+		seen[tile.TileBase] = true
+		if s.countSimilarTilesInHand(tile) == 4 {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// FindAdditionalKongIndices returns the indices of the tiles in the hand that can be used to
+// declare an additional kong.
+func (s *PlayerGameState) FindAdditionalKongIndices() []int {
+	var indices []int
+	for index, tile := range s.hand.GetTiles() {
+		for _, group := range s.meldGroups {
+			if group.GetGroupType() == TileGroupTypePong &&
+				domain.CompareTiles(group.GetTiles()[0], tile) == 0 {
+				indices = append(indices, index)
+				break
+			}
+		}
+	}
+	return indices
+}
+
 // DeclarePong declares a pong using the given tile, which is being discarded. The tiles
 // are moved to the meld area. Returns whether the operation was successful.
 func (s *PlayerGameState) DeclarePong(t *domain.Tile) bool {
@@ -209,6 +351,30 @@ func (s *PlayerGameState) DeclareAdditionalKong(index int) (*domain.Tile, bool)
 // tiles at the given indices. The tiles are moved to the meld area. Returns whether if the
 // operation was successful, and if so, also returns the tiles used in the chow.
 func (s *PlayerGameState) DeclareChow(t *domain.Tile, index1, index2 int) (domain.Tiles, bool) {
+	chowTiles, ok := s.getChowTiles(t, index1, index2)
+	if !ok {
+		return nil, false
+	}
+
+	// Swap indices for convenience.
+	if index1 > index2 {
+		index1, index2 = index2, index1
+	}
+
+	// Remove the tiles from hand.
+	tiles := s.hand.GetTiles()
+	updatedTiles := append(tiles[:index1], tiles[index1+1:index2]...)
+	updatedTiles = append(updatedTiles, tiles[index2+1:]...)
+	s.hand.SetTiles(updatedTiles)
+
+	// Add the tiles to a meld group.
+	s.meldGroups = append(s.meldGroups, NewTileGroup(chowTiles, TileGroupTypeChow))
+	return chowTiles, true
+}
+
+// getChowTiles returns the sorted tiles of the chow formed by the given tile and the tiles at the
+// given indices, and whether they form a chow.
+func (s *PlayerGameState) getChowTiles(t *domain.Tile, index1, index2 int) (domain.Tiles, bool) {
 	if !CanChow(t.GetSuit()) {
 		glog.V(2).Infof("Tile %s cannot be used in a chow\n", t)
 		return nil, false
@@ -218,11 +384,6 @@ func (s *PlayerGameState) DeclareChow(t *domain.Tile, index1, index2 int) (domai
 		return nil, false
 	}
 
-	// Swap indices for convenience.
-	if index1 > index2 {
-		index1, index2 = index2, index1
-	}
-
 	tile1, err := s.hand.GetTileAt(index1)
 	if err != nil {
 		glog.V(2).Infof("Failed to get tile at %d: %s\n", index1, err)
@@ -246,16 +407,99 @@ func (s *PlayerGameState) DeclareChow(t *domain.Tile, index1, index2 int) (domai
 		glog.V(2).Infof("Not a chow - tiles are not consecutive: %s\n", chowTiles)
 		return nil, false
 	}
+	return chowTiles, true
+}
+
+// IsConcealed returns whether the player has not melded any tile from another player, i.e. the
+// melded area only contains concealed kongs.
+func (s *PlayerGameState) IsConcealed() bool {
+	for _, group := range s.meldGroups {
+		if group.GetGroupType() != TileGroupTypeConcealedKong {
+			return false
+		}
+	}
+	return true
+}
 
-	// Remove the tiles from hand.
+// CanDeclareRiichi returns whether the player may declare riichi along with the discard of the
+// tile at the given index, i.e. whether the hand is concealed and stays ready without that tile.
+func (s *PlayerGameState) CanDeclareRiichi(ruleSet RuleSet, discardIndex int) bool {
 	tiles := s.hand.GetTiles()
-	updatedTiles := append(tiles[:index1], tiles[index1+1:index2]...)
-	updatedTiles = append(updatedTiles, tiles[index2+1:]...)
-	s.hand.SetTiles(updatedTiles)
+	if discardIndex < 0 || discardIndex >= len(tiles) {
+		glog.V(2).Infof("Invalid index for riichi discard: %d\n", discardIndex)
+		return false
+	}
+	var remainingTiles domain.Tiles
+	remainingTiles = append(remainingTiles, tiles[:discardIndex]...)
+	remainingTiles = append(remainingTiles, tiles[discardIndex+1:]...)
+	return s.copyWithHandTiles(remainingTiles).canDeclareRiichi(ruleSet)
+}
 
-	// Add the tiles to a meld group.
-	s.meldGroups = append(s.meldGroups, NewTileGroup(chowTiles, TileGroupTypeChow))
-	return chowTiles, true
+// DeclareRiichi declares riichi in Riichi MJ, and returns whether it is successful. Riichi can
+// only be declared once, with a concealed hand that is ready, i.e. after the accompanying discard.
+func (s *PlayerGameState) DeclareRiichi(ruleSet RuleSet) bool {
+	if !s.canDeclareRiichi(ruleSet) {
+		return false
+	}
+	s.riichiDeclared = true
+	return true
+}
+
+func (s *PlayerGameState) canDeclareRiichi(ruleSet RuleSet) bool {
+	if s.riichiDeclared || !s.IsConcealed() {
+		glog.V(2).Infof("Cannot declare riichi\n")
+		return false
+	}
+	if s.hand.NumTiles()%3 != 1 ||
+		NewShantenCalculatorForRuleSet(ruleSet, s).Calculate() != ShantenReady {
+		glog.V(2).Infof("Cannot declare riichi - hand is not ready: %s\n", s.hand)
+		return false
+	}
+	return true
+}
+
+// IsRiichiDeclared returns whether the player has declared riichi.
+func (s *PlayerGameState) IsRiichiDeclared() bool {
+	return s.riichiDeclared
+}
+
+// CanDeclareConcealedKongAfterRiichi returns whether the player may declare a concealed kong with
+// the tile at the given index after riichi, where drawnTile is the tile drawn in the current turn.
+// The kong must contain the drawn tile and must keep the waits of the hand unchanged.
+func (s *PlayerGameState) CanDeclareConcealedKongAfterRiichi(ruleSet RuleSet, index int,
+	drawnTile *domain.Tile) bool {
+	tile, err := s.hand.GetTileAt(index)
+	if err != nil || domain.CompareTiles(tile, drawnTile) != 0 {
+		glog.V(2).Infof("Concealed kong at %d does not contain the drawn tile\n", index)
+		return false
+	}
+
+	var readyTiles domain.Tiles
+	removed := false
+	for _, t := range s.hand.GetTiles() {
+		if !removed && t == drawnTile {
+			removed = true
+			continue
+		}
+		readyTiles = append(readyTiles, t)
+	}
+	kongState := s.Copy()
+	if _, declared := kongState.DeclareConcealedKong(index); !declared || !removed {
+		return false
+	}
+
+	waits := NewWaitCalculator(ruleSet, nil, s.copyWithHandTiles(readyTiles), nil, 0, 0).Calculate()
+	kongWaits := NewWaitCalculator(ruleSet, nil, kongState, nil, 0, 0).Calculate()
+	if len(waits) == 0 || len(waits) != len(kongWaits) {
+		return false
+	}
+	for i, wait := range waits {
+		if wait.Tile != kongWaits[i].Tile {
+			glog.V(2).Infof("Concealed kong at %d changes the waits\n", index)
+			return false
+		}
+	}
+	return true
 }
 
 // GetHand ...
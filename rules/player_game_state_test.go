@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_PlayerGameState_CanDeclareMelds(t *testing.T) {
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 1, 1, 1, 3, 4, 4),
+		createSuitTilesForTest(t, Winds, 0, 0)), nil)
+
+	assert.True(t, player.CanDeclarePong(domain.CreateTileForTest(t, Dots, 1)))
+	assert.True(t, player.CanDeclareKong(domain.CreateTileForTest(t, Dots, 1)))
+	assert.True(t, player.CanDeclarePong(domain.CreateTileForTest(t, Winds, 0)))
+	assert.False(t, player.CanDeclareKong(domain.CreateTileForTest(t, Winds, 0)))
+	assert.False(t, player.CanDeclarePong(domain.CreateTileForTest(t, Dots, 3)))
+
+	chowTile := domain.CreateTileForTest(t, Dots, 2)
+	assert.True(t, player.CanDeclareChow(chowTile, 0, 3))
+	assert.True(t, player.CanDeclareChow(chowTile, 3, 4))
+	assert.False(t, player.CanDeclareChow(chowTile, 0, 1))
+	assert.False(t, player.CanDeclareChow(chowTile, 6, 7))
+	assert.False(t, player.CanDeclareChow(domain.CreateTileForTest(t, Winds, 1), 6, 7))
+	// Validation does not modify the hand.
+	assert.Equal(t, 8, player.GetHand().NumTiles())
+}
+
+func Test_PlayerGameState_FindChowIndices(t *testing.T) {
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 1, 1, 3, 4),
+		createSuitTilesForTest(t, Bamboo, 1, 3)), nil)
+
+	// 1-2-3 and 2-3-4 Dots, without duplicates for the second 1 Dots.
+	assert.Equal(t, [][2]int{{0, 2}, {2, 3}},
+		player.FindChowIndices(domain.CreateTileForTest(t, Dots, 2)))
+	assert.Equal(t, [][2]int{{4, 5}},
+		player.FindChowIndices(domain.CreateTileForTest(t, Bamboo, 2)))
+	assert.Empty(t, player.FindChowIndices(domain.CreateTileForTest(t, Characters, 2)))
+}
+
+func Test_PlayerGameState_RemoveTileAndDiscard(t *testing.T) {
+	player := createPlayerForTest(createSuitTilesForTest(t, Dots, 0, 1, 2, 3), nil)
+
+	tile, removed := player.RemoveTileFromHandAt(1)
+	require.True(t, removed)
+	assert.Equal(t, 1, tile.GetOrdinal())
+	assert.Equal(t, 3, player.GetHand().NumTiles())
+	assert.Empty(t, player.GetDiscardedTiles())
+
+	player.AddTileToDiscardArea(tile)
+	assert.Equal(t, domain.Tiles{tile}, player.GetDiscardedTiles())
+
+	_, removed = player.RemoveTileFromHandAt(3)
+	assert.False(t, removed)
+}
+
+func Test_PlayerGameState_FindKongIndices(t *testing.T) {
+	meldGroups := TileGroups{NewTileGroup(createSuitTilesForTest(t, Bamboo, 4, 4, 4),
+		TileGroupTypePong)}
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 2, 2, 2, 2, 5),
+		createSuitTilesForTest(t, Bamboo, 4)), meldGroups)
+
+	assert.Equal(t, []int{0}, player.FindConcealedKongIndices())
+	assert.Equal(t, []int{5}, player.FindAdditionalKongIndices())
+}
+
+func Test_PlayerGameState_Copy(t *testing.T) {
+	meldGroups := TileGroups{NewTileGroup(createSuitTilesForTest(t, Bamboo, 4, 4, 4),
+		TileGroupTypePong)}
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 2, 2, 5),
+		createSuitTilesForTest(t, Bamboo, 4)), meldGroups)
+
+	stateCopy := player.Copy()
+	require.True(t, stateCopy.DeclarePong(domain.CreateTileForTest(t, Dots, 2)))
+	_, upgraded := stateCopy.DeclareAdditionalKong(1)
+	require.True(t, upgraded)
+	assert.Equal(t, 4, player.GetHand().NumTiles())
+	require.Len(t, player.GetMeldGroups(), 1)
+	assert.Equal(t, TileGroupTypePong, player.GetMeldGroups()[0].GetGroupType())
+
+	publicCopy := player.PublicCopy()
+	assert.Zero(t, publicCopy.GetHand().NumTiles())
+	assert.Len(t, publicCopy.GetMeldGroups(), 1)
+	assert.Equal(t, player.GetWindOrdinal(), publicCopy.GetWindOrdinal())
+}
+
+func Test_PlayerGameState_DeclareRiichi(t *testing.T) {
+	ruleSet := newRuleSetForTest("testrule", TileCountRulesRiichi)
+	// Waits on 1 or 4 Bamboo after discarding the 9 Dots at index 13.
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 1, 2, 5, 5),
+		createSuitTilesForTest(t, Dots, 8)), nil)
+
+	assert.True(t, player.CanDeclareRiichi(ruleSet, 13))
+	assert.False(t, player.CanDeclareRiichi(ruleSet, 0))
+	assert.False(t, player.CanDeclareRiichi(ruleSet, 14))
+	// The hand still contains the extra tile.
+	assert.False(t, player.DeclareRiichi(ruleSet))
+
+	_, removed := player.RemoveTileFromHandAt(13)
+	require.True(t, removed)
+	assert.True(t, player.DeclareRiichi(ruleSet))
+	assert.True(t, player.IsRiichiDeclared())
+	// Riichi can only be declared once.
+	assert.False(t, player.DeclareRiichi(ruleSet))
+}
+
+func Test_PlayerGameState_DeclareRiichiNotConcealed(t *testing.T) {
+	ruleSet := newRuleSetForTest("testrule", TileCountRulesRiichi)
+	meldGroups := TileGroups{NewTileGroup(createSuitTilesForTest(t, Bamboo, 4, 4, 4),
+		TileGroupTypePong)}
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 1)), meldGroups)
+
+	assert.False(t, player.DeclareRiichi(ruleSet))
+	assert.False(t, player.IsRiichiDeclared())
+}
+
+func Test_PlayerGameState_CanDeclareConcealedKongAfterRiichi(t *testing.T) {
+	ruleSet := newRuleSetForTest("testrule", TileCountRulesRiichi)
+	// Waits on 1 or 4 Bamboo, with the 1 Dots drawn last.
+	drawnTile := domain.CreateTileForTest(t, Dots, 0)
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 0, 0, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 1, 2, 5, 5),
+		domain.Tiles{drawnTile}), nil)
+	assert.True(t, player.CanDeclareConcealedKongAfterRiichi(ruleSet, 0, drawnTile))
+	// The 4 Dots is not the drawn tile.
+	assert.False(t, player.CanDeclareConcealedKongAfterRiichi(ruleSet, 3, drawnTile))
+
+	// Waits on 2 or 3 Dots, but only on 3 Dots after a kong of 1 Dots.
+	drawnTile = domain.CreateTileForTest(t, Dots, 0)
+	player = createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 0, 0, 2),
+		createSuitTilesForTest(t, Bamboo, 3, 4, 5, 6, 7, 8, 8, 8, 8),
+		domain.Tiles{drawnTile}), nil)
+	assert.False(t, player.CanDeclareConcealedKongAfterRiichi(ruleSet, 0, drawnTile))
+}
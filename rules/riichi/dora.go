@@ -0,0 +1,22 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Dora are not yaku: they add han only to a hand with at least one yaku. The score of each pattern
+// is its han.
+
+// Red Five (赤ドラ) : 1 for each red five
+func redFives(hand *winningHand) []*rules.Pattern {
+	numRedFives := 0
+	for _, tile := range hand.getAllTiles() {
+		if tile.IsRed() {
+			numRedFives++
+		}
+	}
+	if numRedFives == 0 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("赤ドラ", numRedFives)}
+}
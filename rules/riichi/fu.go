@@ -0,0 +1,65 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+const (
+	// baseFu is the fu every winning hand starts with.
+	baseFu = 20
+	// sevenPairsFu is the fixed fu of Seven Pairs.
+	sevenPairsFu = 25
+	// openPinfuFu is the minimum fu of an open hand won by a discard.
+	openPinfuFu = 30
+)
+
+// computeFu returns the fu (符) of the given hand, rounded up to the next 10 except for Seven
+// Pairs. Fu is awarded for winning, the triplets, the pair and the wait.
+func computeFu(hand *winningHand) int {
+	if hand.getSpecialGroup(rules.TileGroupTypeSevenPairs) != nil {
+		return sevenPairsFu
+	}
+	if isPinfu(hand) && hand.isSelfDrawn() {
+		return baseFu
+	}
+
+	fu := baseFu
+	if hand.isSelfDrawn() {
+		fu += 2
+	} else if hand.isConcealed() {
+		fu += 10
+	}
+	for _, triplet := range hand.getTriplets() {
+		fu += getTripletFu(triplet)
+	}
+	// A pair of a wind that is both the seat wind and the prevailing wind is worth 4.
+	if pair := hand.getPair(); pair != nil {
+		fu += 2 * hand.countValues(pair.GetTiles()[0])
+	}
+	switch hand.wait {
+	case waitKanchan, waitPenchan, waitTanki:
+		fu += 2
+	}
+
+	if fu == baseFu && !hand.isConcealed() {
+		return openPinfuFu
+	}
+	return (fu + 9) / 10 * 10
+}
+
+// getTripletFu returns the fu of the given triplet: 2 for an open pong of simples, doubled if it
+// is of terminals or honors, doubled if it is concealed, and quadrupled if it is a kong.
+func getTripletFu(triplet triplet) int {
+	fu := 2
+	tile := triplet.group.GetTiles()[0]
+	if isHonor(tile) || tile.IsTerminal() {
+		fu *= 2
+	}
+	if triplet.concealed {
+		fu *= 2
+	}
+	if triplet.group.GetGroupType() != rules.TileGroupTypePong {
+		fu *= 4
+	}
+	return fu
+}
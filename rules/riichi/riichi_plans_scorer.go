@@ -0,0 +1,187 @@
+package riichi
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/rules"
+	"sort"
+)
+
+const (
+	// yakumanBasicPoints are the basic points of each yakuman.
+	yakumanBasicPoints = 8000
+	// manganBasicPoints are the basic points of a mangan, which caps hands below 6 han.
+	manganBasicPoints = 2000
+)
+
+// limits are the limit hands by han, from the highest. A hand with at least the given han is worth
+// the given basic points.
+var limits = []struct {
+	name        string
+	han         int
+	basicPoints int
+}{
+	{"数え役満", 13, yakumanBasicPoints},
+	{"三倍満", 11, 6000},
+	{"倍満", 8, 4000},
+	{"跳満", 6, 3000},
+	{"満貫", 5, manganBasicPoints},
+}
+
+// OutPlansScorer is an implementation of rules.OutPlansScorer based on Riichi (Japanese) rules.
+// The score of each yaku pattern is its han (翻), and the total score of a plan is the number of
+// points the winner receives in total. Plans without any yaku are worth 0 points, as they are not
+// a valid Out. The implementation assumes each plan contains a valid combination of tiles. Any
+// invalid combination may result in incorrect scoring.
+type OutPlansScorer struct{}
+
+// NewOutPlansScorer creates a new OutPlansScorer.
+func NewOutPlansScorer() *OutPlansScorer {
+	return &OutPlansScorer{}
+}
+
+// ScoreOutPlans ... (rules.OutPlansScorer implementation)
+func (s *OutPlansScorer) ScoreOutPlans(plans rules.OutPlans,
+	context *rules.OutPlanScoringContext) rules.ScoredOutPlans {
+	var scoredPlans rules.ScoredOutPlans
+	for _, plan := range plans {
+		scoredPlans = append(scoredPlans, s.scoreOutPlan(plan, context))
+	}
+
+	sort.Sort(scoredPlans)
+	return scoredPlans
+}
+
+// scoreOutPlan scores the given plan with the winning group that is worth the most points.
+func (s *OutPlansScorer) scoreOutPlan(plan rules.OutPlan,
+	context *rules.OutPlanScoringContext) *rules.ScoredOutPlan {
+	var bestPlan *rules.ScoredOutPlan
+	for _, hand := range newWinningHands(plan, context) {
+		scoredPlan := s.scoreWinningHand(hand)
+		if bestPlan == nil || scoredPlan.TotalScore > bestPlan.TotalScore {
+			bestPlan = scoredPlan
+		}
+	}
+	return bestPlan
+}
+
+func (s *OutPlansScorer) scoreWinningHand(hand *winningHand) *rules.ScoredOutPlan {
+	if !hasDistinctPairs(hand) {
+		return rules.NewScoredOutPlan(hand.plan, 0, rules.Patterns{rules.NewPattern("役無し", 0)})
+	}
+
+	// A yakuman is worth the limit regardless of any other yaku in the plan.
+	var yakumanPatterns rules.Patterns
+	for _, matchYaku := range yakumanFuncList {
+		yakumanPatterns = append(yakumanPatterns, matchYaku(hand)...)
+	}
+	if len(yakumanPatterns) > 0 {
+		sort.Sort(yakumanPatterns)
+		points := computePoints(hand, len(yakumanPatterns)*yakumanBasicPoints)
+		return rules.NewScoredOutPlan(hand.plan, points, yakumanPatterns)
+	}
+
+	var patterns rules.Patterns
+	for _, matchYaku := range matchYakuFuncList {
+		patterns = append(patterns, matchYaku(hand)...)
+	}
+	if len(patterns) == 0 {
+		return rules.NewScoredOutPlan(hand.plan, 0, rules.Patterns{rules.NewPattern("役無し", 0)})
+	}
+	patterns = append(patterns, redFives(hand)...)
+
+	han := 0
+	for _, pattern := range patterns {
+		han += pattern.Score
+	}
+	fu := computeFu(hand)
+	basicPoints, limitName := getBasicPoints(han, fu)
+	patterns = append(patterns, rules.NewPattern(fmt.Sprintf("%d符", fu), 0))
+	if len(limitName) > 0 {
+		patterns = append(patterns, rules.NewPattern(limitName, 0))
+	}
+	sort.Sort(patterns)
+	return rules.NewScoredOutPlan(hand.plan, computePoints(hand, basicPoints), patterns)
+}
+
+// hasDistinctPairs returns false if the hand is Seven Pairs with four of a kind, which is not a
+// valid Seven Pairs in Riichi.
+func hasDistinctPairs(hand *winningHand) bool {
+	group := hand.getSpecialGroup(rules.TileGroupTypeSevenPairs)
+	if group == nil {
+		return true
+	}
+	tiles := group.GetTiles()
+	for i := 2; i < len(tiles); i += 2 {
+		if tiles[i].GetSuit() == tiles[i-2].GetSuit() &&
+			tiles[i].GetOrdinal() == tiles[i-2].GetOrdinal() {
+			return false
+		}
+	}
+	return true
+}
+
+// getBasicPoints returns the basic points of a hand with the given han and fu, and the name of the
+// limit hand if the points are capped by a limit.
+func getBasicPoints(han, fu int) (int, string) {
+	for _, limit := range limits {
+		if han >= limit.han {
+			return limit.basicPoints, limit.name
+		}
+	}
+	basicPoints := fu << uint(2+han)
+	if basicPoints > manganBasicPoints {
+		return manganBasicPoints, "満貫"
+	}
+	return basicPoints, ""
+}
+
+// computePoints returns the total number of points the winner of the given hand receives, given
+// the basic points of the hand. The dealer receives 1.5 times as many points.
+func computePoints(hand *winningHand, basicPoints int) int {
+	if hand.isSelfDrawn() {
+		if hand.isDealer() {
+			return 3 * roundUpToHundred(2*basicPoints)
+		}
+		// The dealer pays double.
+		return roundUpToHundred(2*basicPoints) + 2*roundUpToHundred(basicPoints)
+	}
+	if hand.isDealer() {
+		return roundUpToHundred(6 * basicPoints)
+	}
+	return roundUpToHundred(4 * basicPoints)
+}
+
+func roundUpToHundred(points int) int {
+	return (points + 99) / 100 * 100
+}
+
+type matchYakuFunc func(*winningHand) []*rules.Pattern
+
+var matchYakuFuncList = []matchYakuFunc{
+	// 1 han
+	riichi,
+	concealedSelfDrawn,
+	pinfu,
+	allSimples,
+	pureDoubleChow,
+	valueHonors,
+	// 2 han
+	mixedTripleChow,
+	pureStraight,
+	sevenPairs,
+	// 3 han and more
+	oneSuit,
+}
+
+// yakumanFuncList contains the yakuman. Each matched yakuman is worth yakumanBasicPoints.
+var yakumanFuncList = []matchYakuFunc{
+	thirteenOrphans,
+	fourConcealedTriplets,
+	bigThreeDragons,
+	fourWinds,
+	allHonors,
+	allTerminals,
+	nineGates,
+	fourKongs,
+	winOnInitialHand,
+}
@@ -0,0 +1,242 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ScoreOutPlans_RiichiPinfuSelfDrawn(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 4, 5, 6),
+		domain.CreateTilesForTest(t, rules.Characters, 2, 3, 4),
+		domain.CreateTilesForTest(t, rules.Dots, 1, 2),
+		domain.CreateTilesForTest(t, rules.Characters, 7, 7),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+	require.True(t, player.DeclareRiichi(NewRuleSet()))
+	outTile := domain.CreateTileForTest(t, rules.Dots, 3)
+	hand.AddTile(outTile)
+	outTileSource := rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawn, outTile, nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	// 4 han 20 fu: the dealer pays 2600 and the others 1300 each.
+	assert.Equal(t, 5200, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"立直", "門前清自摸和", "平和", "断幺九", "20符"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_OpenAllSimplesClosedWait(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 4, 5, 6),
+		domain.CreateTilesForTest(t, rules.Characters, 2, 3, 4),
+		domain.CreateTilesForTest(t, rules.Dots, 2, 4),
+		domain.CreateTilesForTest(t, rules.Characters, 7, 7),
+	))
+	meldTiles := domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypeChow)}
+	player := rules.NewExistingPlayerGameState(hand, 2, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 3))
+	// 1 han, 22 fu rounded up to 30.
+	assert.Equal(t, 1000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"断幺九", "30符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_RedFive(t *testing.T) {
+	redFive, err := domain.NewTileWithVariant(rules.Bamboo, 4, 0, domain.TileVariantRed)
+	require.NoError(t, err)
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 3, 5),
+		domain.CreateTilesForTest(t, rules.Characters, 2, 3, 4),
+		domain.CreateTilesForTest(t, rules.Dots, 2, 4),
+		domain.CreateTilesForTest(t, rules.Characters, 7, 7),
+	))
+	hand.AddTile(redFive)
+	meldTiles := domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypeChow)}
+	player := rules.NewExistingPlayerGameState(hand, 2, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 3))
+	// 2 han, 22 fu rounded up to 30.
+	assert.Equal(t, 2000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"断幺九", "赤ドラ", "30符"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_RedFiveWithoutYaku(t *testing.T) {
+	redFive, err := domain.NewTileWithVariant(rules.Dots, 4, 0, domain.TileVariantRed)
+	require.NoError(t, err)
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dots, 3, 5),
+		domain.CreateTilesForTest(t, rules.Characters, 0, 1, 2),
+		domain.CreateTilesForTest(t, rules.Dots, 6, 7),
+		domain.CreateTilesForTest(t, rules.Characters, 7, 7),
+	))
+	hand.AddTile(redFive)
+	meldTiles := domain.CreateTilesForTest(t, rules.Bamboo, 6, 7, 8)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypeChow)}
+	player := rules.NewExistingPlayerGameState(hand, 2, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 8))
+	// A red five is not a yaku.
+	assert.Equal(t, 0, scoredPlans[0].TotalScore)
+	assert.Equal(t, []string{"役無し"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_SevenPairsDealer(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 0, 0, 8, 8),
+		domain.CreateTilesForTest(t, rules.Characters, 1, 1, 4, 4),
+		domain.CreateTilesForTest(t, rules.Dots, 2, 2, 3, 3),
+		domain.CreateTilesForTest(t, rules.Dragons, 0),
+	))
+	player := rules.NewPlayerGameState(hand, 0)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dragons, 0))
+	assert.Equal(t, 2400, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"七対子", "25符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_ValueHonorsOnDiscardIsOpenPong(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 0),
+		domain.CreateTilesForTest(t, rules.Characters, 4, 4),
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 4, 5, 6),
+		domain.CreateTilesForTest(t, rules.Dots, 6, 7, 8),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dragons, 0))
+	// 20 fu + 10 for a concealed ron + 4 for an open pong of honors, rounded up to 40.
+	assert.Equal(t, 1300, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"役牌 中", "40符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_DoubleWindPong(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Winds, 1, 1),
+		domain.CreateTilesForTest(t, rules.Characters, 4, 4),
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 4, 5, 6),
+		domain.CreateTilesForTest(t, rules.Dots, 6, 7, 8),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	// South is both the seat wind and the prevailing wind.
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Winds, 1), 1)
+	// 2 han, 40 fu.
+	assert.Equal(t, 2600, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"自風 南", "場風 南", "40符"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+
+	// South is only the seat wind in the East round.
+	scoredPlans = rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Winds, 1))
+	assert.ElementsMatch(t, []string{"自風 南", "40符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_DoubleWindPairFu(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Characters, 0, 0, 0),
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 4, 5, 6),
+		domain.CreateTilesForTest(t, rules.Dots, 3, 4),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 0),
+	))
+	player := rules.NewPlayerGameState(hand, 0)
+	require.True(t, player.DeclareRiichi(NewRuleSet()))
+
+	// 20 fu + 10 for a concealed ron + 8 for a concealed pong of terminals + 4 for a pair of the
+	// double wind, rounded up to 50.
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 2), 0)
+	assert.Equal(t, 2400, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"立直", "50符"}, rules.PatternNamesForTest(scoredPlans[0]))
+
+	// A pair of the seat wind alone is worth 2 fu, rounded up to 40.
+	scoredPlans = rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 2), 1)
+	assert.Equal(t, 2000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"立直", "40符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_ThirteenOrphans(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 0, 8),
+		domain.CreateTilesForTest(t, rules.Characters, 0, 8),
+		domain.CreateTilesForTest(t, rules.Dots, 0, 8),
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 1, 2),
+		domain.CreateTilesForTest(t, rules.Winds, 0, 1, 2, 3),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Bamboo, 0))
+	assert.Equal(t, 32000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"国士無双"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_NoYaku(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Characters, 1, 2, 3),
+		domain.CreateTilesForTest(t, rules.Dots, 4, 5, 6, 6, 7, 4, 4),
+	))
+	meldTiles := domain.CreateTilesForTest(t, rules.Bamboo, 0, 0, 0)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypePong)}
+	player := rules.NewExistingPlayerGameState(hand, 1, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 8))
+	assert.Equal(t, 0, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"役無し"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_OpenFullFlushIsMangan(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.CreateTilesForTest(t, rules.Characters, 1, 2, 3, 4, 5, 6, 7, 7, 8, 8))
+	meldTiles := domain.CreateTilesForTest(t, rules.Characters, 0, 0, 0)
+	meldGroups := rules.TileGroups{rules.NewTileGroup(meldTiles, rules.TileGroupTypePong)}
+	player := rules.NewExistingPlayerGameState(hand, 1, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 8))
+	// 5 han for an open Full Flush is capped at mangan.
+	assert.Equal(t, 8000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"清一色", "30符", "満貫"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_RonOnFirstGoAroundIsNotYakuman(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 3, 4, 5),
+		domain.CreateTilesForTest(t, rules.Characters, 2, 3, 4),
+		domain.CreateTilesForTest(t, rules.Dots, 5, 5, 5, 6),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+	// The dealer's first discard.
+	discardInfo := rules.NewDiscardInfo(rules.NewPlayerGameState(domain.NewHand(), 0))
+	outTile := domain.CreateTileForTest(t, rules.Dots, 4)
+	outTileSource := rules.NewOutTileSource(rules.OutTileSourceTypeDiscard, outTile, discardInfo)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	// 人和 is not a yakuman, so this is 2 han 30 fu.
+	assert.Equal(t, 2000, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"平和", "断幺九", "30符"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
@@ -0,0 +1,35 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// deadWallSize is the number of tiles in the dead wall, which are never drawn from the front. The
+// kong replacement tiles are drawn from it. Dora indicators are not revealed from it, as only red
+// fives are scored as dora.
+const deadWallSize = 14
+
+func init() {
+	rules.RegisterRuleSet(NewRuleSet())
+}
+
+// NewRuleSet returns the rules.RuleSet for Riichi MJ.
+func NewRuleSet() rules.RuleSet {
+	return &rules.BaseRuleSet{
+		Name:             flags.RuleNameRiichi,
+		TileCountRules:   rules.TileCountRulesRiichi,
+		TileVariantRules: rules.TileVariantRulesRiichi,
+		NumTilesPerHand:  13,
+		SpecialHands: []rules.TileGroupType{
+			rules.TileGroupTypeSevenPairs,
+			rules.TileGroupTypeThirteenOrphans,
+		},
+		Scorer:           NewOutPlansScorer(),
+		AllowRobbingKong: true,
+		AllowRiichi:      true,
+		DeadWallSize:     deadWallSize,
+		// A hand without any yaku is worth 0 points, and may not be declared as an Out.
+		MinOutScore: 1,
+	}
+}
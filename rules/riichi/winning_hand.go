@@ -0,0 +1,233 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// waitType is the shape of the wait completed by the out tile.
+type waitType int
+
+const (
+	// waitNone is used when no group is completed by the out tile, e.g. for the initial hand, or
+	// when the out tile completes a special hand.
+	waitNone waitType = iota
+	// waitRyanmen is a two-sided wait on a chow, e.g. 34 waiting on 2 or 5.
+	waitRyanmen
+	// waitKanchan is a closed wait on the middle tile of a chow, e.g. 35 waiting on 4.
+	waitKanchan
+	// waitPenchan is an edge wait on a chow, e.g. 12 waiting on 3.
+	waitPenchan
+	// waitShanpon is a wait on one of two pairs to form a pong.
+	waitShanpon
+	// waitTanki is a wait on a single tile to form the pair.
+	waitTanki
+)
+
+// winningHand is an Out plan together with the hand group completed by the out tile. The same plan
+// may be read with different winning groups, which affects the wait, and whether a pong completed
+// by a discard is concealed.
+type winningHand struct {
+	plan    rules.OutPlan
+	context *rules.OutPlanScoringContext
+	// winningGroup is the index of the hand group completed by the out tile, or -1 if there is
+	// none.
+	winningGroup int
+	wait         waitType
+}
+
+// triplet is a pong or kong of a winning hand.
+type triplet struct {
+	group     *rules.TileGroup
+	concealed bool
+}
+
+// newWinningHands returns a winningHand for each hand group of the given plan that may have been
+// completed by the out tile.
+func newWinningHands(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*winningHand {
+	var hands []*winningHand
+	outTile := context.OutTileSource.Tile
+	if outTile != nil {
+		for i, group := range plan.GetHandGroups() {
+			if !containsTile(group.GetTiles(), outTile) {
+				continue
+			}
+			hands = append(hands, &winningHand{
+				plan:         plan,
+				context:      context,
+				winningGroup: i,
+				wait:         getWait(group, outTile),
+			})
+		}
+	}
+	if len(hands) == 0 {
+		hands = append(hands, &winningHand{plan: plan, context: context, winningGroup: -1})
+	}
+	return hands
+}
+
+// getWait returns the wait on the given group that is completed by the given tile.
+func getWait(group *rules.TileGroup, tile *domain.Tile) waitType {
+	switch group.GetGroupType() {
+	case rules.TileGroupTypePair:
+		return waitTanki
+	case rules.TileGroupTypePong:
+		return waitShanpon
+	case rules.TileGroupTypeChow:
+		first := group.GetTiles()[0]
+		suitSize := first.GetSuit().GetSize()
+		switch tile.GetOrdinal() - first.GetOrdinal() {
+		case 1:
+			return waitKanchan
+		case 0:
+			if first.GetOrdinal() == suitSize-3 {
+				return waitPenchan
+			}
+		case 2:
+			if first.GetOrdinal() == 0 {
+				return waitPenchan
+			}
+		}
+		return waitRyanmen
+	}
+	return waitNone
+}
+
+// getAllGroups returns the hand groups followed by the melded groups.
+func (h *winningHand) getAllGroups() rules.TileGroups {
+	var groups rules.TileGroups
+	groups = append(groups, h.plan.GetHandGroups()...)
+	return append(groups, h.plan.GetMeldedGroups()...)
+}
+
+// getAllTiles returns the tiles of all groups.
+func (h *winningHand) getAllTiles() domain.Tiles {
+	var tiles domain.Tiles
+	for _, group := range h.getAllGroups() {
+		tiles = append(tiles, group.GetTiles()...)
+	}
+	return tiles
+}
+
+// getGroupsOfType returns the hand and melded groups of the given type.
+func (h *winningHand) getGroupsOfType(groupType rules.TileGroupType) rules.TileGroups {
+	var groups rules.TileGroups
+	for _, group := range h.getAllGroups() {
+		if group.GetGroupType() == groupType {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// getTriplets returns the pongs and kongs of the hand. A pong in the hand is concealed unless it
+// is completed by a discard.
+func (h *winningHand) getTriplets() []triplet {
+	var triplets []triplet
+	for i, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == rules.TileGroupTypePong {
+			triplets = append(triplets,
+				triplet{group: group, concealed: h.isSelfDrawn() || i != h.winningGroup})
+		}
+	}
+	for _, group := range h.plan.GetMeldedGroups() {
+		if group.IsKanType() {
+			triplets = append(triplets, triplet{
+				group:     group,
+				concealed: group.GetGroupType() == rules.TileGroupTypeConcealedKong,
+			})
+		}
+	}
+	return triplets
+}
+
+// getPair returns the pair of a standard hand, or nil if there is none.
+func (h *winningHand) getPair() *rules.TileGroup {
+	for _, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == rules.TileGroupTypePair {
+			return group
+		}
+	}
+	return nil
+}
+
+// getSpecialGroup returns the group of a special hand of the given type, or nil if the hand is not
+// one.
+func (h *winningHand) getSpecialGroup(groupType rules.TileGroupType) *rules.TileGroup {
+	for _, group := range h.plan.GetHandGroups() {
+		if group.GetGroupType() == groupType {
+			return group
+		}
+	}
+	return nil
+}
+
+// isConcealed returns whether no tile is melded from another player.
+func (h *winningHand) isConcealed() bool {
+	for _, group := range h.plan.GetMeldedGroups() {
+		if group.GetGroupType() != rules.TileGroupTypeConcealedKong {
+			return false
+		}
+	}
+	return true
+}
+
+// isSelfDrawn returns whether the out tile is drawn by the player (tsumo), as opposed to being
+// taken from another player (ron).
+func (h *winningHand) isSelfDrawn() bool {
+	sourceType := h.context.OutTileSource.SourceType
+	return rules.IsSelfDrawnType(sourceType) || sourceType == rules.OutTileSourceTypeInitialHand
+}
+
+// isDealer returns whether the player is the dealer, i.e. East.
+func (h *winningHand) isDealer() bool {
+	return h.context.PlayerGameState.GetWindOrdinal() == 0
+}
+
+// isValueTile returns whether a pong of the given tile is worth han, and a pair of it worth fu.
+func (h *winningHand) isValueTile(tile *domain.Tile) bool {
+	return h.countValues(tile) > 0
+}
+
+// countValues returns the number of han a pong of the given tile is worth as a value tile. A wind
+// that is both the seat wind and the prevailing wind is worth 2.
+func (h *winningHand) countValues(tile *domain.Tile) int {
+	if tile.GetSuit() == rules.Dragons {
+		return 1
+	}
+	numValues := 0
+	if isSeatWind(tile, h.context) {
+		numValues++
+	}
+	if isPrevailingWind(tile, h.context) {
+		numValues++
+	}
+	return numValues
+}
+
+// isSeatWind returns whether the given tile is the wind of the player.
+func isSeatWind(tile *domain.Tile, context *rules.OutPlanScoringContext) bool {
+	return rules.IsWindSuit(tile.GetSuit()) &&
+		tile.GetOrdinal() == context.PlayerGameState.GetWindOrdinal()
+}
+
+// isPrevailingWind returns whether the given tile is the wind of the round.
+func isPrevailingWind(tile *domain.Tile, context *rules.OutPlanScoringContext) bool {
+	return rules.IsWindSuit(tile.GetSuit()) && tile.GetOrdinal() == context.PrevailingWindOrdinal
+}
+
+// isHonor returns whether the given tile is an honor tile.
+func isHonor(tile *domain.Tile) bool {
+	return tile.GetSuit().GetSuitType() == domain.SuitTypeHonor
+}
+
+// containsTile returns whether the given tiles contain a tile with the same suit and ordinal as the
+// given tile.
+func containsTile(tiles domain.Tiles, tile *domain.Tile) bool {
+	for _, t := range tiles {
+		if domain.CompareTiles(t, tile) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package riichi
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Yaku worth han. The score of each pattern is its han.
+
+var dragonNames = []string{"中", "發", "白"}
+var windNames = []string{"東", "南", "西", "北"}
+
+// Riichi (立直) : 1, concealed only
+func riichi(hand *winningHand) []*rules.Pattern {
+	if !hand.isConcealed() || !hand.context.PlayerGameState.IsRiichiDeclared() {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("立直", 1)}
+}
+
+// Fully Concealed Hand (門前清自摸和) : 1, concealed only
+func concealedSelfDrawn(hand *winningHand) []*rules.Pattern {
+	if !hand.isConcealed() || !rules.IsSelfDrawnType(hand.context.OutTileSource.SourceType) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("門前清自摸和", 1)}
+}
+
+// Pinfu (平和) : 1, concealed only
+func pinfu(hand *winningHand) []*rules.Pattern {
+	if !isPinfu(hand) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("平和", 1)}
+}
+
+// isPinfu returns whether the hand has no fu other than for winning: four concealed chows, a pair
+// that is not a value tile, and a two-sided wait.
+func isPinfu(hand *winningHand) bool {
+	if len(hand.plan.GetMeldedGroups()) > 0 || hand.wait != waitRyanmen {
+		return false
+	}
+	for _, group := range hand.plan.GetHandGroups() {
+		switch group.GetGroupType() {
+		case rules.TileGroupTypeChow:
+		case rules.TileGroupTypePair:
+			if hand.isValueTile(group.GetTiles()[0]) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// All Simples (断幺九) : 1
+func allSimples(hand *winningHand) []*rules.Pattern {
+	for _, tile := range hand.getAllTiles() {
+		if isHonor(tile) || tile.IsTerminal() {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("断幺九", 1)}
+}
+
+// Pure Double Chow (一盃口) : 1, concealed only
+func pureDoubleChow(hand *winningHand) []*rules.Pattern {
+	if !hand.isConcealed() {
+		return nil
+	}
+	chows := hand.getGroupsOfType(rules.TileGroupTypeChow)
+	for i := range chows {
+		for j := i + 1; j < len(chows); j++ {
+			if domain.CompareTiles(chows[i].GetTiles()[0], chows[j].GetTiles()[0]) == 0 {
+				return []*rules.Pattern{rules.NewPattern("一盃口", 1)}
+			}
+		}
+	}
+	return nil
+}
+
+// Value Honors (役牌) : 1 for each pong or kong of dragons, the seat wind or the prevailing wind
+func valueHonors(hand *winningHand) []*rules.Pattern {
+	var patterns []*rules.Pattern
+	for _, triplet := range hand.getTriplets() {
+		tile := triplet.group.GetTiles()[0]
+		if tile.GetSuit() == rules.Dragons {
+			patterns = append(patterns,
+				rules.NewPattern(fmt.Sprintf("役牌 %s", dragonNames[tile.GetOrdinal()]), 1))
+			continue
+		}
+		if isSeatWind(tile, hand.context) {
+			patterns = append(patterns,
+				rules.NewPattern(fmt.Sprintf("自風 %s", windNames[tile.GetOrdinal()]), 1))
+		}
+		if isPrevailingWind(tile, hand.context) {
+			patterns = append(patterns,
+				rules.NewPattern(fmt.Sprintf("場風 %s", windNames[tile.GetOrdinal()]), 1))
+		}
+	}
+	return patterns
+}
+
+// Mixed Triple Chow (三色同順) : 2, 1 if open
+func mixedTripleChow(hand *winningHand) []*rules.Pattern {
+	suitsByOrdinal := make(map[int]map[*domain.Suit]bool)
+	for _, chow := range hand.getGroupsOfType(rules.TileGroupTypeChow) {
+		first := chow.GetTiles()[0]
+		if suitsByOrdinal[first.GetOrdinal()] == nil {
+			suitsByOrdinal[first.GetOrdinal()] = make(map[*domain.Suit]bool)
+		}
+		suitsByOrdinal[first.GetOrdinal()][first.GetSuit()] = true
+	}
+	for _, suits := range suitsByOrdinal {
+		if len(suits) == 3 {
+			return []*rules.Pattern{rules.NewPattern("三色同順", hand.getOpenReducedHan(2))}
+		}
+	}
+	return nil
+}
+
+// Pure Straight (一気通貫) : 2, 1 if open
+func pureStraight(hand *winningHand) []*rules.Pattern {
+	ordinalsBySuit := make(map[*domain.Suit]map[int]bool)
+	for _, chow := range hand.getGroupsOfType(rules.TileGroupTypeChow) {
+		first := chow.GetTiles()[0]
+		if ordinalsBySuit[first.GetSuit()] == nil {
+			ordinalsBySuit[first.GetSuit()] = make(map[int]bool)
+		}
+		ordinalsBySuit[first.GetSuit()][first.GetOrdinal()] = true
+	}
+	for _, ordinals := range ordinalsBySuit {
+		if ordinals[0] && ordinals[3] && ordinals[6] {
+			return []*rules.Pattern{rules.NewPattern("一気通貫", hand.getOpenReducedHan(2))}
+		}
+	}
+	return nil
+}
+
+// Seven Pairs (七対子) : 2
+func sevenPairs(hand *winningHand) []*rules.Pattern {
+	if hand.getSpecialGroup(rules.TileGroupTypeSevenPairs) == nil {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("七対子", 2)}
+}
+
+// Half Flush (混一色) : 3, 2 if open
+// Full Flush (清一色) : 6, 5 if open
+func oneSuit(hand *winningHand) []*rules.Pattern {
+	var suit *domain.Suit
+	hasHonors := false
+	for _, tile := range hand.getAllTiles() {
+		if isHonor(tile) {
+			hasHonors = true
+			continue
+		}
+		if suit != nil && suit != tile.GetSuit() {
+			return nil
+		}
+		suit = tile.GetSuit()
+	}
+	if suit == nil {
+		// All Honors is a yakuman.
+		return nil
+	}
+	if hasHonors {
+		return []*rules.Pattern{rules.NewPattern("混一色", hand.getOpenReducedHan(3))}
+	}
+	return []*rules.Pattern{rules.NewPattern("清一色", hand.getOpenReducedHan(6))}
+}
+
+// getOpenReducedHan returns the given han of a yaku, reduced by 1 if the hand is open.
+func (h *winningHand) getOpenReducedHan(han int) int {
+	if !h.isConcealed() {
+		return han - 1
+	}
+	return han
+}
@@ -0,0 +1,143 @@
+package riichi
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Yakuman (役満), the limit hands. Each is worth the limit, regardless of any other yaku.
+
+// yakumanHan is the score of a yakuman pattern, as a counted yakuman starts at 13 han.
+const yakumanHan = 13
+
+// Thirteen Orphans (国士無双)
+func thirteenOrphans(hand *winningHand) []*rules.Pattern {
+	if hand.getSpecialGroup(rules.TileGroupTypeThirteenOrphans) == nil {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("国士無双", yakumanHan)}
+}
+
+// Four Concealed Triplets (四暗刻)
+func fourConcealedTriplets(hand *winningHand) []*rules.Pattern {
+	numConcealed := 0
+	for _, triplet := range hand.getTriplets() {
+		if triplet.concealed {
+			numConcealed++
+		}
+	}
+	if numConcealed != 4 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("四暗刻", yakumanHan)}
+}
+
+// Big Three Dragons (大三元)
+func bigThreeDragons(hand *winningHand) []*rules.Pattern {
+	numDragonTriplets := 0
+	for _, triplet := range hand.getTriplets() {
+		if triplet.group.GetTiles()[0].GetSuit() == rules.Dragons {
+			numDragonTriplets++
+		}
+	}
+	if numDragonTriplets != 3 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("大三元", yakumanHan)}
+}
+
+// Little Four Winds (小四喜)
+// Big Four Winds (大四喜)
+func fourWinds(hand *winningHand) []*rules.Pattern {
+	numWindTriplets := 0
+	for _, triplet := range hand.getTriplets() {
+		if rules.IsWindSuit(triplet.group.GetTiles()[0].GetSuit()) {
+			numWindTriplets++
+		}
+	}
+	if numWindTriplets == 4 {
+		return []*rules.Pattern{rules.NewPattern("大四喜", yakumanHan)}
+	}
+	pair := hand.getPair()
+	if numWindTriplets == 3 && pair != nil && rules.IsWindSuit(pair.GetTiles()[0].GetSuit()) {
+		return []*rules.Pattern{rules.NewPattern("小四喜", yakumanHan)}
+	}
+	return nil
+}
+
+// All Honors (字一色)
+func allHonors(hand *winningHand) []*rules.Pattern {
+	for _, tile := range hand.getAllTiles() {
+		if !isHonor(tile) {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("字一色", yakumanHan)}
+}
+
+// All Terminals (清老頭)
+func allTerminals(hand *winningHand) []*rules.Pattern {
+	for _, tile := range hand.getAllTiles() {
+		if !tile.IsTerminal() {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("清老頭", yakumanHan)}
+}
+
+// Nine Gates (九蓮宝燈)
+func nineGates(hand *winningHand) []*rules.Pattern {
+	outTile := hand.context.OutTileSource.Tile
+	if len(hand.plan.GetMeldedGroups()) > 0 || outTile == nil {
+		return nil
+	}
+	suit := outTile.GetSuit()
+	if suit.GetSuitType() != domain.SuitTypeSimple {
+		return nil
+	}
+	// The hand must wait on 1112345678999 of the suit. To compute what was in the hand, subtract
+	// the out tile from the tiles in the plan.
+	counts := make([]int, suit.GetSize())
+	for _, tile := range hand.getAllTiles() {
+		if tile.GetSuit() != suit {
+			return nil
+		}
+		counts[tile.GetOrdinal()]++
+	}
+	counts[outTile.GetOrdinal()]--
+	for i, count := range counts {
+		expectedCount := 1
+		if i == 0 || i == suit.GetSize()-1 {
+			expectedCount = 3
+		}
+		if count != expectedCount {
+			return nil
+		}
+	}
+	return []*rules.Pattern{rules.NewPattern("九蓮宝燈", yakumanHan)}
+}
+
+// Four Kongs (四槓子)
+func fourKongs(hand *winningHand) []*rules.Pattern {
+	numKongs := len(hand.getGroupsOfType(rules.TileGroupTypeKong)) +
+		len(hand.getGroupsOfType(rules.TileGroupTypeConcealedKong))
+	if numKongs != 4 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("四槓子", yakumanHan)}
+}
+
+// Blessing of Heaven (天和)
+// Blessing of Earth (地和)
+//
+// Both are self-drawn. A win on a discard in the first go-around (人和) is not a yakuman.
+func winOnInitialHand(hand *winningHand) []*rules.Pattern {
+	if hand.context.OutTileSource.SourceType != rules.OutTileSourceTypeInitialHand ||
+		!hand.isSelfDrawn() {
+		return nil
+	}
+	if hand.isDealer() {
+		return []*rules.Pattern{rules.NewPattern("天和", yakumanHan)}
+	}
+	return []*rules.Pattern{rules.NewPattern("地和", yakumanHan)}
+}
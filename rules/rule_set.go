@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/golang/glog"
+	"sort"
+)
+
+// RuleSet binds together everything that varies between MJ rules (HK, ZJ, etc.): the tiles in the
+// deck, the size of a hand, the hands that are recognized as an Out and how an Out is scored.
+type RuleSet interface {
+	// GetName returns the name the rule set is registered under.
+	GetName() flags.RuleName
+	// GetTileCountRules returns the suits available in the game and the count of tiles in each.
+	GetTileCountRules() TileCountRules
+	// GetTileVariantRules returns the tiles that are dealt with a variant, e.g. red fives.
+	GetTileVariantRules() TileVariantRules
+	// GetSuits returns the set of all suits used in the game.
+	GetSuits() []*domain.Suit
+	// GetNumTilesPerHand returns the number of tiles dealt to each player, not including the
+	// additional tile dealt to the first player.
+	GetNumTilesPerHand() int
+	// GetOutPlanCalculatorOptions returns the options to use when calculating Out plans.
+	GetOutPlanCalculatorOptions() OutPlanCalculatorOptions
+	// GetOutPlansScorer returns the scorer of Out plans.
+	GetOutPlansScorer() OutPlansScorer
+	// IsRobbingKongAllowed returns whether a player may declare an Out with the tile that another
+	// player adds to a melded pong to form an additional kong.
+	IsRobbingKongAllowed() bool
+	// IsRiichiAllowed returns whether a player with a concealed ready hand may declare riichi.
+	IsRiichiAllowed() bool
+	// GetDeadWallSize returns the number of tiles at the back of the wall that are never drawn
+	// from the front. The game is over when only these tiles remain.
+	GetDeadWallSize() int
+	// IsQualifyingOut returns whether the given scored plan is worth enough to be declared as an
+	// Out, e.g. whether it meets a minimum score.
+	IsQualifyingOut(plan *ScoredOutPlan) bool
+}
+
+// BaseRuleSet is an implementation of RuleSet using static values. Rule sets may embed it and
+// override individual methods.
+type BaseRuleSet struct {
+	Name           flags.RuleName
+	TileCountRules TileCountRules
+	// TileVariantRules is the list of tiles that are dealt with a variant, e.g. red fives.
+	TileVariantRules TileVariantRules
+	NumTilesPerHand  int
+	// SpecialHands is the list of special hands that are recognized as an Out, e.g.
+	// TileGroupTypeSevenPairs.
+	SpecialHands []TileGroupType
+	Scorer       OutPlansScorer
+	// AllowRobbingKong specifies whether an additional kong may be robbed.
+	AllowRobbingKong bool
+	// AllowRiichi specifies whether riichi may be declared.
+	AllowRiichi  bool
+	DeadWallSize int
+	// MinOutScore is the minimum total score of a plan to be declared as an Out.
+	MinOutScore int
+}
+
+// GetName ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetName() flags.RuleName {
+	return rs.Name
+}
+
+// GetTileCountRules ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetTileCountRules() TileCountRules {
+	return rs.TileCountRules
+}
+
+// GetTileVariantRules ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetTileVariantRules() TileVariantRules {
+	return rs.TileVariantRules
+}
+
+// GetSuits ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetSuits() []*domain.Suit {
+	var suits []*domain.Suit
+	for _, rule := range rs.TileCountRules {
+		suits = append(suits, rule.Suit)
+	}
+	return suits
+}
+
+// GetNumTilesPerHand ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetNumTilesPerHand() int {
+	return rs.NumTilesPerHand
+}
+
+// GetOutPlanCalculatorOptions ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetOutPlanCalculatorOptions() OutPlanCalculatorOptions {
+	return OutPlanCalculatorOptions{
+		NumTilesPerHand: rs.NumTilesPerHand,
+		SpecialHands:    rs.SpecialHands,
+	}
+}
+
+// GetOutPlansScorer ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetOutPlansScorer() OutPlansScorer {
+	return rs.Scorer
+}
+
+// IsRobbingKongAllowed ... (RuleSet implementation)
+func (rs *BaseRuleSet) IsRobbingKongAllowed() bool {
+	return rs.AllowRobbingKong
+}
+
+// IsRiichiAllowed ... (RuleSet implementation)
+func (rs *BaseRuleSet) IsRiichiAllowed() bool {
+	return rs.AllowRiichi
+}
+
+// GetDeadWallSize ... (RuleSet implementation)
+func (rs *BaseRuleSet) GetDeadWallSize() int {
+	return rs.DeadWallSize
+}
+
+// IsQualifyingOut ... (RuleSet implementation)
+func (rs *BaseRuleSet) IsQualifyingOut(plan *ScoredOutPlan) bool {
+	return plan.TotalScore >= rs.MinOutScore
+}
+
+// GetQualifyingOutPlans returns the plans among the given scored plans that qualify as an Out
+// under the given RuleSet, in the same order. The result is empty if none qualifies.
+func GetQualifyingOutPlans(ruleSet RuleSet, scoredPlans ScoredOutPlans) ScoredOutPlans {
+	var qualifyingPlans ScoredOutPlans
+	for _, plan := range scoredPlans {
+		if ruleSet.IsQualifyingOut(plan) {
+			qualifyingPlans = append(qualifyingPlans, plan)
+		}
+	}
+	return qualifyingPlans
+}
+
+// ruleSets is a map from the rule name to its registered RuleSet.
+var ruleSets = make(map[flags.RuleName]RuleSet)
+
+// RegisterRuleSet registers the given RuleSet under its name. This is typically called from the
+// init() function of the package implementing the rule set. It is an error to register two rule
+// sets with the same name.
+func RegisterRuleSet(ruleSet RuleSet) {
+	name := ruleSet.GetName()
+	if _, found := ruleSets[name]; found {
+		panic(fmt.Errorf("Rule set %s is already registered", name))
+	}
+	glog.V(2).Infof("Registering rule set %s\n", name)
+	ruleSets[name] = ruleSet
+}
+
+// GetRuleSet returns the RuleSet registered under the given name, or an error if there is none.
+func GetRuleSet(ruleName flags.RuleName) (RuleSet, error) {
+	ruleSet, found := ruleSets[ruleName]
+	if !found {
+		glog.V(2).Infof("Rule %s not found\n", ruleName)
+		return nil, fmt.Errorf("Rule %s not found", ruleName)
+	}
+	return ruleSet, nil
+}
+
+// GetRuleSetNames returns the names of all registered rule sets in sorted order.
+func GetRuleSetNames() []flags.RuleName {
+	var names []flags.RuleName
+	for name := range ruleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// newRuleSetForTest creates a RuleSet with the given tile count rules and no scorer.
+func newRuleSetForTest(name flags.RuleName, tileCountRules TileCountRules) RuleSet {
+	return &BaseRuleSet{
+		Name:            name,
+		TileCountRules:  tileCountRules,
+		NumTilesPerHand: standardNumTilesPerHand,
+		SpecialHands:    []TileGroupType{TileGroupTypeSevenPairs, TileGroupTypeThirteenOrphans},
+	}
+}
+
+func Test_RegisterRuleSet(t *testing.T) {
+	name := "testrule"
+	ruleSet := newRuleSetForTest(name, TileCountRulesZJ)
+	RegisterRuleSet(ruleSet)
+	defer delete(ruleSets, name)
+
+	found, err := GetRuleSet(name)
+	assert.NoError(t, err)
+	assert.Equal(t, ruleSet, found)
+	assert.Contains(t, GetRuleSetNames(), name)
+
+	// Registering the same name twice is an error.
+	assert.Panics(t, func() { RegisterRuleSet(newRuleSetForTest(name, TileCountRulesHK)) })
+
+	deck, err := NewDeckForGame(name)
+	require.NotNil(t, deck)
+	assert.NoError(t, err)
+	assert.Equal(t, 136, deck.NumRemainingTiles())
+}
+
+func Test_GetRuleSet_UnknownRule(t *testing.T) {
+	ruleSet, err := GetRuleSet("unknownrule")
+	assert.Nil(t, ruleSet)
+	assert.Error(t, err)
+}
+
+func Test_BaseRuleSetSuits(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	assert.Equal(t, []*domain.Suit{Dots, Bamboo, Characters, Winds, Dragons}, ruleSet.GetSuits())
+}
+
+func Test_OutPlanCalculatorOptions_SpecialHands(t *testing.T) {
+	var tiles domain.Tiles
+	for ordinal := 0; ordinal < 14; ordinal += 2 {
+		suit := Dots
+		if ordinal >= Dots.GetSize() {
+			suit = Bamboo
+		}
+		tile := domain.CreateTileForTest(t, suit, ordinal%Dots.GetSize())
+		tiles = append(tiles, tile, domain.CreateTileForTest(t, suit, tile.GetOrdinal()))
+	}
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	player := NewPlayerGameState(hand, 0)
+	outTileSource := createOutTileSourceForTest(tiles[0])
+
+	withSevenPairs := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	plans := NewOutPlanCalculatorForRuleSet(withSevenPairs, player, outTileSource).Calculate()
+	require.Len(t, plans, 1)
+	assert.Equal(t, TileGroupTypeSevenPairs, plans[0].GetHandGroups()[0].GetGroupType())
+
+	withoutSevenPairs := &BaseRuleSet{
+		Name:            flags.RuleNameZJ,
+		TileCountRules:  TileCountRulesZJ,
+		NumTilesPerHand: standardNumTilesPerHand,
+	}
+	plans = NewOutPlanCalculatorForRuleSet(withoutSevenPairs, player, outTileSource).Calculate()
+	assert.Empty(t, plans)
+}
+
+func Test_GetQualifyingOutPlans(t *testing.T) {
+	ruleSet := &BaseRuleSet{Name: flags.RuleNameZJ, MinOutScore: 8}
+	high := NewScoredOutPlan(NewOutPlan(nil, nil), 12, nil)
+	exact := NewScoredOutPlan(NewOutPlan(nil, nil), 8, nil)
+	low := NewScoredOutPlan(NewOutPlan(nil, nil), 6, nil)
+
+	assert.Equal(t, ScoredOutPlans{high, exact},
+		GetQualifyingOutPlans(ruleSet, ScoredOutPlans{high, exact, low}))
+	assert.Empty(t, GetQualifyingOutPlans(ruleSet, ScoredOutPlans{low}))
+}
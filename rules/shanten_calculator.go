@@ -0,0 +1,275 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/golang/glog"
+)
+
+const (
+	// ShantenComplete is the shanten number of a hand that is already an Out.
+	ShantenComplete = -1
+	// ShantenReady is the shanten number of a hand that is ready, i.e. one tile away from an Out.
+	ShantenReady = 0
+)
+
+// ShantenCalculator calculates the shanten number of a hand, i.e. the number of tiles the hand is
+// away from being ready. A ready hand has a shanten number of 0. If the hand contains the extra
+// tile (e.g. 14 tiles minus melds) and is already an Out, the shanten number is -1.
+// The shanten number is the minimum over the standard hand decomposition (sets and a pair) and
+// the special hands recognized by the given OutPlanCalculatorOptions.
+type ShantenCalculator struct {
+	// counts is a map from suit to the number of tiles of each ordinal in the hand.
+	counts          map[*domain.Suit][]int
+	suits           []*domain.Suit
+	numHandTiles    int
+	numMeldedGroups int
+	options         OutPlanCalculatorOptions
+}
+
+// NewShantenCalculator creates a new ShantenCalculator for the hand and melded groups of the given
+// player. The hand must contain 3n+1 or 3n+2 tiles.
+func NewShantenCalculator(suits []*domain.Suit, player *PlayerGameState,
+	options OutPlanCalculatorOptions) *ShantenCalculator {
+	return newShantenCalculatorForTiles(suits, player.GetHand().GetTiles(),
+		len(player.GetMeldGroups()), options)
+}
+
+// NewShantenCalculatorForRuleSet creates a new ShantenCalculator for the given player, using the
+// suits and options of the given RuleSet.
+func NewShantenCalculatorForRuleSet(ruleSet RuleSet, player *PlayerGameState) *ShantenCalculator {
+	return NewShantenCalculator(ruleSet.GetSuits(), player, ruleSet.GetOutPlanCalculatorOptions())
+}
+
+func newShantenCalculatorForTiles(suits []*domain.Suit, tiles domain.Tiles, numMeldedGroups int,
+	options OutPlanCalculatorOptions) *ShantenCalculator {
+	if len(tiles)%3 == 0 {
+		panic(fmt.Errorf("Invalid number of tiles in hand for shanten: %d", len(tiles)))
+	}
+	inventory := newTileInventory(suits, tiles)
+	counts := make(map[*domain.Suit][]int)
+	for suit, suitTiles := range inventory {
+		suitCounts := make([]int, len(suitTiles))
+		for ordinal, ordinalTiles := range suitTiles {
+			suitCounts[ordinal] = len(ordinalTiles)
+		}
+		counts[suit] = suitCounts
+	}
+	return &ShantenCalculator{
+		counts:          counts,
+		suits:           suits,
+		numHandTiles:    len(tiles),
+		numMeldedGroups: numMeldedGroups,
+		options:         options,
+	}
+}
+
+// Calculate returns the shanten number of the hand.
+func (c *ShantenCalculator) Calculate() int {
+	shanten := c.calculateStandard()
+	for _, specialHand := range c.options.SpecialHands {
+		var specialShanten int
+		switch specialHand {
+		case TileGroupTypeSevenPairs:
+			specialShanten = c.calculateSevenPairs()
+		case TileGroupTypeThirteenOrphans:
+			specialShanten = c.calculateThirteenOrphans()
+		case TileGroupTypeHonorsAndKnitted:
+			specialShanten = c.calculateHonorsAndKnitted()
+		default:
+			glog.V(2).Infof("No shanten calculation for special hand %s\n", specialHand)
+			continue
+		}
+		if specialShanten < shanten {
+			shanten = specialShanten
+		}
+	}
+	return shanten
+}
+
+// numSetsRequired returns the number of sets that still need to be formed from the hand.
+func (c *ShantenCalculator) numSetsRequired() int {
+	return c.numHandTiles / 3
+}
+
+// calculateStandard returns the shanten number for the standard hand of sets and a pair, using
+// the formula 2 * (sets required) - 2 * (complete sets) - (partial sets) - (pair).
+func (c *ShantenCalculator) calculateStandard() int {
+	best := 2 * c.numSetsRequired()
+	c.calculateStandardHelper(0, 0, 0, 0, false, &best)
+	return best
+}
+
+func (c *ShantenCalculator) calculateStandardHelper(suitIndex, ordinal, numSets,
+	numPartialSets int, hasPair bool, best *int) {
+	// Advance to the next ordinal with remaining tiles.
+	for suitIndex < len(c.suits) && ordinal >= len(c.counts[c.suits[suitIndex]]) {
+		suitIndex++
+		ordinal = 0
+	}
+	for suitIndex < len(c.suits) && c.counts[c.suits[suitIndex]][ordinal] == 0 {
+		ordinal++
+		for suitIndex < len(c.suits) && ordinal >= len(c.counts[c.suits[suitIndex]]) {
+			suitIndex++
+			ordinal = 0
+		}
+	}
+
+	numSetsRequired := c.numSetsRequired()
+	// Partial sets beyond the number of sets required are useless.
+	numUsefulPartialSets := numPartialSets
+	if numSets+numUsefulPartialSets > numSetsRequired {
+		numUsefulPartialSets = numSetsRequired - numSets
+	}
+	shanten := 2*numSetsRequired - 2*numSets - numUsefulPartialSets
+	if hasPair {
+		shanten--
+	}
+	if suitIndex == len(c.suits) {
+		if shanten < *best {
+			*best = shanten
+		}
+		return
+	}
+	// Each remaining tile lowers the shanten number by at most 2/3, i.e. 2 for a complete set of 3
+	// tiles. Stop if the remaining tiles cannot improve on the best shanten number so far, which
+	// keeps the search fast for larger hands.
+	if shanten-2*c.countTilesFrom(suitIndex, ordinal)/3 >= *best {
+		return
+	}
+
+	suit := c.suits[suitIndex]
+	counts := c.counts[suit]
+	canChow := CanChow(suit)
+	hasRoomForSet := numSets+numPartialSets < numSetsRequired
+
+	// Use the tiles as the pair.
+	if !hasPair && counts[ordinal] >= 2 {
+		counts[ordinal] -= 2
+		c.calculateStandardHelper(suitIndex, ordinal, numSets, numPartialSets, true, best)
+		counts[ordinal] += 2
+	}
+	// Use the tiles as a pong.
+	if counts[ordinal] >= 3 {
+		counts[ordinal] -= 3
+		c.calculateStandardHelper(suitIndex, ordinal, numSets+1, numPartialSets, hasPair, best)
+		counts[ordinal] += 3
+	}
+	// Use the tile as the head of a chow.
+	if canChow && ordinal+2 < len(counts) && counts[ordinal+1] > 0 && counts[ordinal+2] > 0 {
+		counts[ordinal]--
+		counts[ordinal+1]--
+		counts[ordinal+2]--
+		c.calculateStandardHelper(suitIndex, ordinal, numSets+1, numPartialSets, hasPair, best)
+		counts[ordinal]++
+		counts[ordinal+1]++
+		counts[ordinal+2]++
+	}
+	if hasRoomForSet {
+		// Use the tiles as a partial pong.
+		if counts[ordinal] >= 2 {
+			counts[ordinal] -= 2
+			c.calculateStandardHelper(suitIndex, ordinal, numSets, numPartialSets+1, hasPair,
+				best)
+			counts[ordinal] += 2
+		}
+		// Use the tile as a partial chow with either of the next two ordinals.
+		for gap := 1; gap <= 2; gap++ {
+			if canChow && ordinal+gap < len(counts) && counts[ordinal+gap] > 0 {
+				counts[ordinal]--
+				counts[ordinal+gap]--
+				c.calculateStandardHelper(suitIndex, ordinal, numSets, numPartialSets+1,
+					hasPair, best)
+				counts[ordinal]++
+				counts[ordinal+gap]++
+			}
+		}
+	}
+	// Leave the tile unused.
+	counts[ordinal]--
+	c.calculateStandardHelper(suitIndex, ordinal, numSets, numPartialSets, hasPair, best)
+	counts[ordinal]++
+}
+
+// countTilesFrom returns the number of tiles in the hand from the given suit and ordinal onwards.
+func (c *ShantenCalculator) countTilesFrom(suitIndex, ordinal int) int {
+	numTiles := 0
+	for ; suitIndex < len(c.suits); suitIndex++ {
+		counts := c.counts[c.suits[suitIndex]]
+		for ; ordinal < len(counts); ordinal++ {
+			numTiles += counts[ordinal]
+		}
+		ordinal = 0
+	}
+	return numTiles
+}
+
+// calculateSevenPairs returns the shanten number for the "Seven Pairs" hand. Consistent with the
+// OutPlanCalculator, four of a kind is considered as two pairs.
+func (c *ShantenCalculator) calculateSevenPairs() int {
+	if c.numMeldedGroups > 0 || c.numSetsRequired() != 4 {
+		return c.unreachableShanten()
+	}
+	numPairs := 0
+	for _, counts := range c.counts {
+		for _, count := range counts {
+			numPairs += count / 2
+		}
+	}
+	if numPairs > numSevenPairs {
+		numPairs = numSevenPairs
+	}
+	return numSevenPairs - 1 - numPairs
+}
+
+// calculateThirteenOrphans returns the shanten number for the "Thirteen Orphans" hand.
+func (c *ShantenCalculator) calculateThirteenOrphans() int {
+	if c.numMeldedGroups > 0 || c.numSetsRequired() != 4 {
+		return c.unreachableShanten()
+	}
+	numKinds := 0
+	hasPair := false
+	for _, tileBase := range thirteenOrphanTiles {
+		counts, found := c.counts[tileBase.GetSuit()]
+		if !found || counts[tileBase.GetOrdinal()] == 0 {
+			continue
+		}
+		numKinds++
+		if counts[tileBase.GetOrdinal()] >= 2 {
+			hasPair = true
+		}
+	}
+	shanten := len(thirteenOrphanTiles) - numKinds
+	if hasPair {
+		shanten--
+	}
+	return shanten
+}
+
+// calculateHonorsAndKnitted returns the shanten number for the "Honors and Knitted Tiles" hand,
+// which requires 14 distinct honors and tiles of the same knitted sequence.
+func (c *ShantenCalculator) calculateHonorsAndKnitted() int {
+	if c.numMeldedGroups > 0 || c.numSetsRequired() != 4 {
+		return c.unreachableShanten()
+	}
+	numKinds := 0
+	for _, suitOrder := range knittedSuitOrders {
+		numKindsForOrder := 0
+		for suit, counts := range c.counts {
+			for ordinal, count := range counts {
+				if count > 0 && (!CanChow(suit) || isKnittedTile(suitOrder, suit, ordinal)) {
+					numKindsForOrder++
+				}
+			}
+		}
+		if numKindsForOrder > numKinds {
+			numKinds = numKindsForOrder
+		}
+	}
+	return numHonorsAndKnittedTiles - 1 - numKinds
+}
+
+// unreachableShanten returns a shanten number that is greater than any achievable by the hand.
+func (c *ShantenCalculator) unreachableShanten() int {
+	return 2*c.numSetsRequired() + 1
+}
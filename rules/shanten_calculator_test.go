@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// createSuitTilesForTest creates one tile of the given suit for each of the given ordinals.
+func createSuitTilesForTest(t *testing.T, suit *domain.Suit, ordinals ...int) domain.Tiles {
+	var tiles domain.Tiles
+	for _, ordinal := range ordinals {
+		tiles = append(tiles, domain.CreateTileForTest(t, suit, ordinal))
+	}
+	return tiles
+}
+
+// createPlayerForTest creates a PlayerGameState with the given hand tiles and melded groups.
+func createPlayerForTest(tiles domain.Tiles, meldGroups TileGroups) *PlayerGameState {
+	hand := domain.NewHand()
+	hand.SetTiles(tiles)
+	return NewExistingPlayerGameState(hand, 0, nil, nil, meldGroups)
+}
+
+func concatTiles(tilesList ...domain.Tiles) domain.Tiles {
+	var allTiles domain.Tiles
+	for _, tiles := range tilesList {
+		allTiles = append(allTiles, tiles...)
+	}
+	return allTiles
+}
+
+func Test_ShantenCalculator(t *testing.T) {
+	testCases := []struct {
+		description     string
+		tiles           domain.Tiles
+		meldGroups      TileGroups
+		options         OutPlanCalculatorOptions
+		expectedShanten int
+	}{
+		{
+			"Complete hand",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 0, 0, 1, 1)),
+			nil,
+			defaultOutPlanCalculatorOptions,
+			ShantenComplete,
+		},
+		{
+			"Ready hand waiting on pair",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 0, 0, 1)),
+			nil,
+			defaultOutPlanCalculatorOptions,
+			ShantenReady,
+		},
+		{
+			"One away from ready",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 2),
+				createSuitTilesForTest(t, Characters, 4, 8)),
+			nil,
+			defaultOutPlanCalculatorOptions,
+			1,
+		},
+		{
+			"Honors cannot form partial chows",
+			concatTiles(
+				createSuitTilesForTest(t, Winds, 0, 1, 2, 3),
+				createSuitTilesForTest(t, Dragons, 0, 1, 2),
+				createSuitTilesForTest(t, Dots, 0, 4, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 4, 8)),
+			nil,
+			OutPlanCalculatorOptions{},
+			8,
+		},
+		{
+			"Seven pairs ready",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 0, 2, 2, 4, 4, 6, 6, 8, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 0, 2)),
+			nil,
+			defaultOutPlanCalculatorOptions,
+			ShantenReady,
+		},
+		{
+			"Seven pairs not recognized",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 0, 2, 2, 4, 4, 6, 6, 8, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 0, 2)),
+			nil,
+			OutPlanCalculatorOptions{},
+			3,
+		},
+		{
+			"Thirteen orphans ready",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 8),
+				createSuitTilesForTest(t, Bamboo, 0, 8),
+				createSuitTilesForTest(t, Characters, 0, 8),
+				createSuitTilesForTest(t, Winds, 0, 1, 2, 3),
+				createSuitTilesForTest(t, Dragons, 0, 1, 1)),
+			nil,
+			defaultOutPlanCalculatorOptions,
+			ShantenReady,
+		},
+		{
+			"Honors and knitted tiles ready",
+			concatTiles(
+				createSuitTilesForTest(t, Dots, 0, 3, 6),
+				createSuitTilesForTest(t, Bamboo, 1, 4),
+				createSuitTilesForTest(t, Characters, 2, 5, 8),
+				createSuitTilesForTest(t, Winds, 0, 1, 2),
+				createSuitTilesForTest(t, Dragons, 0, 1)),
+			nil,
+			OutPlanCalculatorOptions{
+				SpecialHands: []TileGroupType{TileGroupTypeHonorsAndKnitted},
+			},
+			ShantenReady,
+		},
+		{
+			"Ready hand with melds",
+			concatTiles(
+				createSuitTilesForTest(t, Bamboo, 0, 0),
+				createSuitTilesForTest(t, Dots, 1, 2)),
+			TileGroups{
+				NewTileGroup(createSuitTilesForTest(t, Characters, 0, 1, 2), TileGroupTypeChow),
+				NewTileGroup(createSuitTilesForTest(t, Characters, 4, 4, 4), TileGroupTypePong),
+				NewTileGroup(createSuitTilesForTest(t, Winds, 1, 1, 1, 1), TileGroupTypeKong),
+			},
+			defaultOutPlanCalculatorOptions,
+			ShantenReady,
+		},
+	}
+	for _, tc := range testCases {
+		player := createPlayerForTest(tc.tiles, tc.meldGroups)
+		calculator := NewShantenCalculator(GetSuitsForGame(), player, tc.options)
+		assert.Equal(t, tc.expectedShanten, calculator.Calculate(), tc.description)
+	}
+}
@@ -28,6 +28,9 @@ var (
 
 func ordinalPlusOneAndSuit(suffix string) domain.TileFriendlyNameFunc {
 	return func(t *domain.Tile) string {
+		if t.IsRed() {
+			return fmt.Sprintf("Red %d %s", 1+t.GetOrdinal(), suffix)
+		}
 		return fmt.Sprintf("%d %s", 1+t.GetOrdinal(), suffix)
 	}
 }
@@ -47,14 +50,19 @@ func suitAndOrdinalPlusOne(prefix string) domain.TileFriendlyNameFunc {
 	}
 }
 
+// GetWindName returns the name of the wind with the given ordinal, e.g. "East" for 0.
+func GetWindName(ordinal int) string {
+	return windNames[ordinal]
+}
+
 var suits = []*domain.Suit{
 	Bamboo, Characters, Dots, // Simples
 	Dragons, Winds, // Honors
 	Flowers, Seasons, // Bonus
 }
 
-// GetSuitsForGame returns the set of all suits used in the game.
-// TODO: remove this and replace with one that takes in rule name as input.
+// GetSuitsForGame returns the set of all suits known to the package. Use RuleSet.GetSuits() for
+// the suits used by a particular rule.
 func GetSuitsForGame() []*domain.Suit {
 	return suits
 }
@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// numRemainingTilesForTest is the number of tiles remaining in the deck when scoring in tests,
+// which is neither the first nor the last tile.
+const numRemainingTilesForTest = 20
+
+// ScoreForTest calculates the Out plans of the given player under the given RuleSet, asserts that
+// there is at least one, and returns the scored plans in the East round.
+func ScoreForTest(t *testing.T, ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource) ScoredOutPlans {
+	return ScoreForTestWithPrevailingWind(t, ruleSet, player, outTileSource, 0)
+}
+
+// ScoreForTestWithPrevailingWind is ScoreForTest in the round of the given prevailing wind.
+func ScoreForTestWithPrevailingWind(t *testing.T, ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource, prevailingWindOrdinal int) ScoredOutPlans {
+	plans := NewOutPlanCalculatorForRuleSet(ruleSet, player, outTileSource).Calculate()
+	require.NotEmpty(t, plans)
+	context := NewOutPlanScoringContext(outTileSource, player, numRemainingTilesForTest,
+		prevailingWindOrdinal)
+	return ruleSet.GetOutPlansScorer().ScoreOutPlans(plans, context)
+}
+
+// DiscardSourceForTest returns the source of an Out with the given tile discarded by North, which
+// is not the first discard of North.
+func DiscardSourceForTest(t *testing.T, suit *domain.Suit, ordinal int) *OutTileSource {
+	discardInfo := NewDiscardInfo(NewExistingPlayerGameState(
+		domain.NewHand(), 3, nil, domain.Tiles{nil}, nil))
+	return NewOutTileSource(OutTileSourceTypeDiscard, domain.CreateTileForTest(t, suit, ordinal),
+		discardInfo)
+}
+
+// PatternNamesForTest returns the names of the patterns of the given scored plan, in order.
+func PatternNamesForTest(plan *ScoredOutPlan) []string {
+	var names []string
+	for _, pattern := range plan.Patterns {
+		names = append(names, pattern.Name)
+	}
+	return names
+}
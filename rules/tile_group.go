@@ -98,6 +98,14 @@ const (
 	// TileGroupTypeThirteenOrphans is a special designation for "Thirteen Orphans". All of
 	// the tiles will be represented as a single group.
 	TileGroupTypeThirteenOrphans
+	// TileGroupTypeHonorsAndKnitted is a special designation for "Honors and Knitted Tiles", i.e.
+	// 14 distinct honors and tiles of a knitted sequence. All of the tiles will be represented as
+	// a single group.
+	TileGroupTypeHonorsAndKnitted
+	// TileGroupTypeKnittedStraight is a special designation for the 9 tiles of a "Knitted
+	// Straight", i.e. 147, 258 and 369 each in a different simple suit. The rest of the hand is
+	// represented by a set and a pair as in a standard hand.
+	TileGroupTypeKnittedStraight
 )
 
 func (t TileGroupType) String() string {
@@ -116,6 +124,10 @@ func (t TileGroupType) String() string {
 		return "SevenPairs"
 	case TileGroupTypeThirteenOrphans:
 		return "ThirteenOrphans"
+	case TileGroupTypeHonorsAndKnitted:
+		return "HonorsAndKnitted"
+	case TileGroupTypeKnittedStraight:
+		return "KnittedStraight"
 	}
 	glog.Errorf("Unhandled TileGroupType %d\n", t)
 	return "?"
@@ -0,0 +1,37 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// numBonusTilesPerSuit is the number of flowers, and of seasons.
+const numBonusTilesPerSuit = 4
+
+// Eight Immortals (八仙過海) : 8
+// Four Flowers or Four Seasons (花槓) : 2 for each
+// Seat Flower (花牌) : 1 for each flower or season of the player's seat, not in a set of four
+func flowers(hand *winningHand) []*rules.Pattern {
+	bonusTiles := make(map[*domain.Suit][]*domain.Tile)
+	for _, tile := range hand.context.PlayerGameState.GetBonusTiles() {
+		bonusTiles[tile.GetSuit()] = append(bonusTiles[tile.GetSuit()], tile)
+	}
+	if len(bonusTiles[rules.Flowers]) == numBonusTilesPerSuit &&
+		len(bonusTiles[rules.Seasons]) == numBonusTilesPerSuit {
+		return []*rules.Pattern{rules.NewPattern("八仙過海", 8)}
+	}
+
+	var patterns []*rules.Pattern
+	for _, suit := range []*domain.Suit{rules.Flowers, rules.Seasons} {
+		if len(bonusTiles[suit]) == numBonusTilesPerSuit {
+			patterns = append(patterns, rules.NewPattern("花槓", 2))
+			continue
+		}
+		for _, tile := range bonusTiles[suit] {
+			if tile.GetOrdinal() == hand.getSeatWindOrdinal() {
+				patterns = append(patterns, rules.NewPattern("花牌", 1))
+			}
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,71 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Honors
+
+// Big Three Dragons (大三元) : 8
+// Little Three Dragons (小三元) : 4
+// Dragon Pung (三元牌) : 1 for each
+func dragons(hand *winningHand) []*rules.Pattern {
+	numDragonPungs := countHonorPungs(hand, rules.Dragons)
+	switch {
+	case numDragonPungs == 3:
+		return []*rules.Pattern{rules.NewPattern("大三元", 8)}
+	case numDragonPungs == 2 && isPairOfSuit(hand, rules.Dragons):
+		return []*rules.Pattern{rules.NewPattern("小三元", 4)}
+	}
+	var patterns []*rules.Pattern
+	for i := 0; i < numDragonPungs; i++ {
+		patterns = append(patterns, rules.NewPattern("三元牌", 1))
+	}
+	return patterns
+}
+
+// Big Four Winds (大四喜) : 16
+// Little Four Winds (小四喜) : 8
+// Seat Wind (門風) : 1
+// Prevailing Wind (圈風) : 1
+func winds(hand *winningHand) []*rules.Pattern {
+	numWindPungs := countHonorPungs(hand, rules.Winds)
+	switch {
+	case numWindPungs == 4:
+		return []*rules.Pattern{rules.NewPattern("大四喜", 16)}
+	case numWindPungs == 3 && isPairOfSuit(hand, rules.Winds):
+		return []*rules.Pattern{rules.NewPattern("小四喜", 8)}
+	}
+	var patterns []*rules.Pattern
+	for _, pung := range hand.getPungs() {
+		tile := pung.group.GetTiles()[0]
+		if tile.GetSuit() != rules.Winds {
+			continue
+		}
+		if tile.GetOrdinal() == hand.getSeatWindOrdinal() {
+			patterns = append(patterns, rules.NewPattern("門風", 1))
+		}
+		if tile.GetOrdinal() == hand.getPrevailingWindOrdinal() {
+			patterns = append(patterns, rules.NewPattern("圈風", 1))
+		}
+	}
+	return patterns
+}
+
+// countHonorPungs returns the number of pungs of the given honor suit.
+func countHonorPungs(hand *winningHand, suit *domain.Suit) int {
+	count := 0
+	for _, pung := range hand.getPungs() {
+		if pung.group.GetTiles()[0].GetSuit() == suit {
+			count++
+		}
+	}
+	return count
+}
+
+// isPairOfSuit returns whether the pair of the hand is of the given suit.
+func isPairOfSuit(hand *winningHand, suit *domain.Suit) bool {
+	pair := hand.getPair()
+	return pair != nil && pair.GetTiles()[0].GetSuit() == suit
+}
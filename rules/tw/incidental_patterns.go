@@ -0,0 +1,102 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Incidental
+
+// Dealer (莊家) : 1
+func dealer(hand *winningHand) []*rules.Pattern {
+	if hand.getSeatWindOrdinal() != 0 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("莊家", 1)}
+}
+
+// Heavenly Hand (天胡) : 24
+func heavenlyHand(hand *winningHand) []*rules.Pattern {
+	if hand.context.OutTileSource.SourceType != rules.OutTileSourceTypeInitialHand {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("天胡", 24)}
+}
+
+// Concealed Self-Drawn (門清自摸) : 3
+// Concealed (門清) : 1
+// Self-Drawn (自摸) : 1
+//
+// A Heavenly Hand scores none of these.
+func concealedOrSelfDrawn(hand *winningHand) []*rules.Pattern {
+	if hand.context.OutTileSource.SourceType == rules.OutTileSourceTypeInitialHand {
+		return nil
+	}
+	switch concealed, selfDrawn := hand.isConcealed(), hand.isSelfDrawn(); {
+	case concealed && selfDrawn:
+		return []*rules.Pattern{rules.NewPattern("門清自摸", 3)}
+	case concealed:
+		return []*rules.Pattern{rules.NewPattern("門清", 1)}
+	case selfDrawn:
+		return []*rules.Pattern{rules.NewPattern("自摸", 1)}
+	}
+	return nil
+}
+
+// All Melded (全求人) : 2
+func meldedHand(hand *winningHand) []*rules.Pattern {
+	if !isMeldedHand(hand) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("全求人", 2)}
+}
+
+// isMeldedHand returns whether all sets are melded from other players, and the pair is completed
+// by a discard.
+func isMeldedHand(hand *winningHand) bool {
+	if len(hand.plan.GetHandGroups()) != 1 ||
+		!rules.IsExternalOutSourceType(hand.context.OutTileSource.SourceType) {
+		return false
+	}
+	for _, group := range hand.plan.GetMeldedGroups() {
+		if group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+			return false
+		}
+	}
+	return true
+}
+
+// Single Wait (獨聽) : 1, if the out tile is the only tile that would have completed the hand,
+// and the hand is not All Melded.
+func singleWait(hand *winningHand) []*rules.Pattern {
+	if hand.numWaits != 1 || isMeldedHand(hand) {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("獨聽", 1)}
+}
+
+// Last Tile Draw (海底撈月) : 1
+// Last Tile Claim (河底撈魚) : 1
+func lastTile(hand *winningHand) []*rules.Pattern {
+	if hand.context.NumRemainingTilesInDeck > 0 {
+		return nil
+	}
+	switch hand.context.OutTileSource.SourceType {
+	case rules.OutTileSourceTypeSelfDrawn, rules.OutTileSourceTypeSelfDrawnReplacement:
+		return []*rules.Pattern{rules.NewPattern("海底撈月", 1)}
+	case rules.OutTileSourceTypeDiscard:
+		return []*rules.Pattern{rules.NewPattern("河底撈魚", 1)}
+	}
+	return nil
+}
+
+// Out with Replacement Tile (槓上開花) : 1
+// Robbing the Kong (搶槓) : 1
+func winOnKong(hand *winningHand) []*rules.Pattern {
+	switch hand.context.OutTileSource.SourceType {
+	case rules.OutTileSourceTypeSelfDrawnReplacement:
+		return []*rules.Pattern{rules.NewPattern("槓上開花", 1)}
+	case rules.OutTileSourceTypeAdditionalKong:
+		return []*rules.Pattern{rules.NewPattern("搶槓", 1)}
+	}
+	return nil
+}
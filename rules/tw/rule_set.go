@@ -0,0 +1,32 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+)
+
+const (
+	// numTilesPerHand is the number of tiles dealt to each player, which form five sets and a pair
+	// with the additional tile.
+	numTilesPerHand = 16
+	// deadWallSize is the number of tiles at the back of the wall that are never drawn, so that
+	// the game is over when 16 tiles remain.
+	deadWallSize = 16
+)
+
+func init() {
+	rules.RegisterRuleSet(NewRuleSet())
+}
+
+// NewRuleSet returns the rules.RuleSet for Taiwanese 16-tile MJ.
+func NewRuleSet() rules.RuleSet {
+	rs := &rules.BaseRuleSet{
+		Name:             flags.RuleNameTW,
+		TileCountRules:   rules.TileCountRulesTW,
+		NumTilesPerHand:  numTilesPerHand,
+		AllowRobbingKong: true,
+		DeadWallSize:     deadWallSize,
+	}
+	rs.Scorer = NewOutPlansScorer(rs)
+	return rs
+}
@@ -0,0 +1,75 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+)
+
+// Sets
+
+// numSetsPerHand is the number of sets of an Out hand besides the pair.
+const numSetsPerHand = 5
+
+// All Chows (平胡) : 2, without honors or bonus tiles, not self-drawn and not on a single wait
+func allChows(hand *winningHand) []*rules.Pattern {
+	pair := hand.getPair()
+	if hand.countChows() != numSetsPerHand || pair == nil || isHonor(pair.GetTiles()[0]) ||
+		len(hand.context.PlayerGameState.GetBonusTiles()) > 0 || hand.isSelfDrawn() ||
+		hand.numWaits == 1 {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("平胡", 2)}
+}
+
+// All Pungs (碰碰胡) : 4
+func allPungs(hand *winningHand) []*rules.Pattern {
+	if len(hand.getPungs()) != numSetsPerHand {
+		return nil
+	}
+	return []*rules.Pattern{rules.NewPattern("碰碰胡", 4)}
+}
+
+// Five Concealed Pungs (五暗刻) : 8
+// Four Concealed Pungs (四暗刻) : 5
+// Three Concealed Pungs (三暗刻) : 2
+func concealedPungs(hand *winningHand) []*rules.Pattern {
+	numConcealedPungs := 0
+	for _, pung := range hand.getPungs() {
+		if pung.concealed {
+			numConcealedPungs++
+		}
+	}
+	switch numConcealedPungs {
+	case 5:
+		return []*rules.Pattern{rules.NewPattern("五暗刻", 8)}
+	case 4:
+		return []*rules.Pattern{rules.NewPattern("四暗刻", 5)}
+	case 3:
+		return []*rules.Pattern{rules.NewPattern("三暗刻", 2)}
+	}
+	return nil
+}
+
+// All Honors (字一色) : 16
+// Full Flush (清一色) : 8
+// Half Flush (混一色) : 4
+func oneSuit(hand *winningHand) []*rules.Pattern {
+	simpleSuits := make(map[*domain.Suit]bool)
+	hasHonors := false
+	for _, tile := range hand.getAllTiles() {
+		if isHonor(tile) {
+			hasHonors = true
+		} else {
+			simpleSuits[tile.GetSuit()] = true
+		}
+	}
+	switch {
+	case len(simpleSuits) == 0:
+		return []*rules.Pattern{rules.NewPattern("字一色", 16)}
+	case len(simpleSuits) > 1:
+		return nil
+	case hasHonors:
+		return []*rules.Pattern{rules.NewPattern("混一色", 4)}
+	}
+	return []*rules.Pattern{rules.NewPattern("清一色", 8)}
+}
@@ -0,0 +1,89 @@
+package tw
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ScoreOutPlans_ConcealedAllChows(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dots, 0, 1, 2, 3, 4, 5),
+		domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 5, 6, 7),
+		domain.CreateTilesForTest(t, rules.Characters, 3, 4, 7, 7),
+	))
+	player := rules.NewPlayerGameState(hand, 1)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 5))
+	assert.Equal(t, 3, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"門清", "平胡"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_DealerSelfDrawnWithFlowers(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Dragons, 0, 0, 0, 1, 1, 1, 2, 2),
+		domain.CreateTilesForTest(t, rules.Dots, 0, 1, 2, 4, 4, 4),
+		domain.CreateTilesForTest(t, rules.Bamboo, 6, 7, 8),
+	))
+	bonusTiles := domain.ConcatTilesForTest(
+		domain.CreateTilesForTest(t, rules.Flowers, 0),
+		domain.CreateTilesForTest(t, rules.Seasons, 2),
+	)
+	player := rules.NewExistingPlayerGameState(hand, 0, bonusTiles, nil, nil)
+	outTileSource := rules.NewOutTileSource(
+		rules.OutTileSourceTypeSelfDrawn, hand.GetTiles()[8], nil)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player, outTileSource)
+	assert.Equal(t, 11, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"莊家", "門清自摸", "小三元", "三暗刻", "花牌"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_AllMeldedFullFlush(t *testing.T) {
+	hand := domain.NewHand()
+	hand.SetTiles(domain.CreateTilesForTest(t, rules.Dots, 8))
+	var meldGroups rules.TileGroups
+	for ordinal := 0; ordinal < 5; ordinal++ {
+		meldTiles := domain.CreateTilesForTest(t, rules.Dots, ordinal, ordinal, ordinal)
+		meldGroups = append(meldGroups, rules.NewTileGroup(meldTiles, rules.TileGroupTypePong))
+	}
+	player := rules.NewExistingPlayerGameState(hand, 2, nil, nil, meldGroups)
+
+	scoredPlans := rules.ScoreForTest(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Dots, 8))
+	// All Melded implies the single wait on the pair.
+	assert.Equal(t, 14, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"清一色", "碰碰胡", "全求人"},
+		rules.PatternNamesForTest(scoredPlans[0]))
+}
+
+func Test_ScoreOutPlans_WindPungs(t *testing.T) {
+	windHand := func(windOrdinal int) *domain.Hand {
+		hand := domain.NewHand()
+		hand.SetTiles(domain.ConcatTilesForTest(
+			domain.CreateTilesForTest(t, rules.Winds, windOrdinal, windOrdinal, windOrdinal),
+			domain.CreateTilesForTest(t, rules.Dots, 0, 1, 2),
+			domain.CreateTilesForTest(t, rules.Bamboo, 1, 2, 3, 5, 6, 7),
+			domain.CreateTilesForTest(t, rules.Characters, 3, 4, 7, 7),
+		))
+		return hand
+	}
+
+	// East pung for the South seat in the East round.
+	player := rules.NewPlayerGameState(windHand(0), 1)
+	scoredPlans := rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 5), 0)
+	assert.Equal(t, 2, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"門清", "圈風"}, rules.PatternNamesForTest(scoredPlans[0]))
+
+	// South pung for the South seat in the South round.
+	player = rules.NewPlayerGameState(windHand(1), 1)
+	scoredPlans = rules.ScoreForTestWithPrevailingWind(t, NewRuleSet(), player,
+		rules.DiscardSourceForTest(t, rules.Characters, 5), 1)
+	assert.Equal(t, 3, scoredPlans[0].TotalScore)
+	assert.ElementsMatch(t, []string{"門清", "門風", "圈風"}, rules.PatternNamesForTest(scoredPlans[0]))
+}
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"fmt"
 	"github.com/derekimcheng/mj/domain"
 )
 
@@ -20,6 +21,41 @@ var (
 		domain.NewTileBase(Dragons, 1),
 		domain.NewTileBase(Dragons, 2),
 	}
+
+	// knittedSuitOrders are the possible orders of the simple suits in a knitted sequence. The
+	// suit at index i has the tiles of ordinals i, i+3 and i+6, e.g. 147 of Dots, 258 of Bamboo
+	// and 369 of Characters.
+	knittedSuitOrders = [][]*domain.Suit{
+		{Dots, Bamboo, Characters},
+		{Dots, Characters, Bamboo},
+		{Bamboo, Dots, Characters},
+		{Bamboo, Characters, Dots},
+		{Characters, Dots, Bamboo},
+		{Characters, Bamboo, Dots},
+	}
 )
 
+// isKnittedTile returns whether a tile of the given suit and ordinal belongs to the knitted
+// sequence of the given suit order.
+func isKnittedTile(suitOrder []*domain.Suit, suit *domain.Suit, ordinal int) bool {
+	return suitOrder[ordinal%3] == suit
+}
+
 type tileInventory = map[*domain.Suit][][]*domain.Tile
+
+// newTileInventory creates a tileInventory for the given suits and populates it with the given
+// tiles. It is an error to call this function with tiles that are not eligible for the hand.
+func newTileInventory(suits []*domain.Suit, tiles domain.Tiles) tileInventory {
+	inventory := make(tileInventory)
+	for _, s := range suits {
+		inventory[s] = make([][]*domain.Tile, s.GetSize())
+	}
+	for _, t := range tiles {
+		if !IsEligibleForHand(t.GetSuit()) {
+			panic(fmt.Errorf("Hand should not contain ineligible tiles when counting, got %s", t))
+		}
+		suitTiles := inventory[t.GetSuit()]
+		suitTiles[t.GetOrdinal()] = append(suitTiles[t.GetOrdinal()], t)
+	}
+	return inventory
+}
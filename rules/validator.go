@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"strings"
+)
+
+// ValidationErrors is a list of problems found while validating a player state.
+type ValidationErrors []error
+
+// Error ... (error implementation)
+func (errs ValidationErrors) Error() string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("Found %d problem(s): %s", len(errs), strings.Join(messages, "; "))
+}
+
+// tileValue identifies the tiles of a suit and ordinal, ignoring the tile ID.
+type tileValue struct {
+	suit    *domain.Suit
+	ordinal int
+}
+
+// ValidatePlayerGameState checks that the given player state is possible under the given rule set,
+// and returns ValidationErrors describing every problem found, or nil if the state is valid. The
+// following are checked:
+//   - Each tile belongs to a suit of the rule set, and no tile appears more often than its
+//     TileCountRule allows, counting the hand, melded area, bonus area, discarded tiles and the out
+//     tile.
+//   - Each meld group is a legal pong, chow or kong.
+//   - Bonus tiles are only in the bonus area.
+//   - The total number of tiles adds up. If outTileSource is nil, the hand may be waiting for a
+//     tile or about to discard one. Otherwise, an Out is being declared, so the hand, melds and out
+//     tile add up to GetNumTilesPerHand()+1, plus one for each kong. A self-drawn out tile must
+//     already be in the hand, while one from another player must not.
+func ValidatePlayerGameState(ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource) error {
+	var errs ValidationErrors
+	errs = append(errs, validateTileCounts(ruleSet, player, outTileSource)...)
+	errs = append(errs, validateMeldGroups(player.GetMeldGroups())...)
+	errs = append(errs, validateBonusTiles(player)...)
+	errs = append(errs, validateNumTiles(ruleSet, player, outTileSource)...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateTileCounts checks the suit and multiplicity of every tile of the player.
+func validateTileCounts(ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource) []error {
+	maxCounts := make(map[*domain.Suit]int)
+	for _, rule := range ruleSet.GetTileCountRules() {
+		maxCounts[rule.Suit] = rule.Count
+	}
+
+	var tiles domain.Tiles
+	tiles = append(tiles, player.GetHand().GetTiles()...)
+	for _, group := range player.GetMeldGroups() {
+		tiles = append(tiles, group.GetTiles()...)
+	}
+	tiles = append(tiles, player.GetBonusTiles()...)
+	tiles = append(tiles, player.GetDiscardedTiles()...)
+	if outTileSource != nil && IsExternalOutSourceType(outTileSource.SourceType) &&
+		outTileSource.Tile != nil {
+		tiles = append(tiles, outTileSource.Tile)
+	}
+
+	var errs []error
+	counts := make(map[tileValue]int)
+	for _, tile := range tiles {
+		if _, found := maxCounts[tile.GetSuit()]; !found {
+			errs = append(errs, fmt.Errorf("Tile %s is not used in rule %s", tile,
+				ruleSet.GetName()))
+			continue
+		}
+		value := tileValue{tile.GetSuit(), tile.GetOrdinal()}
+		counts[value]++
+		if counts[value] == maxCounts[value.suit]+1 {
+			errs = append(errs, fmt.Errorf("Tile %s appears more than %d time(s)", tile,
+				maxCounts[value.suit]))
+		}
+	}
+	return errs
+}
+
+// validateMeldGroups checks that each of the given meld groups is a legal pong, chow or kong.
+func validateMeldGroups(groups TileGroups) []error {
+	var errs []error
+	for _, group := range groups {
+		if err := validateMeldGroup(group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateMeldGroup returns an error if the given meld group is not legal.
+func validateMeldGroup(group *TileGroup) error {
+	tiles := group.GetTiles()
+	expectedNumTiles := 3
+	switch group.GetGroupType() {
+	case TileGroupTypePong, TileGroupTypeChow:
+	case TileGroupTypeKong, TileGroupTypeConcealedKong:
+		expectedNumTiles = 4
+	default:
+		return fmt.Errorf("Meld group %s has invalid type %s", group, group.GetGroupType())
+	}
+	if len(tiles) != expectedNumTiles {
+		return fmt.Errorf("Meld group %s of type %s must have %d tiles, got %d", group,
+			group.GetGroupType(), expectedNumTiles, len(tiles))
+	}
+
+	suit := tiles[0].GetSuit()
+	for i, tile := range tiles {
+		if tile.GetSuit() != suit {
+			return fmt.Errorf("Meld group %s has tiles of different suits", group)
+		}
+		if group.GetGroupType() == TileGroupTypeChow {
+			if tile.GetOrdinal() != tiles[0].GetOrdinal()+i {
+				return fmt.Errorf("Meld group %s is not a chow of consecutive tiles", group)
+			}
+		} else if tile.GetOrdinal() != tiles[0].GetOrdinal() {
+			return fmt.Errorf("Meld group %s of type %s has different tiles", group,
+				group.GetGroupType())
+		}
+	}
+
+	if group.GetGroupType() == TileGroupTypeChow {
+		if !CanChow(suit) {
+			return fmt.Errorf("Meld group %s is a chow of %s, which cannot be chowed", group,
+				suit.GetName())
+		}
+	} else if !CanPong(suit) {
+		return fmt.Errorf("Meld group %s is a %s of %s, which cannot be melded", group,
+			group.GetGroupType(), suit.GetName())
+	}
+	return nil
+}
+
+// validateBonusTiles checks that the bonus tiles of the player are only in the bonus area.
+func validateBonusTiles(player *PlayerGameState) []error {
+	var errs []error
+	for _, tile := range player.GetHand().GetTiles() {
+		if !IsEligibleForHand(tile.GetSuit()) {
+			errs = append(errs, fmt.Errorf("Bonus tile %s is in the hand", tile))
+		}
+	}
+	for _, tile := range player.GetBonusTiles() {
+		if IsEligibleForHand(tile.GetSuit()) {
+			errs = append(errs, fmt.Errorf("Tile %s in the bonus area is not a bonus tile", tile))
+		}
+	}
+	return errs
+}
+
+// validateNumTiles checks that the number of tiles in the hand and melded area adds up. See
+// ValidatePlayerGameState.
+func validateNumTiles(ruleSet RuleSet, player *PlayerGameState,
+	outTileSource *OutTileSource) []error {
+	numTiles := len(player.GetHand().GetTiles())
+	numKongs := 0
+	for _, group := range player.GetMeldGroups() {
+		numTiles += len(group.GetTiles())
+		if group.GetGroupType() == TileGroupTypeKong ||
+			group.GetGroupType() == TileGroupTypeConcealedKong {
+			numKongs++
+		}
+	}
+
+	numTilesPerHand := ruleSet.GetNumTilesPerHand()
+	if outTileSource == nil {
+		if numTiles-numKongs != numTilesPerHand && numTiles-numKongs != numTilesPerHand+1 {
+			return []error{fmt.Errorf(
+				"Hand and meld groups have %d tiles with %d kong(s), expected %d or %d", numTiles,
+				numKongs, numTilesPerHand+numKongs, numTilesPerHand+1+numKongs)}
+		}
+		return nil
+	}
+
+	if IsExternalOutSourceType(outTileSource.SourceType) {
+		numTiles++
+	}
+	if expected := numTilesPerHand + 1 + numKongs; numTiles != expected {
+		return []error{fmt.Errorf(
+			"Hand, meld groups and out tile have %d tiles with %d kong(s), expected %d", numTiles,
+			numKongs, expected)}
+	}
+	return nil
+}
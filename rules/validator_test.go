@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ValidatePlayerGameState_Valid(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK)
+	// A hand of 10 tiles and a melded kong.
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 1, 2, 3, 4, 5, 6, 7, 8, 8),
+		createSuitTilesForTest(t, Bamboo, 0)),
+		TileGroups{NewTileGroup(createSuitTilesForTest(t, Winds, 0, 0, 0, 0), TileGroupTypeKong)})
+	player.bonusTiles = createSuitTilesForTest(t, Flowers, 0)
+
+	assert.NoError(t, ValidatePlayerGameState(ruleSet, player, nil))
+	// The out tile of a robbed kong is not in the hand.
+	robbedKongSource := NewOutTileSource(OutTileSourceTypeAdditionalKong,
+		domain.CreateTileForTest(t, Dots, 8), nil)
+	assert.NoError(t, ValidatePlayerGameState(ruleSet, player, robbedKongSource))
+
+	// A self-drawn out tile must be in the hand.
+	selfDrawnSource := NewOutTileSource(OutTileSourceTypeSelfDrawn,
+		domain.CreateTileForTest(t, Dots, 8), nil)
+	assert.Error(t, ValidatePlayerGameState(ruleSet, player, selfDrawnSource))
+	player.hand.AddTile(selfDrawnSource.Tile)
+	assert.NoError(t, ValidatePlayerGameState(ruleSet, player, selfDrawnSource))
+	assert.NoError(t, ValidatePlayerGameState(ruleSet, player, nil))
+	assert.Error(t, ValidatePlayerGameState(ruleSet, player, robbedKongSource))
+}
+
+func Test_ValidatePlayerGameState_TileCounts(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 1, 1, 1, 1, 1, 2, 3, 4, 5, 6),
+		createSuitTilesForTest(t, Flowers, 0)),
+		TileGroups{NewTileGroup(createSuitTilesForTest(t, Dots, 1, 2, 3), TileGroupTypeChow)})
+
+	err := ValidatePlayerGameState(ruleSet, player, nil)
+	require.Error(t, err)
+	errs, ok := err.(ValidationErrors)
+	require.True(t, ok, "Unexpected error type: %s", err)
+	// Six 2 Dots, a Flower that is not used in ZJ, which is also a bonus tile in the hand.
+	assert.Len(t, errs, 3, "Unexpected errors: %s", errs)
+	assert.Contains(t, err.Error(), "appears more than 4 time(s)")
+	assert.Contains(t, err.Error(), "is not used in rule")
+	assert.Contains(t, err.Error(), "Bonus tile")
+}
+
+func Test_ValidatePlayerGameState_MeldGroups(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameHK, TileCountRulesHK)
+	testCases := []struct {
+		description string
+		group       *TileGroup
+		valid       bool
+	}{
+		{"pong", NewTileGroup(createSuitTilesForTest(t, Dots, 4, 4, 4), TileGroupTypePong), true},
+		{"chow", NewTileGroup(createSuitTilesForTest(t, Dots, 3, 4, 5), TileGroupTypeChow), true},
+		{"concealed kong",
+			NewTileGroup(createSuitTilesForTest(t, Dragons, 1, 1, 1, 1), TileGroupTypeConcealedKong),
+			true},
+		{"pong of different tiles",
+			NewTileGroup(createSuitTilesForTest(t, Dots, 4, 4, 5), TileGroupTypePong), false},
+		{"chow with a gap",
+			NewTileGroup(createSuitTilesForTest(t, Dots, 3, 4, 6), TileGroupTypeChow), false},
+		{"chow of honors",
+			NewTileGroup(createSuitTilesForTest(t, Winds, 0, 1, 2), TileGroupTypeChow), false},
+		{"chow of different suits",
+			NewTileGroup(concatTiles(createSuitTilesForTest(t, Dots, 3, 4),
+				createSuitTilesForTest(t, Bamboo, 5)), TileGroupTypeChow), false},
+		{"kong of 3 tiles",
+			NewTileGroup(createSuitTilesForTest(t, Dots, 4, 4, 4), TileGroupTypeKong), false},
+		{"pair", NewTileGroup(createSuitTilesForTest(t, Dots, 4, 4), TileGroupTypePair), false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			hand := createSuitTilesForTest(t, Bamboo, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5)
+			player := createPlayerForTest(hand, TileGroups{testCase.group})
+			err := ValidatePlayerGameState(ruleSet, player, nil)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
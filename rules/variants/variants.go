@@ -0,0 +1,18 @@
+// Package variants imports all rule set implementations so that they are registered with
+// rules.RegisterRuleSet. Import it for its side effects only:
+//
+//	import _ "github.com/derekimcheng/mj/rules/variants"
+package variants
+
+import (
+	// Registers the Hong Kong rule set.
+	_ "github.com/derekimcheng/mj/rules/hk"
+	// Registers the Chinese Official rule set.
+	_ "github.com/derekimcheng/mj/rules/mcr"
+	// Registers the Riichi rule set.
+	_ "github.com/derekimcheng/mj/rules/riichi"
+	// Registers the Taiwanese rule set.
+	_ "github.com/derekimcheng/mj/rules/tw"
+	// Registers the Zung Jung rule set.
+	_ "github.com/derekimcheng/mj/rules/zj"
+)
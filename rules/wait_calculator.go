@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"strings"
+)
+
+// waitTileID is the ID given to the hypothetical tiles used to test each wait.
+const waitTileID = -1
+
+// Wait represents a tile that would complete a ready hand.
+type Wait struct {
+	Tile domain.TileBase
+	// NumUnseenTiles is the number of copies of the tile that are not visible to the player.
+	NumUnseenTiles int
+	// BestDiscardOut is the best-scoring plan if the tile is obtained from a discard. It is nil if
+	// there is no scorer, no opponent to discard the tile, or no plan that qualifies as an Out.
+	BestDiscardOut *ScoredOutPlan
+	// BestSelfDrawnOut is the best-scoring plan if the tile is self-drawn. It is nil if there is
+	// no scorer, or no plan that qualifies as an Out.
+	BestSelfDrawnOut *ScoredOutPlan
+}
+
+// String ...
+func (w *Wait) String() string {
+	str := fmt.Sprintf("%s (%d unseen", w.Tile, w.NumUnseenTiles)
+	if w.BestDiscardOut != nil {
+		str += fmt.Sprintf(", discard: %d", w.BestDiscardOut.TotalScore)
+	}
+	if w.BestSelfDrawnOut != nil {
+		str += fmt.Sprintf(", self-drawn: %d", w.BestSelfDrawnOut.TotalScore)
+	}
+	return str + ")"
+}
+
+// Waits is a slice of Wait.
+type Waits []*Wait
+
+// NumUnseenTiles returns the total number of unseen tiles over all waits.
+func (ws Waits) NumUnseenTiles() int {
+	total := 0
+	for _, w := range ws {
+		total += w.NumUnseenTiles
+	}
+	return total
+}
+
+// String ...
+func (ws Waits) String() string {
+	var strs []string
+	for _, w := range ws {
+		strs = append(strs, w.String())
+	}
+	return strings.Join(strs, ", ")
+}
+
+// WaitCalculator enumerates the tiles that would complete a ready hand.
+type WaitCalculator struct {
+	ruleSet RuleSet
+	scorer  OutPlansScorer
+	player  *PlayerGameState
+	// opponents are used for counting visible tiles. The last opponent is assumed to be the
+	// discarder when previewing an Out by discard.
+	opponents               []*PlayerGameState
+	numRemainingTilesInDeck int
+	prevailingWindOrdinal   int
+}
+
+// NewWaitCalculator creates a new WaitCalculator for the given player. The scorer may be nil, in
+// which case the waits are not scored. The number of remaining tiles is used for scoring as if
+// the wait tile was obtained immediately, in a round of the given prevailing wind.
+func NewWaitCalculator(ruleSet RuleSet, scorer OutPlansScorer, player *PlayerGameState,
+	opponents []*PlayerGameState, numRemainingTilesInDeck int,
+	prevailingWindOrdinal int) *WaitCalculator {
+	return &WaitCalculator{
+		ruleSet:                 ruleSet,
+		scorer:                  scorer,
+		player:                  player,
+		opponents:               opponents,
+		numRemainingTilesInDeck: numRemainingTilesInDeck,
+		prevailingWindOrdinal:   prevailingWindOrdinal,
+	}
+}
+
+// Calculate returns the waits of the player's hand in suit and ordinal order. The result is empty
+// if the hand is not ready.
+func (c *WaitCalculator) Calculate() Waits {
+	unseenCounts := countUnseenTiles(c.ruleSet, c.player, c.opponents)
+	var discardInfo *DiscardInfo
+	if len(c.opponents) > 0 {
+		discardInfo = NewDiscardInfo(c.opponents[len(c.opponents)-1])
+	}
+
+	var waits Waits
+	for _, suit := range c.ruleSet.GetSuits() {
+		if !IsEligibleForHand(suit) {
+			continue
+		}
+		for ordinal := 0; ordinal < suit.GetSize(); ordinal++ {
+			tile, err := domain.NewTile(suit, ordinal, waitTileID)
+			if err != nil {
+				panic(err)
+			}
+			// Test the tile as a discard, so that the player's hand is left untouched.
+			source := NewOutTileSource(OutTileSourceTypeDiscard, tile,
+				NewDiscardInfo(c.player))
+			plans := NewOutPlanCalculatorForRuleSet(c.ruleSet, c.player, source).Calculate()
+			if len(plans) == 0 {
+				continue
+			}
+
+			wait := &Wait{Tile: tile.TileBase, NumUnseenTiles: unseenCounts[tile.TileBase]}
+			if c.scorer != nil {
+				if discardInfo != nil {
+					wait.BestDiscardOut = c.scoreBest(plans, NewOutTileSource(
+						OutTileSourceTypeDiscard, tile, discardInfo), c.player)
+				}
+				wait.BestSelfDrawnOut = c.scoreSelfDrawn(tile)
+			}
+			waits = append(waits, wait)
+		}
+	}
+	return waits
+}
+
+func (c *WaitCalculator) scoreSelfDrawn(tile *domain.Tile) *ScoredOutPlan {
+	var tiles domain.Tiles
+	tiles = append(tiles, c.player.GetHand().GetTiles()...)
+	tiles = append(tiles, tile)
+	player := c.player.copyWithHandTiles(tiles)
+	source := NewOutTileSource(OutTileSourceTypeSelfDrawn, tile, nil)
+	plans := NewOutPlanCalculatorForRuleSet(c.ruleSet, player, source).Calculate()
+	return c.scoreBest(plans, source, player)
+}
+
+func (c *WaitCalculator) scoreBest(plans OutPlans, source *OutTileSource,
+	player *PlayerGameState) *ScoredOutPlan {
+	context := NewOutPlanScoringContext(source, player, c.numRemainingTilesInDeck,
+		c.prevailingWindOrdinal)
+	scoredPlans := GetQualifyingOutPlans(c.ruleSet, c.scorer.ScoreOutPlans(plans, context))
+	if len(scoredPlans) == 0 {
+		return nil
+	}
+	return scoredPlans[0]
+}
+
+// CountWaitsBeforeOut returns the number of kinds of tiles that would have completed the hand of
+// the given context before the out tile was obtained, or 0 if it is unknown. It is useful for
+// scoring patterns of a single wait.
+func CountWaitsBeforeOut(ruleSet RuleSet, context *OutPlanScoringContext) int {
+	player := context.PlayerGameState
+	outTileSource := context.OutTileSource
+	if !IsExternalOutSourceType(outTileSource.SourceType) {
+		if outTileSource.Tile == nil {
+			return 0
+		}
+		// Take out the out tile from the hand.
+		var tiles domain.Tiles
+		removed := false
+		for _, tile := range player.GetHand().GetTiles() {
+			if !removed && domain.CompareTiles(tile, outTileSource.Tile) == 0 {
+				removed = true
+				continue
+			}
+			tiles = append(tiles, tile)
+		}
+		if !removed {
+			return 0
+		}
+		player = player.copyWithHandTiles(tiles)
+	}
+	return len(NewWaitCalculator(ruleSet, nil, player, nil, 0,
+		context.PrevailingWindOrdinal).Calculate())
+}
+
+// countUnseenTiles returns a map from tile to the number of copies that are not visible to the
+// given player, i.e. not in the player's hand, and not in any melded or discarded area.
+func countUnseenTiles(ruleSet RuleSet, player *PlayerGameState,
+	opponents []*PlayerGameState) map[domain.TileBase]int {
+	unseenCounts := make(map[domain.TileBase]int)
+	for _, rule := range ruleSet.GetTileCountRules() {
+		for ordinal := 0; ordinal < rule.Suit.GetSize(); ordinal++ {
+			unseenCounts[domain.NewTileBase(rule.Suit, ordinal)] = rule.Count
+		}
+	}
+
+	// The same tile may be visible in multiple areas, e.g. a discarded tile that was melded.
+	seen := make(map[*domain.Tile]bool)
+	markSeen := func(tiles domain.Tiles) {
+		for _, tile := range tiles {
+			if tile == nil || seen[tile] {
+				continue
+			}
+			seen[tile] = true
+			if unseenCounts[tile.TileBase] > 0 {
+				unseenCounts[tile.TileBase]--
+			}
+		}
+	}
+	markSeen(player.GetHand().GetTiles())
+	for _, state := range append([]*PlayerGameState{player}, opponents...) {
+		for _, group := range state.GetMeldGroups() {
+			markSeen(group.GetTiles())
+		}
+		markSeen(state.GetDiscardedTiles())
+	}
+	return unseenCounts
+}
@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// sourceTypeScorer is an OutPlansScorer that scores each plan by the out tile source type.
+type sourceTypeScorer struct{}
+
+func (s *sourceTypeScorer) ScoreOutPlans(plans OutPlans,
+	context *OutPlanScoringContext) ScoredOutPlans {
+	var scoredPlans ScoredOutPlans
+	for _, plan := range plans {
+		score := 1
+		if IsSelfDrawnType(context.OutTileSource.SourceType) {
+			score = 2
+		}
+		scoredPlans = append(scoredPlans,
+			NewScoredOutPlan(plan, score, Patterns{NewPattern("test", score)}))
+	}
+	return scoredPlans
+}
+
+func Test_WaitCalculator(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 0, 0, 1, 2)), nil)
+
+	opponentHand := domain.NewHand()
+	opponentHand.SetTiles(createSuitTilesForTest(t, Bamboo, 0, 2))
+	opponent := NewPlayerGameState(opponentHand, 1)
+	_, discarded := opponent.DiscardTileAt(0)
+	require.True(t, discarded)
+
+	waits := NewWaitCalculator(ruleSet, &sourceTypeScorer{}, player,
+		[]*PlayerGameState{opponent}, 20, 0).Calculate()
+	require.Len(t, waits, 2)
+
+	assert.Equal(t, domain.NewTileBase(Bamboo, 0), waits[0].Tile)
+	// 2 in hand, 1 discarded by the opponent.
+	assert.Equal(t, 1, waits[0].NumUnseenTiles)
+	assert.Equal(t, 1, waits[0].BestDiscardOut.TotalScore)
+	assert.Equal(t, 2, waits[0].BestSelfDrawnOut.TotalScore)
+
+	assert.Equal(t, domain.NewTileBase(Bamboo, 3), waits[1].Tile)
+	assert.Equal(t, 4, waits[1].NumUnseenTiles)
+	assert.Equal(t, 5, waits.NumUnseenTiles())
+
+	// The player's hand is left untouched.
+	assert.Equal(t, 13, player.GetHand().NumTiles())
+}
+
+func Test_WaitCalculator_NotReady(t *testing.T) {
+	ruleSet := newRuleSetForTest(flags.RuleNameZJ, TileCountRulesZJ)
+	player := createPlayerForTest(concatTiles(
+		createSuitTilesForTest(t, Dots, 0, 1, 2, 3, 4, 5, 6, 7, 8),
+		createSuitTilesForTest(t, Bamboo, 0, 2),
+		createSuitTilesForTest(t, Characters, 4, 8)), nil)
+
+	waits := NewWaitCalculator(ruleSet, nil, player, nil, 20, 0).Calculate()
+	assert.Empty(t, waits)
+}
@@ -9,6 +9,8 @@ import (
 // 3.0 Honor Tiles
 
 // 3.1 Value Honor (番牌) : 10 per set
+// A set of Dragons, of the Seat Wind or of the Prevailing Wind is a Value Honor. A set of a wind
+// that is both the Seat Wind and the Prevailing Wind counts as two Value Honors.
 func valueHonor(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rules.Pattern {
 	allGroups := append(plan.GetHandGroups(), plan.GetMeldedGroups()...)
 	numHonors := 0
@@ -21,11 +23,15 @@ func valueHonor(plan rules.OutPlan, context *rules.OutPlanScoringContext) []*rul
 		if suit.GetSuitType() != domain.SuitTypeHonor {
 			continue
 		}
-		// Prevailing wind
 		if rules.IsWindSuit(suit) {
+			// Seat wind
 			if firstTile.GetOrdinal() == context.PlayerGameState.GetWindOrdinal() {
 				numHonors++
 			}
+			// Prevailing wind
+			if firstTile.GetOrdinal() == context.PrevailingWindOrdinal {
+				numHonors++
+			}
 		} else {
 			numHonors++
 		}
@@ -0,0 +1,25 @@
+package zj
+
+import (
+	"github.com/derekimcheng/mj/flags"
+	"github.com/derekimcheng/mj/rules"
+)
+
+func init() {
+	rules.RegisterRuleSet(NewRuleSet())
+}
+
+// NewRuleSet returns the rules.RuleSet for Zung Jung MJ.
+func NewRuleSet() rules.RuleSet {
+	return &rules.BaseRuleSet{
+		Name:            flags.RuleNameZJ,
+		TileCountRules:  rules.TileCountRulesZJ,
+		NumTilesPerHand: 13,
+		SpecialHands: []rules.TileGroupType{
+			rules.TileGroupTypeSevenPairs,
+			rules.TileGroupTypeThirteenOrphans,
+		},
+		Scorer:           NewOutPlansScorer(),
+		AllowRobbingKong: true,
+	}
+}
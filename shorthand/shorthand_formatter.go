@@ -0,0 +1,95 @@
+package shorthand
+
+import (
+	"fmt"
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"strings"
+)
+
+var suitsToLetters = func() map[*domain.Suit]rune {
+	m := make(map[*domain.Suit]rune)
+	for letter, suit := range lettersToSuits {
+		m[suit] = letter
+	}
+	return m
+}()
+
+// Formatter turns tiles / meld groups into shorthand form strings that are accepted by Parser.
+type Formatter struct{}
+
+// NewFormatter creates a new Formatter.
+func NewFormatter() *Formatter {
+	return &Formatter{}
+}
+
+// FormatTiles returns the shorthand form of the given tiles, e.g. "123b55m". The order of the
+// tiles is preserved, and consecutive tiles of the same suit share the suit letter. A red five is
+// written as 0, e.g. "0m".
+func (f *Formatter) FormatTiles(tiles domain.Tiles) string {
+	var sb strings.Builder
+	for i, tile := range tiles {
+		if tile.IsRed() {
+			sb.WriteRune(redFive)
+		} else {
+			sb.WriteRune(rune('1' + tile.GetOrdinal()))
+		}
+		if i == len(tiles)-1 || tiles[i+1].GetSuit() != tile.GetSuit() {
+			sb.WriteRune(getSuitLetter(tile.GetSuit()))
+		}
+	}
+	return sb.String()
+}
+
+// FormatTileGroup returns the shorthand form of the given group, e.g. "[123b]". Concealed kongs
+// are marked with '!', e.g. "[!7777m]". Groups that are not meld groups (e.g. pairs) are formatted
+// in the same way, but are not accepted by Parser.ParseMeldGroups.
+func (f *Formatter) FormatTileGroup(group *rules.TileGroup) string {
+	prefix := ""
+	if group.GetGroupType() == rules.TileGroupTypeConcealedKong {
+		prefix = string(concealedKongMarker)
+	}
+	return fmt.Sprintf("%c%s%s%c", meldGroupStart, prefix, f.FormatTiles(group.GetTiles()),
+		meldGroupEnd)
+}
+
+// FormatTileGroups returns the shorthand form of the given groups, e.g. "[111m][234b]".
+func (f *Formatter) FormatTileGroups(groups rules.TileGroups) string {
+	var sb strings.Builder
+	for _, group := range groups {
+		sb.WriteString(f.FormatTileGroup(group))
+	}
+	return sb.String()
+}
+
+// FormatOutPlan returns the shorthand form of the given plan, i.e. the hand groups followed by the
+// melded groups if there are any, e.g. "[123b][55m] [111m]".
+func (f *Formatter) FormatOutPlan(plan rules.OutPlan) string {
+	return joinNonEmpty(f.FormatTileGroups(plan.GetHandGroups()),
+		f.FormatTileGroups(plan.GetMeldedGroups()))
+}
+
+// FormatPlayerGameState returns the shorthand form of the hand and melded groups of the given
+// player, e.g. "123b55m [111m]". The result is accepted by Parser.ParseHandAndMeldGroups.
+func (f *Formatter) FormatPlayerGameState(player *rules.PlayerGameState) string {
+	return joinNonEmpty(f.FormatTiles(player.GetHand().GetTiles()),
+		f.FormatTileGroups(player.GetMeldGroups()))
+}
+
+func getSuitLetter(suit *domain.Suit) rune {
+	letter, found := suitsToLetters[suit]
+	if !found {
+		panic(fmt.Errorf("No shorthand letter for suit %s", suit.GetName()))
+	}
+	return letter
+}
+
+func joinNonEmpty(strs ...string) string {
+	var nonEmptyStrs []string
+	for _, str := range strs {
+		if len(str) > 0 {
+			nonEmptyStrs = append(nonEmptyStrs, str)
+		}
+	}
+	return strings.Join(nonEmptyStrs, " ")
+}
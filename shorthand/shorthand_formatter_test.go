@@ -0,0 +1,105 @@
+package shorthand
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func assertSameTiles(t *testing.T, expected, actual domain.Tiles) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, 0, domain.CompareTiles(expected[i], actual[i]), "tile %d", i)
+		assert.Equal(t, expected[i].GetVariant(), actual[i].GetVariant(), "tile %d", i)
+	}
+}
+
+func assertSameTileGroups(t *testing.T, expected, actual rules.TileGroups) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].GetGroupType(), actual[i].GetGroupType(), "group %d", i)
+		assertSameTiles(t, expected[i].GetTiles(), actual[i].GetTiles())
+	}
+}
+
+func Test_FormatTiles(t *testing.T) {
+	formatter := NewFormatter()
+	for _, str := range []string{"", "1m", "123b55m", "19m19d19b1234w123y", "53d12b", "12f34s",
+		"340m505b"} {
+		tiles, err := NewParser().ParseTiles(str)
+		require.NoError(t, err)
+		assert.Equal(t, str, formatter.FormatTiles(tiles))
+	}
+}
+
+func Test_FormatTiles_RoundTrip(t *testing.T) {
+	formatter := NewFormatter()
+	parser := NewParser()
+	var tiles domain.Tiles
+	for _, suit := range rules.GetSuitsForGame() {
+		if !rules.IsEligibleForHand(suit) {
+			continue
+		}
+		for ordinal := suit.GetSize() - 1; ordinal >= 0; ordinal-- {
+			tile, err := domain.NewTile(suit, ordinal, ordinal)
+			require.NoError(t, err)
+			tiles = append(tiles, tile)
+		}
+	}
+
+	parsedTiles, err := parser.ParseTiles(formatter.FormatTiles(tiles))
+	require.NoError(t, err)
+	assertSameTiles(t, tiles, parsedTiles)
+}
+
+func Test_FormatTileGroups_RoundTrip(t *testing.T) {
+	formatter := NewFormatter()
+	groups, err := NewParser().ParseMeldGroups("[111m][234b][0555d][!7777m][333w][406b]")
+	require.NoError(t, err)
+
+	str := formatter.FormatTileGroups(groups)
+	assert.Equal(t, "[111m][234b][0555d][!7777m][333w][406b]", str)
+	parsedGroups, err := NewParser().ParseMeldGroups(str)
+	require.NoError(t, err)
+	assertSameTileGroups(t, groups, parsedGroups)
+}
+
+func Test_FormatPlayerGameState_RoundTrip(t *testing.T) {
+	formatter := NewFormatter()
+	for _, str := range []string{
+		"123b55m [111m][!2222y]",
+		"1234567m12345d",
+		"55m [111m][234b][345b][!1111w]",
+	} {
+		tiles, groups, err := NewParser().ParseHandAndMeldGroups(str)
+		require.NoError(t, err)
+		hand := domain.NewHand()
+		hand.SetTiles(tiles)
+		player := rules.NewExistingPlayerGameState(hand, 0, nil, nil, groups)
+
+		formatted := formatter.FormatPlayerGameState(player)
+		assert.Equal(t, str, formatted)
+		parsedTiles, parsedGroups, err := NewParser().ParseHandAndMeldGroups(formatted)
+		require.NoError(t, err)
+		assertSameTiles(t, tiles, parsedTiles)
+		assertSameTileGroups(t, groups, parsedGroups)
+	}
+}
+
+func Test_FormatOutPlan(t *testing.T) {
+	formatter := NewFormatter()
+	hand, err := NewParser().ParseTiles("12344b")
+	require.NoError(t, err)
+	meldGroups, err := NewParser().ParseMeldGroups("[111m][!2222y][555d]")
+	require.NoError(t, err)
+	playerHand := domain.NewHand()
+	playerHand.SetTiles(hand)
+	player := rules.NewExistingPlayerGameState(playerHand, 0, nil, nil, meldGroups)
+
+	plans := rules.NewOutPlanCalculator(rules.GetSuitsForGame(), player,
+		rules.NewOutTileSource(rules.OutTileSourceTypeSelfDrawn, hand[4], nil)).Calculate()
+	require.Len(t, plans, 1)
+	assert.Equal(t, "[123b][44b] [111m][555d][!2222y]", formatter.FormatOutPlan(plans[0]))
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/derekimcheng/mj/domain"
 	"github.com/derekimcheng/mj/rules"
 	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"unicode"
 )
 
 const (
@@ -16,6 +19,16 @@ const (
 	dragons   = 'y'
 	flowers   = 'f'
 	seasons   = 's'
+
+	// redFive is the number used in place of 5 for a red five, e.g. "0m".
+	redFive = '0'
+	// redFiveOrdinal is the ordinal of the tile represented by redFive.
+	redFiveOrdinal = 4
+
+	// Characters used in meld group shorthand notation.
+	meldGroupStart      = '['
+	meldGroupEnd        = ']'
+	concealedKongMarker = '!'
 )
 
 var lettersToSuits = map[rune]*domain.Suit{
@@ -24,6 +37,7 @@ var lettersToSuits = map[rune]*domain.Suit{
 	bamboo:    rules.Bamboo,
 	winds:     rules.Winds,
 	dragons:   rules.Dragons,
+	flowers:   rules.Flowers,
 	seasons:   rules.Seasons,
 }
 
@@ -38,13 +52,21 @@ func NewParser() *Parser {
 }
 
 // ParseTiles parses the given tiles shorthand form and returns the corresponding Tiles, or an error
-// if the input is invalid.
+// if the input is invalid. A red five is written as 0, e.g. "0m".
 func (r *Parser) ParseTiles(tilesStr string) (domain.Tiles, error) {
 	var tiles domain.Tiles
 	var ordinalsSoFar []int
+	// redSoFar is whether each of ordinalsSoFar is a red five.
+	var redSoFar []bool
 	for _, c := range tilesStr {
 		if c >= '1' && c <= '9' {
 			ordinalsSoFar = append(ordinalsSoFar, int(c)-int('1'))
+			redSoFar = append(redSoFar, false)
+			continue
+		}
+		if c == redFive {
+			ordinalsSoFar = append(ordinalsSoFar, redFiveOrdinal)
+			redSoFar = append(redSoFar, true)
 			continue
 		}
 		suit, found := lettersToSuits[c]
@@ -54,12 +76,19 @@ func (r *Parser) ParseTiles(tilesStr string) (domain.Tiles, error) {
 		if len(ordinalsSoFar) == 0 {
 			return nil, fmt.Errorf("Suit must be preceded with at least one number")
 		}
-		for _, ordinal := range ordinalsSoFar {
+		for i, ordinal := range ordinalsSoFar {
+			variant := domain.TileVariantNone
+			if redSoFar[i] {
+				if !rules.CanChow(suit) {
+					return nil, fmt.Errorf("Suit %s has no red five", suit.GetName())
+				}
+				variant = domain.TileVariantRed
+			}
 			if ordinal >= suit.GetSize() {
 				return nil, fmt.Errorf("%d is out of range for suit %s",
 					ordinal+1, suit.GetName())
 			}
-			tile, err := domain.NewTile(suit, ordinal, r.nextID)
+			tile, err := domain.NewTileWithVariant(suit, ordinal, r.nextID, variant)
 			if err != nil {
 				return nil, errors.Wrapf(err, "error constructing tile")
 			}
@@ -67,13 +96,131 @@ func (r *Parser) ParseTiles(tilesStr string) (domain.Tiles, error) {
 			r.nextID++
 		}
 		ordinalsSoFar = nil
+		redSoFar = nil
 	}
 	return tiles, nil
 }
 
-// ParseMeldGroups parses the given tiles shorthand form and returns the corresponding meld groups,
-// or an error if the input is invalid.
+// ParseMeldGroups parses the given meld groups shorthand form and returns the corresponding meld
+// groups, or an error if the input is invalid. Each group is enclosed in brackets and contains the
+// tiles of a single suit, e.g. "[111m][234b][5555d][!7777m]" for a pong, a chow, an exposed kong
+// and a concealed kong (marked with '!'). Whitespace between groups is ignored.
 func (r *Parser) ParseMeldGroups(meldGroupsStr string) (rules.TileGroups, error) {
-	// TODO: implement
-	return nil, nil
+	var groups rules.TileGroups
+	runes := []rune(meldGroupsStr)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if unicode.IsSpace(c) {
+			continue
+		}
+		if c != meldGroupStart {
+			return nil, fmt.Errorf("Expected '%c' at position %d, got '%c'", meldGroupStart, i, c)
+		}
+		end := i + 1
+		for end < len(runes) && runes[end] != meldGroupEnd {
+			end++
+		}
+		if end == len(runes) {
+			return nil, fmt.Errorf("Missing '%c' for group starting at position %d", meldGroupEnd,
+				i)
+		}
+		group, err := r.parseMeldGroup(string(runes[i+1 : end]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing group %s", string(runes[i:end+1]))
+		}
+		groups = append(groups, group)
+		i = end
+	}
+	return groups, nil
+}
+
+// ParseHandAndMeldGroups parses the given hand and meld groups shorthand form, e.g.
+// "123b55m [111m]", and returns the corresponding hand tiles and meld groups, or an error if the
+// input is invalid. The meld groups start at the first '['.
+func (r *Parser) ParseHandAndMeldGroups(str string) (domain.Tiles, rules.TileGroups, error) {
+	handStr := str
+	meldGroupsStr := ""
+	if index := strings.IndexRune(str, meldGroupStart); index >= 0 {
+		handStr = str[:index]
+		meldGroupsStr = str[index:]
+	}
+	tiles, err := r.ParseTiles(strings.TrimSpace(handStr))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error parsing hand tiles")
+	}
+	groups, err := r.ParseMeldGroups(meldGroupsStr)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error parsing meld groups")
+	}
+	return tiles, groups, nil
+}
+
+// parseMeldGroup parses the contents of a single bracketed meld group.
+func (r *Parser) parseMeldGroup(groupStr string) (*rules.TileGroup, error) {
+	isConcealed := false
+	if len(groupStr) > 0 && rune(groupStr[0]) == concealedKongMarker {
+		isConcealed = true
+		groupStr = groupStr[1:]
+	}
+	tiles, err := r.ParseTiles(groupStr)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(tiles)
+
+	groupType, err := getMeldGroupType(tiles)
+	if err != nil {
+		return nil, err
+	}
+	if isConcealed {
+		if groupType != rules.TileGroupTypeKong {
+			return nil, fmt.Errorf("Only a kong can be concealed")
+		}
+		groupType = rules.TileGroupTypeConcealedKong
+	}
+	return rules.NewTileGroup(tiles, groupType), nil
+}
+
+// getMeldGroupType returns the type of meld group formed by the given sorted tiles, or an error if
+// the tiles do not form a valid meld group.
+func getMeldGroupType(tiles domain.Tiles) (rules.TileGroupType, error) {
+	numTiles := len(tiles)
+	if numTiles != 3 && numTiles != 4 {
+		return 0, fmt.Errorf("Invalid number of tiles in group: %d", numTiles)
+	}
+	suit := tiles[0].GetSuit()
+	for _, tile := range tiles[1:] {
+		if tile.GetSuit() != suit {
+			return 0, fmt.Errorf("All tiles in group must be of the same suit")
+		}
+	}
+
+	isSameOrdinal := true
+	isConsecutive := true
+	for i := 1; i < numTiles; i++ {
+		if tiles[i].GetOrdinal() != tiles[0].GetOrdinal() {
+			isSameOrdinal = false
+		}
+		if tiles[i].GetOrdinal() != tiles[i-1].GetOrdinal()+1 {
+			isConsecutive = false
+		}
+	}
+
+	switch {
+	case isSameOrdinal:
+		if !rules.CanPong(suit) {
+			return 0, fmt.Errorf("Suit %s cannot form a pong or kong", suit.GetName())
+		}
+		if numTiles == 4 {
+			return rules.TileGroupTypeKong, nil
+		}
+		return rules.TileGroupTypePong, nil
+	case isConsecutive && numTiles == 3:
+		if !rules.CanChow(suit) {
+			return 0, fmt.Errorf("Suit %s cannot form a chow", suit.GetName())
+		}
+		return rules.TileGroupTypeChow, nil
+	default:
+		return 0, fmt.Errorf("Tiles %s do not form a pong, chow or kong", tiles)
+	}
 }
@@ -0,0 +1,101 @@
+package shorthand
+
+import (
+	"github.com/derekimcheng/mj/domain"
+	"github.com/derekimcheng/mj/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ParseMeldGroups(t *testing.T) {
+	parser := NewParser()
+	groups, err := parser.ParseMeldGroups("[111m][324b] [5555d][!7777m][222y]")
+	require.NoError(t, err)
+	require.Len(t, groups, 5)
+
+	expectedTypes := []rules.TileGroupType{
+		rules.TileGroupTypePong,
+		rules.TileGroupTypeChow,
+		rules.TileGroupTypeKong,
+		rules.TileGroupTypeConcealedKong,
+		rules.TileGroupTypePong,
+	}
+	for i, group := range groups {
+		assert.Equal(t, expectedTypes[i], group.GetGroupType(), "group %d", i)
+	}
+
+	// Chow tiles are sorted.
+	chowTiles := groups[1].GetTiles()
+	require.Len(t, chowTiles, 3)
+	for i, tile := range chowTiles {
+		assert.Equal(t, rules.Bamboo, tile.GetSuit())
+		assert.Equal(t, i+1, tile.GetOrdinal())
+	}
+}
+
+func Test_ParseMeldGroups_Empty(t *testing.T) {
+	groups, err := NewParser().ParseMeldGroups("")
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func Test_ParseMeldGroups_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"111m",
+		"[111m",
+		"[]",
+		"[11m]",
+		"[11111m]",
+		"[11m1b]",
+		"[135m]",
+		"[1234m]",
+		"[123w]",
+		"[!111m]",
+		"[1111f]",
+		"[111x]",
+	} {
+		_, err := NewParser().ParseMeldGroups(input)
+		assert.Error(t, err, "input %s", input)
+	}
+}
+
+func Test_ParseTiles_BonusTiles(t *testing.T) {
+	tiles, err := NewParser().ParseTiles("14f23s")
+	require.NoError(t, err)
+	require.Len(t, tiles, 4)
+	assert.Equal(t, rules.Flowers, tiles[0].GetSuit())
+	assert.Equal(t, 0, tiles[0].GetOrdinal())
+	assert.Equal(t, rules.Flowers, tiles[1].GetSuit())
+	assert.Equal(t, 3, tiles[1].GetOrdinal())
+	assert.Equal(t, rules.Seasons, tiles[2].GetSuit())
+	assert.Equal(t, rules.Seasons, tiles[3].GetSuit())
+
+	_, err = NewParser().ParseTiles("5f")
+	assert.Error(t, err)
+}
+
+func Test_ParseTiles_RedFives(t *testing.T) {
+	tiles, err := NewParser().ParseTiles("05m0d")
+	require.NoError(t, err)
+	require.Len(t, tiles, 3)
+	assert.True(t, tiles[0].IsRed())
+	assert.Equal(t, 4, tiles[0].GetOrdinal())
+	assert.False(t, tiles[1].IsRed())
+	assert.Equal(t, 0, domain.CompareTiles(tiles[0], tiles[1]))
+	assert.Equal(t, rules.Dots, tiles[2].GetSuit())
+	assert.True(t, tiles[2].IsRed())
+
+	// A red five in a meld group is sorted as a regular five.
+	groups, err := NewParser().ParseMeldGroups("[406m][505b]")
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, rules.TileGroupTypeChow, groups[0].GetGroupType())
+	assert.True(t, groups[0].GetTiles()[1].IsRed())
+	assert.Equal(t, rules.TileGroupTypePong, groups[1].GetGroupType())
+
+	for _, input := range []string{"0w", "0y", "0f"} {
+		_, err := NewParser().ParseTiles(input)
+		assert.Error(t, err, "input %s", input)
+	}
+}
@@ -14,6 +14,8 @@ const (
 	ShowDiscardedTiles CommandType = "discarded"
 	// ShowMelded shows the melded area.
 	ShowMelded CommandType = "melded"
+	// Hint shows the advice for each possible discard.
+	Hint CommandType = "hint"
 	// DiscardTile discards a tile at the given index. Corresponds to DiscardTileCommand.
 	DiscardTile CommandType = "discard"
 	// Pong creates a meld from a pong tile group. Only available if the tile completing the
@@ -35,6 +37,13 @@ const (
 	Pass CommandType = "pass"
 	// Out declares the player has reached an out hand.
 	Out CommandType = "out"
+	// RobKong declares an out hand with the tile another player adds to a melded pong to form an
+	// additional kong. Only available right after the additional kong is declared.
+	RobKong CommandType = "rob"
+	// Riichi declares riichi with a concealed ready hand and discards the tile at the given index,
+	// which must leave the hand ready. Only available in Riichi MJ. Corresponds to
+	// DiscardTileCommand.
+	Riichi CommandType = "riichi"
 )
 
 // TileIndexCommand represents information of a command that specifies a tile index.
@@ -69,7 +78,7 @@ func (c *TileIndexCommand2) GetIndex2() int {
 // Command represents a command from an input that may modify the state of the game.
 type Command struct {
 	commandType CommandType
-	// tile is only set if commandType is DiscardTile / ConcealedKong / AdditionalKong.
+	// tile is only set if commandType is DiscardTile / ConcealedKong / AdditionalKong / Riichi.
 	tile *TileIndexCommand
 	// tile is only set if commandType is Chow.
 	tile2 *TileIndexCommand2
@@ -80,11 +89,23 @@ func (c *Command) GetCommandType() CommandType {
 	return c.commandType
 }
 
+// String returns the command in the console syntax accepted by ParseCommand, e.g. "discard 3".
+func (c *Command) String() string {
+	switch c.commandType {
+	case DiscardTile, ConcealedKong, AdditionalKong, Riichi:
+		return fmt.Sprintf("%s %d", c.commandType, c.tile.index)
+	case Chow:
+		return fmt.Sprintf("%s %d %d", c.commandType, c.tile2.index1, c.tile2.index2)
+	}
+	return c.commandType
+}
+
 // GetTileIndexCommand ...
 func (c *Command) GetTileIndexCommand() *TileIndexCommand {
 	if c.commandType != DiscardTile &&
 		c.commandType != ConcealedKong &&
-		c.commandType != AdditionalKong {
+		c.commandType != AdditionalKong &&
+		c.commandType != Riichi {
 		panic(fmt.Errorf("invalid command type for TileIndexCommand: %s", c.commandType))
 	}
 	return c.tile
@@ -113,6 +134,11 @@ func NewShowMeldedCommand() *Command {
 	return &Command{commandType: ShowMelded}
 }
 
+// NewHintCommand returns a new Hint command.
+func NewHintCommand() *Command {
+	return &Command{commandType: Hint}
+}
+
 // NewDiscardTileCommand returns a new DiscardTile command with the given index.
 func NewDiscardTileCommand(index int) *Command {
 	return &Command{commandType: DiscardTile, tile: &TileIndexCommand{index: index}}
@@ -144,6 +170,11 @@ func NewChowCommand(index1, index2 int) *Command {
 		tile2: &TileIndexCommand2{index1: index1, index2: index2}}
 }
 
+// NewRiichiCommand returns a new Riichi command that discards the tile at the given index.
+func NewRiichiCommand(index int) *Command {
+	return &Command{commandType: Riichi, tile: &TileIndexCommand{index: index}}
+}
+
 // NewPassCommand retrurns a new Out command.
 func NewPassCommand() *Command {
 	return &Command{commandType: Pass}
@@ -154,6 +185,11 @@ func NewOutCommand() *Command {
 	return &Command{commandType: Out}
 }
 
+// NewRobKongCommand returns a new RobKong command.
+func NewRobKongCommand() *Command {
+	return &Command{commandType: RobKong}
+}
+
 // CommandTypes is a slice of CommandTypes.
 type CommandTypes []CommandType
 
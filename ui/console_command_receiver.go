@@ -14,6 +14,7 @@ var commandAliases = map[string]string{
 	"c":  Chow,
 	"ck": ConcealedKong,
 	"d":  DiscardTile,
+	"h":  Hint,
 	"k":  Kong,
 	"p":  Pong,
 }
@@ -21,12 +22,16 @@ var commandAliases = map[string]string{
 // ConsoleCommandReceiver receives command from the an input stream, such as the console.
 type ConsoleCommandReceiver struct {
 	scanner *bufio.Scanner
+	// out is where the prompts and input errors are written.
+	out io.Writer
 }
 
-// NewConsoleCommandReceiver creates a new ConsoleCommandReceiver with the given input source.
-func NewConsoleCommandReceiver(r io.Reader) *ConsoleCommandReceiver {
+// NewConsoleCommandReceiver creates a new ConsoleCommandReceiver with the given input source, which
+// writes its prompts to the given writer.
+func NewConsoleCommandReceiver(r io.Reader, w io.Writer) *ConsoleCommandReceiver {
 	return &ConsoleCommandReceiver{
 		scanner: bufio.NewScanner(r),
+		out:     w,
 	}
 }
 
@@ -34,7 +39,7 @@ func NewConsoleCommandReceiver(r io.Reader) *ConsoleCommandReceiver {
 func (recver *ConsoleCommandReceiver) PromptForCommand(acceptedCommands CommandTypes) (*Command, error) {
 	// Repeat until an error is encountered or a valid Command is obtained.
 	for {
-		fmt.Printf("Enter a command [%s]: ", strings.Join(acceptedCommands, "|"))
+		fmt.Fprintf(recver.out, "Enter a command [%s]: ", strings.Join(acceptedCommands, "|"))
 		success := recver.scanner.Scan()
 		if !success {
 			err := recver.scanner.Err()
@@ -49,13 +54,13 @@ func (recver *ConsoleCommandReceiver) PromptForCommand(acceptedCommands CommandT
 			continue
 		}
 
-		cmd, err := parseCommand(text)
+		cmd, err := ParseCommand(text)
 		if err != nil {
-			fmt.Printf("Received error from parsing command: %s\n", err)
+			fmt.Fprintf(recver.out, "Received error from parsing command: %s\n", err)
 			continue
 		}
 		if !acceptedCommands.ContainsCommand(cmd.GetCommandType()) {
-			fmt.Printf("Unacceptable command %s\n", cmd.GetCommandType())
+			fmt.Fprintf(recver.out, "Unacceptable command %s\n", cmd.GetCommandType())
 			continue
 		}
 		return cmd, nil
@@ -69,7 +74,9 @@ func resolveCommand(cmdStr string) string {
 	return cmdStr
 }
 
-func parseCommand(input string) (*Command, error) {
+// ParseCommand parses a command in the console syntax, e.g. "discard 3" or "c 1 2". This is the
+// inverse of Command.String.
+func ParseCommand(input string) (*Command, error) {
 	fields := strings.Fields(input)
 	if len(fields) < 1 {
 		return nil, fmt.Errorf("Fewer than 1 field in input")
@@ -84,6 +91,8 @@ func parseCommand(input string) (*Command, error) {
 		return NewShowDiscardedTilesCommand(), nil
 	case ShowMelded:
 		return NewShowMeldedCommand(), nil
+	case Hint:
+		return NewHintCommand(), nil
 	case DiscardTile:
 		if len(args) < 1 {
 			return nil, fmt.Errorf("Not enough args for DiscardTile")
@@ -138,6 +147,17 @@ func parseCommand(input string) (*Command, error) {
 		return NewPassCommand(), nil
 	case Out:
 		return NewOutCommand(), nil
+	case RobKong:
+		return NewRobKongCommand(), nil
+	case Riichi:
+		if len(args) < 1 {
+			return nil, fmt.Errorf("Not enough args for Riichi")
+		}
+		index, err := strconv.Atoi(args[0])
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("Invalid arg for Riichi: %s", args[0])
+		}
+		return NewRiichiCommand(index), nil
 	}
 	return nil, fmt.Errorf("Unrecognized command %s", cmdStr)
 }
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+// scriptCommentPrefix starts a comment line in a command script.
+const scriptCommentPrefix = "#"
+
+// ScriptedCommandReceiver returns the commands of a fixed script in order, e.g. for automated
+// games. Unlike ConsoleCommandReceiver, it does not re-prompt: an unaccepted command or the end of
+// the script results in an error, which is also kept for inspection after the game.
+type ScriptedCommandReceiver struct {
+	commands []*Command
+	// next is the index of the next command to return.
+	next int
+	// err is the first error returned by PromptForCommand.
+	err error
+}
+
+// NewScriptedCommandReceiver creates a new ScriptedCommandReceiver with the given commands.
+func NewScriptedCommandReceiver(commands []*Command) *ScriptedCommandReceiver {
+	return &ScriptedCommandReceiver{commands: commands}
+}
+
+// NewScriptedCommandReceiverFromReader creates a new ScriptedCommandReceiver with the commands read
+// from the given reader, one command per line in the syntax accepted by ParseCommand. Empty lines
+// and lines starting with '#' are ignored.
+func NewScriptedCommandReceiverFromReader(r io.Reader) (*ScriptedCommandReceiver, error) {
+	var commands []*Command
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, scriptCommentPrefix) {
+			continue
+		}
+		cmd, err := ParseCommand(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse line %d of script", lineNumber)
+		}
+		commands = append(commands, cmd)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to read script")
+	}
+	return NewScriptedCommandReceiver(commands), nil
+}
+
+// PromptForCommand ... (CommandReceiver implementation)
+func (recver *ScriptedCommandReceiver) PromptForCommand(
+	acceptedCommands CommandTypes) (*Command, error) {
+	if recver.err != nil {
+		return nil, recver.err
+	}
+	if recver.next >= len(recver.commands) {
+		recver.err = fmt.Errorf("Script ended after %d commands, expected one of [%s]",
+			len(recver.commands), strings.Join(acceptedCommands, "|"))
+		return nil, recver.err
+	}
+	cmd := recver.commands[recver.next]
+	if !acceptedCommands.ContainsCommand(cmd.GetCommandType()) {
+		recver.err = fmt.Errorf("Unacceptable command %d '%s', expected one of [%s]",
+			recver.next+1, cmd, strings.Join(acceptedCommands, "|"))
+		return nil, recver.err
+	}
+	recver.next++
+	return cmd, nil
+}
+
+// Err returns the first error returned by PromptForCommand, if any.
+func (recver *ScriptedCommandReceiver) Err() error {
+	return recver.err
+}
+
+// NumRemainingCommands returns the number of commands that have not been returned yet.
+func (recver *ScriptedCommandReceiver) NumRemainingCommands() int {
+	return len(recver.commands) - recver.next
+}
+
+// Verify returns an error if the script did not run to completion, i.e. if PromptForCommand
+// returned an error or some commands were not returned.
+func (recver *ScriptedCommandReceiver) Verify() error {
+	if recver.err != nil {
+		return recver.err
+	}
+	if remaining := recver.NumRemainingCommands(); remaining > 0 {
+		return fmt.Errorf("%d commands of the script were not used, starting with '%s'",
+			remaining, recver.commands[recver.next])
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func Test_ScriptedCommandReceiver_FromReader(t *testing.T) {
+	receiver, err := NewScriptedCommandReceiverFromReader(strings.NewReader(`
+		# Comments and empty lines are ignored.
+		d 3
+
+		chow 5 2
+		pass
+	`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, receiver.NumRemainingCommands())
+
+	cmd, err := receiver.PromptForCommand(CommandTypes{DiscardTile})
+	require.NoError(t, err)
+	assert.Equal(t, "discard 3", cmd.String())
+	cmd, err = receiver.PromptForCommand(CommandTypes{Chow, Pass})
+	require.NoError(t, err)
+	assert.Equal(t, "chow 2 5", cmd.String())
+	assert.Error(t, receiver.Verify())
+
+	cmd, err = receiver.PromptForCommand(CommandTypes{Chow, Pass})
+	require.NoError(t, err)
+	assert.Equal(t, Pass, cmd.GetCommandType())
+	assert.NoError(t, receiver.Verify())
+
+	// The end of the script is an error.
+	_, err = receiver.PromptForCommand(CommandTypes{DiscardTile})
+	assert.Error(t, err)
+	assert.Error(t, receiver.Verify())
+}
+
+func Test_ScriptedCommandReceiver_UnacceptedCommand(t *testing.T) {
+	receiver := NewScriptedCommandReceiver([]*Command{NewPongCommand(), NewPassCommand()})
+	_, err := receiver.PromptForCommand(CommandTypes{DiscardTile})
+	assert.Error(t, err)
+	// The receiver does not recover from an error.
+	_, err = receiver.PromptForCommand(CommandTypes{Pong})
+	assert.Error(t, err)
+	assert.Equal(t, err, receiver.Err())
+}
+
+func Test_ScriptedCommandReceiver_InvalidScript(t *testing.T) {
+	_, err := NewScriptedCommandReceiverFromReader(strings.NewReader("discard x\n"))
+	assert.Error(t, err)
+}